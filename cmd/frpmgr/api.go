@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/samber/lo"
+
+	"github.com/koho/frpmgr/pkg/api"
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/util"
+	"github.com/koho/frpmgr/services"
+)
+
+func cmdAPI(args []string) error {
+	fs := newFlagSet("api")
+	addr := fs.String("addr", "", "The loopback `address` to listen on (default "+api.DefaultAddr+").")
+	withMetrics := fs.Bool("metrics", false, "Enable the Prometheus endpoint /metrics.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	switch fs.Arg(0) {
+	case "install":
+		var app config.App
+		if _, err := config.UnmarshalAppConf(profiles.AppFile(), &app); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		app.API.Enabled = true
+		app.API.Metrics = *withMetrics
+		if *addr != "" {
+			app.API.Addr = *addr
+		}
+		if app.API.Token == "" {
+			token, err := util.RandToken(32)
+			if err != nil {
+				return err
+			}
+			app.API.Token = token
+		}
+		if err := app.Save(profiles.AppFile()); err != nil {
+			return err
+		}
+		if err := services.InstallManagerService(profiles.Root()); err != nil {
+			return err
+		}
+		fmt.Println("Address:", lo.Ternary(app.API.Addr != "", app.API.Addr, api.DefaultAddr))
+		fmt.Println("Token:", app.API.Token)
+		return nil
+	case "uninstall":
+		var app config.App
+		if _, err := config.UnmarshalAppConf(profiles.AppFile(), &app); err == nil {
+			app.API.Enabled = false
+			if err = app.Save(profiles.AppFile()); err != nil {
+				return err
+			}
+		}
+		// The service keeps running the schedules without the API
+		if profiles.Scheduled() {
+			return services.InstallManagerService(profiles.Root())
+		}
+		return services.UninstallManagerService()
+	default:
+		fs.Usage()
+		return flag.ErrHelp
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"archive/zip"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/samber/lo"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/profile"
+	"github.com/koho/frpmgr/services"
+)
+
+func cmdBackup(args []string) error {
+	fs := newFlagSet("backup")
+	keep := fs.Int("keep", 0, "Keep at most `n` backups in the directory (default "+strconv.Itoa(profile.DefaultBackupRetention)+").")
+	schedule := fs.Int("schedule", -1, "Back up to the directory every `hours`, or disable scheduled backups with 0.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	target := appConf.Backup.Dir
+	if fs.NArg() == 1 {
+		target = userPath(fs.Arg(0))
+	}
+	if *schedule == 0 {
+		appConf.Backup = config.BackupConfig{}
+		if err := appConf.Save(profiles.AppFile()); err != nil {
+			return err
+		}
+		return services.UpdateManagerService(profiles)
+	}
+	if target == "" {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	if *schedule > 0 {
+		appConf.Backup = config.BackupConfig{Dir: target, Interval: *schedule, Keep: *keep}
+		if err := appConf.Save(profiles.AppFile()); err != nil {
+			return err
+		}
+		// Backups are written by the management service
+		if err := services.UpdateManagerService(profiles); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "backing up to %s every %d hours\n", target, *schedule)
+		return nil
+	}
+	// A file is written as it is, and a directory keeps the latest backups
+	if info, err := os.Stat(target); fs.NArg() == 0 || (err == nil && info.IsDir()) {
+		name, err := profiles.BackupTo(target, lo.Ternary(*keep > 0, *keep, appConf.Backup.Keep))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stdout, name)
+		return nil
+	}
+	f, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if err = profiles.Backup(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func cmdRestore(args []string) error {
+	fs := newFlagSet("restore")
+	noServices := fs.Bool("no-services", false, "Do not start the services of configs that start at boot.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	zr, err := zip.OpenReader(userPath(fs.Arg(0)))
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	list, err := profiles.RestoreBackup(&zr.Reader, os.Getenv(passwordEnv))
+	if err != nil {
+		return err
+	}
+	for _, conf := range list {
+		fmt.Fprintf(os.Stdout, "restored %s (%s)\n", conf.Name(), conf.ID())
+	}
+	if *noServices {
+		return nil
+	}
+	return services.InstallRestoredServices(profiles.Root(), list)
+}
+
+func cmdLocation(args []string) error {
+	fs := newFlagSet("location")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	if fs.NArg() == 0 {
+		path, err := os.Executable()
+		if err != nil {
+			return err
+		}
+		loc, err := config.LoadLocation(filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "%s: %s\n", loc, profiles.Root())
+		return nil
+	}
+	loc := config.DataLocation(fs.Arg(0))
+	if !slices.Contains(config.Locations, loc) {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	repo, err := services.MoveDataRoot(profiles, loc)
+	if repo != nil {
+		fmt.Fprintf(os.Stdout, "moved to %s\n", repo.Root())
+	}
+	return err
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/consts"
+	"github.com/koho/frpmgr/pkg/profile"
+	"github.com/koho/frpmgr/pkg/util"
+	"github.com/koho/frpmgr/services"
+)
+
+// passwordEnv is the environment variable holding the master password, which opens the vault
+// of secrets. It's also the password of the backup to restore.
+const passwordEnv = "FRPMGR_PASSWORD"
+
+// cliCommand is a headless subcommand which manages configs without the GUI.
+type cliCommand struct {
+	// Arguments shown in the usage line.
+	args string
+	// Short description of the command.
+	desc string
+	run  func(args []string) error
+}
+
+var (
+	cliCommands map[string]cliCommand
+	// workDir is the working directory of the calling shell.
+	// Relative paths given by users are resolved against it.
+	workDir  string
+	appConf  config.App
+	profiles *profile.Repository
+)
+
+func init() {
+	cliCommands = map[string]cliCommand{
+		"list":          {"[--json]", "List all configs.", cmdList},
+		"show":          {"[--json] <name>", "Show the details of a config.", cmdShow},
+		"import":        {"[--json] [--dry-run] [--subscribe [--interval duration] [--policy policy]] [--header header]... [--token-file file] [--sha256 hex] [--max-size bytes] [--proxy url] [--retries n] [--passphrase-file file] [--signer key] [--strategy strategy] [--app] [--stores] <file|url|zip|link>...", "Import configs from files, URLs, ZIP archives or share links.", cmdImport},
+		"share":         {"[--proxy names] [--visitor] [--redact policy] [--raw | -o file | --passphrase-file file --sign-key file --expires date] <name>...", "Share configs or some of their proxies by a link, a config or a ZIP file.", cmdShare},
+		"diff":          {"[--json] <name|file> <name|file>", "Show the semantic differences between two configs.", cmdDiff},
+		"export":        {"[--plain] [-o file [--app] [--stores]] [name]...", "Export configs to a ZIP file, or print a single config.", cmdExport},
+		"start":         {"<name>...", "Start the service of configs.", cmdStart},
+		"stop":          {"<name>...", "Stop the service of configs.", cmdStop},
+		"reload":        {"<name>...", "Hot-reload the running service of configs.", cmdReload},
+		"delete":        {"<name>...", "Delete configs with their services and logs.", cmdDelete},
+		"rename":        {"<name> <new-name>", "Rename a config, and move its files and service to a file name derived from the new name.", cmdRename},
+		"validate":      {"[--json] <name|file>...", "Validate configs.", cmdValidate},
+		"check":         {"[--json] [name]...", "Check for ports and domains claimed by more than one config.", cmdCheck},
+		"history":       {"[--json] <name> [diff <from> <to> | restore <rev>]", "List, compare or restore the revisions of a config.", cmdHistory},
+		"api":           {"[--addr address] [--metrics] <install|uninstall>", "Install or uninstall the management API service.", cmdAPI},
+		"subscribe":     {"[--interval duration] [--policy merge|override] <name> <url>", "Refresh a config from its source URL on a schedule.", cmdSubscribe},
+		"unsubscribe":   {"<name>...", "Stop refreshing configs from their source URLs.", cmdUnsubscribe},
+		"refresh":       {"[--json] [--due] [name]...", "Refresh subscribed configs from their source URLs now.", cmdRefresh},
+		"subscriptions": {"[--json] [name]", "List subscribed configs, or show the refresh history of a config.", cmdSubscriptions},
+		"backup":        {"[--keep n] [--schedule hours] [file|dir]", "Back up all configs, logs, store files and settings, or schedule backups to a directory.", cmdBackup},
+		"restore":       {"[--no-services] <file>", "Restore a backup and start the services of configs that start at boot.", cmdRestore},
+		"location":      {"[portable|machine|user]", "Show the data directory, or move all data to another location.", cmdLocation},
+	}
+}
+
+// printCommands writes the usage of all commands to w.
+func printCommands(w io.Writer) {
+	names := lo.Keys(cliCommands)
+	sort.Strings(names)
+	fmt.Fprintln(w, "Commands:")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, name := range names {
+		cmd := cliCommands[name]
+		fmt.Fprintf(tw, "  %s %s\t%s\n", name, cmd.args, cmd.desc)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\nThe master password is read from %s when secrets are encrypted.\n", passwordEnv)
+}
+
+// runCLI executes the given command line and returns the exit code.
+func runCLI(args []string) int {
+	attachConsole()
+	cmd, ok := cliCommands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+		printCommands(os.Stderr)
+		return 2
+	}
+	var err error
+	if workDir, err = os.Getwd(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	// Relative paths in configs are resolved against the data root, as in services.
+	root, err := config.DataRoot()
+	if err == nil {
+		err = os.Chdir(root)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	profiles = profile.NewRepository(root, &appConf)
+	if _, err = config.UnmarshalAppConf(profiles.AppFile(), &appConf); err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	vault, err := appConf.OpenVault(os.Getenv(passwordEnv))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: failed to open vault: %v (set %s to the master password)\n", err, passwordEnv)
+		return 1
+	}
+	// Credentials written to the application configuration by hand are sealed
+	sealed, err := appConf.SealSecrets(vault)
+	if err == nil && sealed {
+		err = appConf.Save(profiles.AppFile())
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error: failed to seal download credentials:", err)
+		return 1
+	}
+	profiles.SetVault(vault)
+	if err = cmd.run(args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+		return 1
+	}
+	return 0
+}
+
+// newFlagSet creates a flag set of the given command. The usage message is printed to stderr.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() {
+		cmd := cliCommands[name]
+		fmt.Fprintf(fs.Output(), "Usage: frpmgr %s %s\n\n%s\n", name, cmd.args, cmd.desc)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// userPath returns the absolute path of a path given in command line.
+func userPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(workDir, path)
+}
+
+// findConf returns the config with the given identifier or name.
+func findConf(cfgList []*profile.Profile, key string) (*profile.Profile, error) {
+	if i := slices.IndexFunc(cfgList, func(conf *profile.Profile) bool {
+		return conf.ID() == key
+	}); i >= 0 {
+		return cfgList[i], nil
+	}
+	matches := lo.Filter(cfgList, func(conf *profile.Profile, i int) bool {
+		return conf.Name() == key
+	})
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("config %q not found", key)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("config name %q is ambiguous, use the identifier instead", key)
+	}
+}
+
+// loadConfig loads the config file at the given path, or the config with the given identifier or name.
+func loadConfig(key string) (*config.ClientConfig, error) {
+	if path := userPath(key); util.FileExists(path) {
+		return config.UnmarshalClientConf(path)
+	}
+	cfgList, err := findConfs([]string{key})
+	if err != nil {
+		return nil, err
+	}
+	return cfgList[0].Data, nil
+}
+
+// findConfs loads all configs and returns the configs matching the given keys.
+func findConfs(keys []string) ([]*profile.Profile, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no config specified")
+	}
+	cfgList, err := profiles.List()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*profile.Profile, 0, len(keys))
+	for _, key := range keys {
+		conf, err := findConf(cfgList, key)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, conf)
+	}
+	return result, nil
+}
+
+// confState returns the service state of the given config.
+func confState(conf *profile.Profile) consts.ConfigState {
+	if _, pid, err := services.QueryStartInfo(conf.Path); err == nil && pid > 0 {
+		return consts.ConfigStateStarted
+	}
+	return consts.ConfigStateStopped
+}
+
+func stateText(state consts.ConfigState) string {
+	switch state {
+	case consts.ConfigStateStarted:
+		return "started"
+	case consts.ConfigStateStopped:
+		return "stopped"
+	case consts.ConfigStateStarting:
+		return "starting"
+	case consts.ConfigStateStopping:
+		return "stopping"
+	default:
+		return "unknown"
+	}
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// confInfo is the summary of a config.
+type confInfo struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Format      string `json:"format"`
+	State       string `json:"state"`
+	Server      string `json:"server"`
+	ManualStart bool   `json:"manualStart"`
+	Proxies     int    `json:"proxies"`
+	Expiry      string `json:"expiry,omitempty"`
+}
+
+// proxyInfo is the summary of a proxy.
+type proxyInfo struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Role       string `json:"role,omitempty"`
+	LocalAddr  string `json:"localAddr,omitempty"`
+	RemotePort string `json:"remotePort,omitempty"`
+	Domains    string `json:"domains,omitempty"`
+	Plugin     string `json:"plugin,omitempty"`
+	Disabled   bool   `json:"disabled"`
+}
+
+func newConfInfo(conf *profile.Profile) confInfo {
+	info := confInfo{
+		ID:          conf.ID(),
+		Name:        conf.Name(),
+		Path:        conf.Path,
+		Format:      strings.TrimPrefix(conf.Data.Ext(), "."),
+		State:       stateText(confState(conf)),
+		Server:      conf.Data.ServerAddress + ":" + strconv.Itoa(conf.Data.ServerPort),
+		ManualStart: conf.Data.ManualStart,
+		Proxies:     len(conf.Data.Proxies),
+	}
+	if path, err := filepath.Abs(conf.Path); err == nil {
+		info.Path = path
+	}
+	if d, err := config.Expiry(conf.Path, conf.Data.AutoDelete); err == nil {
+		info.Expiry = time.Now().Add(d).Format(time.RFC3339)
+	}
+	return info
+}
+
+func newProxyInfo(proxy *config.Proxy) proxyInfo {
+	info := proxyInfo{
+		Name:       proxy.Name,
+		Type:       proxy.Type,
+		Role:       proxy.Role,
+		RemotePort: proxy.RemotePort,
+		Domains:    strings.Join(lo.Compact([]string{proxy.SubDomain, proxy.CustomDomains}), ","),
+		Plugin:     proxy.Plugin,
+		Disabled:   proxy.Disabled,
+	}
+	if proxy.IsVisitor() {
+		info.LocalAddr = proxy.BindAddr
+		if proxy.BindPort != 0 {
+			info.LocalAddr += ":" + strconv.Itoa(proxy.BindPort)
+		}
+	} else if proxy.LocalPort != "" {
+		info.LocalAddr = util.GetOrElse(proxy.LocalIP, "127.0.0.1") + ":" + proxy.LocalPort
+	}
+	return info
+}
@@ -28,6 +28,8 @@ func info(title string, format string, v ...interface{}) {
 
 var (
 	confPath    string
+	dataRoot    string
+	runAPI      bool
 	showVersion bool
 	showHelp    bool
 	flagOutput  strings.Builder
@@ -35,6 +37,8 @@ var (
 
 func init() {
 	flag.StringVar(&confPath, "c", "", "The path to config `file` (Service-only).")
+	flag.StringVar(&dataRoot, "root", "", "The data root `directory` (Service-only).")
+	flag.BoolVar(&runAPI, "api", false, "Serve the management API (Service-only).")
 	flag.BoolVar(&showVersion, "v", false, "Display version information.")
 	flag.BoolVar(&showHelp, "h", false, "Show help information.")
 	flag.CommandLine.SetOutput(&flagOutput)
@@ -44,6 +48,8 @@ func init() {
 func main() {
 	if showHelp {
 		flag.Usage()
+		flagOutput.WriteString("\n")
+		printCommands(&flagOutput)
 		info(ui.AppLocalName, flagOutput.String())
 		return
 	}
@@ -55,16 +61,25 @@ func main() {
 		}, "\n"))
 		return
 	}
+	if flag.NArg() > 0 {
+		os.Exit(runCLI(flag.Args()))
+	}
 	inService, err := svc.IsWindowsService()
 	if err != nil {
 		fatal(err)
 	}
 	if inService {
+		if runAPI {
+			if err = services.RunManager(dataRoot); err != nil {
+				fatal(err)
+			}
+			return
+		}
 		if confPath == "" {
 			os.Exit(1)
 			return
 		}
-		if err = services.Run(confPath); err != nil {
+		if err = services.Run(dataRoot, confPath); err != nil {
 			fatal(err)
 		}
 	} else {
@@ -0,0 +1,881 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/consts"
+	"github.com/koho/frpmgr/pkg/profile"
+	"github.com/koho/frpmgr/pkg/sharelink"
+	"github.com/koho/frpmgr/pkg/util"
+	"github.com/koho/frpmgr/services"
+)
+
+func cmdList(args []string) error {
+	fs := newFlagSet("list")
+	jsonOutput := fs.Bool("json", false, "Print the result in JSON format.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfgList, err := profiles.List()
+	if err != nil {
+		return err
+	}
+	infos := lo.Map(cfgList, func(conf *profile.Profile, i int) confInfo {
+		return newConfInfo(conf)
+	})
+	if *jsonOutput {
+		return printJSON(infos)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tSTATE\tSERVER\tPROXIES\tSTART")
+	for _, info := range infos {
+		start := "auto"
+		if info.ManualStart {
+			start = "manual"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\n", info.ID, info.Name, info.State, info.Server, info.Proxies, start)
+	}
+	return tw.Flush()
+}
+
+func cmdShow(args []string) error {
+	fs := newFlagSet("show")
+	jsonOutput := fs.Bool("json", false, "Print the result in JSON format.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	cfgList, err := findConfs(fs.Args())
+	if err != nil {
+		return err
+	}
+	conf := cfgList[0]
+	info := struct {
+		confInfo
+		Protocol string      `json:"protocol"`
+		User     string      `json:"user,omitempty"`
+		LogFile  string      `json:"logFile,omitempty"`
+		Proxies  []proxyInfo `json:"proxies"`
+	}{
+		confInfo: newConfInfo(conf),
+		Protocol: util.GetOrElse(conf.Data.Protocol, consts.ProtoTCP),
+		User:     conf.Data.User,
+		LogFile:  conf.Data.LogFile,
+		Proxies: lo.Map(conf.Data.Proxies, func(proxy *config.Proxy, i int) proxyInfo {
+			return newProxyInfo(proxy)
+		}),
+	}
+	if *jsonOutput {
+		return printJSON(info)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, item := range [][2]string{
+		{"ID", info.ID},
+		{"Name", info.Name},
+		{"Path", info.Path},
+		{"Format", info.Format},
+		{"State", info.State},
+		{"Server", info.Server},
+		{"Protocol", info.Protocol},
+		{"User", info.User},
+		{"Manual Start", strconv.FormatBool(info.ManualStart)},
+		{"Expiry", info.Expiry},
+		{"Log File", info.LogFile},
+	} {
+		if item[1] != "" {
+			fmt.Fprintf(tw, "%s:\t%s\n", item[0], item[1])
+		}
+	}
+	if err = tw.Flush(); err != nil {
+		return err
+	}
+	if len(info.Proxies) == 0 {
+		return nil
+	}
+	fmt.Fprintln(os.Stdout)
+	tw = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROXY\tTYPE\tLOCAL\tREMOTE\tPLUGIN\tENABLED")
+	for _, p := range info.Proxies {
+		remote := util.GetOrElse(p.RemotePort, p.Domains)
+		if p.Role != "" {
+			remote = p.Role
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%t\n", p.Name, p.Type, p.LocalAddr, remote, p.Plugin, !p.Disabled)
+	}
+	return tw.Flush()
+}
+
+// importSource reads configs from a file, URL or ZIP archive.
+// It returns a list of config files with their names, or the bundle if the source is a ZIP archive.
+func importSource(ctx context.Context, source string, dl *downloadFlags) (files map[string][]byte, bundle *profile.Bundle, err error) {
+	var filename string
+	var data []byte
+	zipped := false
+	if isURL(source) {
+		opts, err := dl.options(source)
+		if err != nil {
+			return nil, nil, err
+		}
+		result, err := util.Download(ctx, source, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		filename, data = result.Filename, result.Data
+		zipped = result.MediaType == "application/zip"
+	} else {
+		content, err := os.ReadFile(userPath(source))
+		if err != nil {
+			return nil, nil, err
+		}
+		filename, data = filepath.Base(source), content
+	}
+	if !zipped && strings.ToLower(filepath.Ext(filename)) != ".zip" {
+		return map[string][]byte{filename: data}, nil, nil
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("the file %q is not a valid ZIP file", source)
+	}
+	bundle, err = profile.OpenBundle(zr)
+	return nil, bundle, err
+}
+
+// downloadFlags override the download options of the application for URL imports.
+type downloadFlags struct {
+	header    headerFlag
+	tokenFile string
+	sha256    string
+	maxSize   int64
+	proxy     string
+	retries   int
+}
+
+// headerFlag is a repeatable flag of HTTP headers in the form of "Name: value".
+type headerFlag http.Header
+
+func (h headerFlag) String() string {
+	return ""
+}
+
+func (h headerFlag) Set(s string) error {
+	k, v, ok := strings.Cut(s, ":")
+	if !ok || strings.TrimSpace(k) == "" {
+		return errors.New("header must be in the form of \"Name: value\"")
+	}
+	http.Header(h).Add(strings.TrimSpace(k), strings.TrimSpace(v))
+	return nil
+}
+
+func newDownloadFlags(fs *flag.FlagSet) *downloadFlags {
+	dl := &downloadFlags{header: make(headerFlag)}
+	fs.Var(dl.header, "header", "Send the `header` \"Name: value\" to URLs. It can be repeated.")
+	fs.StringVar(&dl.tokenFile, "token-file", "", "Send the bearer token in `file` to URLs.")
+	fs.StringVar(&dl.sha256, "sha256", "", "Require the SHA-256 checksum `hex` of the downloaded files. It can also be given by the URL fragment \"#sha256=hex\".")
+	fs.Int64Var(&dl.maxSize, "max-size", 0, "Limit the size of downloaded files to `bytes` (default "+strconv.Itoa(util.DefaultMaxDownloadSize)+").")
+	fs.StringVar(&dl.proxy, "proxy", "", "Download through the proxy `url`, or \""+util.ProxyDirect+"\" to connect directly (default system proxy).")
+	fs.IntVar(&dl.retries, "retries", 0, "Retry `n` times after network or server errors.")
+	return dl
+}
+
+// options returns the download options of the URL. The flags take precedence over the
+// download settings of the application.
+func (dl *downloadFlags) options(url string) (util.DownloadOptions, error) {
+	opts, err := appConf.Download.Options(url, profiles.Vault())
+	if err != nil {
+		return opts, err
+	}
+	if len(dl.header) > 0 {
+		if opts.Header == nil {
+			opts.Header = make(http.Header)
+		}
+		for k, v := range dl.header {
+			opts.Header[k] = v
+		}
+	}
+	if dl.tokenFile != "" {
+		b, err := os.ReadFile(userPath(dl.tokenFile))
+		if err != nil {
+			return opts, err
+		}
+		opts.Token = strings.TrimSpace(string(b))
+	}
+	if dl.sha256 != "" {
+		opts.SHA256 = dl.sha256
+	}
+	if dl.maxSize > 0 {
+		opts.MaxSize = dl.maxSize
+	}
+	if dl.proxy != "" {
+		opts.Proxy = dl.proxy
+	}
+	if dl.retries > 0 {
+		opts.Retries = dl.retries
+	}
+	return opts, nil
+}
+
+// isURL reports whether the import source is an HTTP URL.
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// importPreview is the change made by importing a config.
+type importPreview struct {
+	Name string `json:"name"`
+	// Existing is the identifier of the existing config with the same name.
+	Existing string       `json:"existing,omitempty"`
+	Diff     *config.Diff `json:"diff,omitempty"`
+}
+
+// importResult is the result of importing a config file.
+type importResult struct {
+	*confInfo
+	File   string               `json:"file"`
+	Name   string               `json:"name"`
+	Status profile.ImportStatus `json:"status"`
+	Error  string               `json:"error,omitempty"`
+}
+
+func previewImport(cfgList []*profile.Profile, conf *config.ClientConfig) importPreview {
+	preview := importPreview{Name: conf.Name()}
+	if existing, err := findConf(cfgList, conf.Name()); err == nil {
+		preview.Existing = existing.ID()
+		preview.Diff = config.DiffConfigs(existing.Data, conf)
+	}
+	return preview
+}
+
+func cmdImport(args []string) error {
+	fs := newFlagSet("import")
+	jsonOutput := fs.Bool("json", false, "Print the result in JSON format.")
+	dryRun := fs.Bool("dry-run", false, "Show the differences from existing configs with the same name without importing.")
+	passphraseFile := fs.String("passphrase-file", "", "Read the passphrase of encrypted share links from `file`.")
+	signer := fs.String("signer", "", "Require share links to be signed by the Base64-encoded Ed25519 public `key`.")
+	subscribe := fs.Bool("subscribe", false, "Refresh the configs imported from URLs on a schedule.")
+	strategy := fs.String("strategy", string(profile.ImportDuplicate), "Import a config in a ZIP file as a `duplicate`, or skip or overwrite it, when a config with the same name exists.")
+	withApp := fs.Bool("app", false, "Use the default values in ZIP files for new configs.")
+	withStores := fs.Bool("stores", false, "Restore the store files in ZIP files.")
+	interval, policy := subscriptionFlags(fs)
+	dl := newDownloadFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	switch profile.ImportStrategy(*strategy) {
+	case profile.ImportDuplicate, profile.ImportSkip, profile.ImportOverwrite:
+	default:
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	if err := os.MkdirAll(profiles.Dir(), os.ModePerm); err != nil {
+		return err
+	}
+	var cfgList []*profile.Profile
+	if *dryRun {
+		var err error
+		if cfgList, err = profiles.List(); err != nil {
+			return err
+		}
+	}
+	var imported []importResult
+	var previews []importPreview
+	var errs []error
+	var subscribed bool
+	for _, source := range fs.Args() {
+		var link *sharelink.Link
+		var files map[string][]byte
+		var bundle *profile.Bundle
+		var err error
+		if sharelink.IsLink(source) {
+			if link, err = decodeLink(source, *passphraseFile, *signer); err == nil {
+				files = map[string][]byte{"link": link.Content}
+			}
+		} else {
+			files, bundle, err = importSource(context.Background(), source, dl)
+		}
+		if err == nil && *subscribe && (!isURL(source) || bundle != nil) {
+			err = errors.New("only a config at a URL can be subscribed")
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", source, err))
+			continue
+		}
+		if bundle != nil && *dryRun {
+			for i := range bundle.Manifest.Profiles {
+				entry := &bundle.Manifest.Profiles[i]
+				conf, err := bundle.Config(entry)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", entry.File, err))
+					continue
+				}
+				previews = append(previews, previewImport(cfgList, conf))
+			}
+			continue
+		} else if bundle != nil {
+			results, err := profiles.Import(bundle, profile.ImportOptions{
+				Strategy: profile.ImportStrategy(*strategy),
+				App:      *withApp,
+				Stores:   *withStores,
+			})
+			for _, result := range results {
+				item := importResult{File: result.File, Name: result.Name, Status: result.Status}
+				if result.Profile != nil {
+					info := newConfInfo(result.Profile)
+					item.confInfo = &info
+				}
+				if result.Err != nil {
+					item.Error = result.Err.Error()
+					errs = append(errs, fmt.Errorf("%s: %w", result.File, result.Err))
+				}
+				imported = append(imported, item)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", source, err))
+			}
+			continue
+		}
+		names := lo.Keys(files)
+		sort.Strings(names)
+		for _, name := range names {
+			conf, err := config.UnmarshalClientConf(files[name])
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				continue
+			}
+			if conf.Name() == "" {
+				conf.ClientCommon.Name = util.FileNameWithoutExt(name)
+			}
+			if link != nil {
+				conf.LimitExpiry(link.Expires)
+			}
+			if *dryRun {
+				previews = append(previews, previewImport(cfgList, conf))
+				continue
+			}
+			p, err := profiles.Create(conf)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				continue
+			}
+			if *subscribe {
+				if err = profiles.Subscribe(p.ID(), profile.Subscription{URL: source, Interval: *interval, Policy: profile.MergePolicy(*policy)}); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				} else {
+					subscribed = true
+				}
+			}
+			info := newConfInfo(p)
+			imported = append(imported, importResult{confInfo: &info, File: name, Name: p.Name(), Status: profile.ImportCreated})
+		}
+	}
+	if *dryRun {
+		if *jsonOutput {
+			if err := printJSON(lo.Ternary(previews == nil, []importPreview{}, previews)); err != nil {
+				return err
+			}
+		} else {
+			for _, preview := range previews {
+				switch {
+				case preview.Existing == "":
+					fmt.Fprintf(os.Stdout, "would import %s as a new config\n", preview.Name)
+				case preview.Diff.Empty():
+					fmt.Fprintf(os.Stdout, "would import %s, same as existing config %s\n", preview.Name, preview.Existing)
+				default:
+					fmt.Fprintf(os.Stdout, "would import %s, differs from existing config %s:\n%s", preview.Name, preview.Existing, preview.Diff)
+				}
+			}
+		}
+	} else if *jsonOutput {
+		if err := printJSON(lo.Ternary(imported == nil, []importResult{}, imported)); err != nil {
+			return err
+		}
+	} else {
+		for _, item := range imported {
+			switch item.Status {
+			case profile.ImportCreated:
+				fmt.Fprintf(os.Stdout, "imported %s (%s)\n", item.Name, item.ID)
+			case profile.ImportOverwritten:
+				fmt.Fprintf(os.Stdout, "overwrote %s (%s)\n", item.Name, item.ID)
+			case profile.ImportSkipped:
+				fmt.Fprintf(os.Stdout, "skipped %s, config %s has the same name\n", item.Name, item.ID)
+			}
+		}
+	}
+	if subscribed {
+		errs = append(errs, services.EnsureManagerService(profiles.Root()))
+	}
+	return errors.Join(errs...)
+}
+
+// decodeLink decodes a share link with the passphrase in the given file.
+// If signer is not empty, the link must be signed by the Base64-encoded public key.
+func decodeLink(source, passphraseFile, signer string) (*sharelink.Link, error) {
+	var passphrase string
+	if passphraseFile != "" {
+		b, err := os.ReadFile(userPath(passphraseFile))
+		if err != nil {
+			return nil, err
+		}
+		passphrase = strings.TrimRight(string(b), "\r\n")
+	}
+	link, err := sharelink.Decode(source, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if signer != "" {
+		key, err := base64.StdEncoding.DecodeString(signer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signer key: %w", err)
+		}
+		if !bytes.Equal(key, link.Signer) {
+			return nil, sharelink.ErrBadSignature
+		}
+	}
+	return link, nil
+}
+
+func cmdShare(args []string) error {
+	fs := newFlagSet("share")
+	proxies := fs.String("proxy", "", "Share only the comma-separated proxy `names`.")
+	visitor := fs.Bool("visitor", false, "Share the visitors of the STCP, XTCP and SUDP proxies instead of the proxies.")
+	redact := fs.String("redact", "none", "Redact secrets by `policy`: none, strip or placeholder.")
+	keepSK := fs.Bool("keep-secret-keys", false, "Keep the secret keys of STCP, XTCP and SUDP proxies when redacting.")
+	raw := fs.Bool("raw", false, "Print the shared config instead of a link.")
+	output := fs.String("o", "", "Write the shared configs to a ZIP `file` instead of a link.")
+	passphraseFile := fs.String("passphrase-file", "", "Encrypt the link with the passphrase in `file`.")
+	signKey := fs.String("sign-key", "", "Sign the link with the Ed25519 private key in a PKCS #8 PEM `file`.")
+	expires := fs.String("expires", "", "The `date` after which the link and the imported config expire, e.g. 2006-01-02. The expiry date of the config is used by default.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 || *output == "" && fs.NArg() != 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	cfgList, err := findConfs(fs.Args())
+	if err != nil {
+		return err
+	}
+	opts := config.ShareOptions{VisitorSide: *visitor, KeepSecretKeys: *keepSK}
+	if *proxies != "" {
+		opts.Proxies = strings.Split(*proxies, ",")
+	}
+	if opts.Redaction, err = config.ParseRedaction(*redact); err != nil {
+		return err
+	}
+	if *output != "" {
+		f, err := os.Create(userPath(*output))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return config.ShareZip(f, lo.Map(cfgList, func(p *profile.Profile, i int) *config.ClientConfig { return p.Data }), opts)
+	}
+	conf := cfgList[0].Data
+	if *raw {
+		shared, err := config.Share(conf, opts)
+		if err != nil {
+			return err
+		}
+		b, err := shared.Marshal()
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(b)
+		return err
+	}
+	var linkOpts sharelink.Options
+	if *passphraseFile != "" {
+		b, err := os.ReadFile(userPath(*passphraseFile))
+		if err != nil {
+			return err
+		}
+		if linkOpts.Passphrase = strings.TrimRight(string(b), "\r\n"); linkOpts.Passphrase == "" {
+			return errors.New("the passphrase is empty")
+		}
+	}
+	if *signKey != "" {
+		if linkOpts.SigningKey, err = readSigningKey(userPath(*signKey)); err != nil {
+			return err
+		}
+	}
+	if *expires != "" {
+		if linkOpts.Expires, err = time.ParseInLocation(time.DateOnly, *expires, time.Local); err != nil {
+			return err
+		}
+	}
+	link, err := config.ShareLink(conf, opts, linkOpts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, link)
+	if linkOpts.SigningKey != nil {
+		fmt.Fprintln(os.Stderr, "signed by:", base64.StdEncoding.EncodeToString(linkOpts.SigningKey.Public().(ed25519.PublicKey)))
+	}
+	return nil
+}
+
+// readSigningKey reads an Ed25519 private key from a PKCS #8 PEM file.
+func readSigningKey(path string) (ed25519.PrivateKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("no PEM data is found in the signing key file")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if k, ok := key.(ed25519.PrivateKey); ok {
+		return k, nil
+	}
+	return nil, errors.New("the signing key is not an Ed25519 key")
+}
+
+func cmdDiff(args []string) error {
+	fs := newFlagSet("diff")
+	jsonOutput := fs.Bool("json", false, "Print the result in JSON format.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	old, err := loadConfig(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	cur, err := loadConfig(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	diff := config.DiffConfigs(old, cur)
+	if *jsonOutput {
+		return printJSON(diff)
+	}
+	fmt.Fprint(os.Stdout, diff)
+	return nil
+}
+
+func cmdExport(args []string) error {
+	fs := newFlagSet("export")
+	output := fs.String("o", "", "Write the configs to a ZIP `file` with a manifest of their order and checksums.")
+	plain := fs.Bool("plain", false, "Decrypt the secrets encrypted by the vault, so the configs can be used by frp directly.")
+	withApp := fs.Bool("app", false, "Include the default values of new configs in the ZIP file.")
+	withStores := fs.Bool("stores", false, "Include the store files of frp in the ZIP file.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	var cfgList []*profile.Profile
+	var err error
+	if fs.NArg() > 0 {
+		cfgList, err = findConfs(fs.Args())
+	} else {
+		cfgList, err = profiles.List()
+	}
+	if err != nil {
+		return err
+	}
+	if *output == "" && len(cfgList) != 1 {
+		return errors.New("an output file is required to export multiple configs")
+	}
+	if *output != "" {
+		f, err := os.Create(userPath(*output))
+		if err != nil {
+			return err
+		}
+		if err = profiles.Export(f, cfgList, profile.ExportOptions{App: *withApp, Stores: *withStores, Plain: *plain}); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+	path := cfgList[0].Path
+	if *plain && profiles.Vault() != nil {
+		tempDir, err := os.MkdirTemp("", "frpmgr")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tempDir)
+		if path, err = profiles.ExportPlain(cfgList[0], tempDir); err != nil {
+			return err
+		}
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(content)
+	return err
+}
+
+func cmdRename(args []string) error {
+	fs := newFlagSet("rename")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 || strings.TrimSpace(fs.Arg(1)) == "" {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	cfgList, err := profiles.List()
+	if err != nil {
+		return err
+	}
+	conf, err := findConf(cfgList, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if name := fs.Arg(1); name != conf.Name() && slices.ContainsFunc(cfgList, func(item *profile.Profile) bool {
+		return item.Name() == name
+	}) {
+		return fmt.Errorf("config %q already exists", name)
+	}
+	oldID := conf.ID()
+	if err = services.RenameProfile(profiles, conf, fs.Arg(1)); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "renamed %s to %s (%s)\n", oldID, conf.Name(), conf.ID())
+	return nil
+}
+
+func cmdDelete(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no config specified")
+	}
+	cfgList, err := profiles.List()
+	if err != nil {
+		return err
+	}
+	for _, key := range args {
+		conf, err := findConf(cfgList, key)
+		if err != nil {
+			return err
+		}
+		running := confState(conf) == consts.ConfigStateStarted
+		if err = services.UninstallService(conf.Path, true); err != nil && running {
+			return fmt.Errorf("%s: %w", conf.Name(), err)
+		}
+		if err = profiles.Delete(conf); err != nil {
+			return err
+		}
+		cfgList = slices.DeleteFunc(cfgList, func(c *profile.Profile) bool { return c == conf })
+		if err = profiles.Reorder(lo.Map(cfgList, func(c *profile.Profile, i int) string { return c.ID() })); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func cmdValidate(args []string) error {
+	fs := newFlagSet("validate")
+	jsonOutput := fs.Bool("json", false, "Print the result in JSON format.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	type result struct {
+		Name        string              `json:"name"`
+		Path        string              `json:"path"`
+		Valid       bool                `json:"valid"`
+		Error       string              `json:"error,omitempty"`
+		Diagnostics []config.Diagnostic `json:"diagnostics,omitempty"`
+	}
+	var cfgList []*profile.Profile
+	results := make([]result, 0, fs.NArg())
+	for _, key := range fs.Args() {
+		r := result{Name: key, Path: userPath(key)}
+		if !util.FileExists(r.Path) {
+			if cfgList == nil {
+				var err error
+				if cfgList, err = profiles.List(); err != nil {
+					return err
+				}
+			}
+			conf, err := findConf(cfgList, key)
+			if err != nil {
+				return err
+			}
+			r.Path = conf.Path
+		}
+		if err := services.VerifyClientConfig(r.Path); err != nil {
+			r.Error = err.Error()
+		} else {
+			r.Valid = true
+		}
+		if conf, err := config.UnmarshalClientConf(r.Path); err == nil {
+			r.Diagnostics = config.Lint(conf)
+			if slices.ContainsFunc(r.Diagnostics, func(d config.Diagnostic) bool {
+				return d.Severity == config.SeverityError
+			}) {
+				r.Valid = false
+			}
+		}
+		results = append(results, r)
+	}
+	if *jsonOutput {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			switch {
+			case r.Error != "":
+				fmt.Fprintf(os.Stdout, "%s: %s\n", r.Name, r.Error)
+			case r.Valid && len(r.Diagnostics) == 0:
+				fmt.Fprintf(os.Stdout, "%s: ok\n", r.Name)
+			default:
+				fmt.Fprintf(os.Stdout, "%s:\n", r.Name)
+			}
+			for _, d := range r.Diagnostics {
+				fmt.Fprintf(os.Stdout, "  %s\n", d)
+			}
+		}
+	}
+	if invalid := lo.CountBy(results, func(r result) bool { return !r.Valid }); invalid > 0 {
+		return fmt.Errorf("%d of %d configs are invalid", invalid, len(results))
+	}
+	return nil
+}
+
+func cmdCheck(args []string) error {
+	fs := newFlagSet("check")
+	jsonOutput := fs.Bool("json", false, "Print the result in JSON format.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfgList, err := profiles.List()
+	if err != nil {
+		return err
+	}
+	conflicts := config.FindConflicts(lo.Map(cfgList, func(conf *profile.Profile, i int) *config.ClientConfig {
+		return conf.Data
+	}))
+	// Only show conflicts of the given configs.
+	if fs.NArg() > 0 {
+		var selected []*profile.Profile
+		for _, key := range fs.Args() {
+			conf, err := findConf(cfgList, key)
+			if err != nil {
+				return err
+			}
+			selected = append(selected, conf)
+		}
+		conflicts = lo.Filter(conflicts, func(c config.Conflict, i int) bool {
+			return lo.SomeBy(selected, func(conf *profile.Profile) bool { return c.Involves(conf.Data) })
+		})
+	}
+	if *jsonOutput {
+		if err = printJSON(lo.Ternary(conflicts != nil, conflicts, []config.Conflict{})); err != nil {
+			return err
+		}
+	} else {
+		for _, c := range conflicts {
+			fmt.Fprintln(os.Stdout, c)
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%d conflicts found", len(conflicts))
+	}
+	return nil
+}
+
+func cmdHistory(args []string) error {
+	fs := newFlagSet("history")
+	jsonOutput := fs.Bool("json", false, "Print the result in JSON format.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	cfgList, err := findConfs(fs.Args()[:1])
+	if err != nil {
+		return err
+	}
+	conf := cfgList[0]
+	revs := make([]int, 0, 2)
+	for _, arg := range fs.Args()[min(fs.NArg(), 2):] {
+		rev, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid revision %q", arg)
+		}
+		revs = append(revs, rev)
+	}
+	switch {
+	case fs.NArg() == 1:
+		list, err := profiles.Revisions(conf.ID())
+		if err != nil {
+			return err
+		}
+		if *jsonOutput {
+			return printJSON(list)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "REV\tTIME\tSUMMARY")
+		for _, rev := range list {
+			fmt.Fprintf(tw, "%d\t%s\t%s\n", rev.ID, rev.Time.Format(time.DateTime), rev.Summary)
+		}
+		return tw.Flush()
+	case fs.Arg(1) == "diff" && len(revs) == 2:
+		diff, err := profiles.DiffRevisions(conf.ID(), revs[0], revs[1])
+		if err != nil {
+			return err
+		}
+		if *jsonOutput {
+			return printJSON(diff)
+		}
+		fmt.Fprint(os.Stdout, diff)
+		return nil
+	case fs.Arg(1) == "restore" && len(revs) == 1:
+		if err = profiles.Restore(conf, revs[0]); err != nil {
+			return err
+		}
+		// Apply the restored config to the running service
+		if confState(conf) == consts.ConfigStateStarted {
+			if err = services.VerifyClientConfig(conf.Path); err != nil {
+				return fmt.Errorf("%s: %w", conf.Name(), err)
+			}
+			return services.ReloadService(conf.Path)
+		}
+		return nil
+	default:
+		fs.Usage()
+		return flag.ErrHelp
+	}
+}
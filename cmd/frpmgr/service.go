@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/koho/frpmgr/services"
+)
+
+func cmdStart(args []string) error {
+	cfgList, err := findConfs(args)
+	if err != nil {
+		return err
+	}
+	for _, conf := range cfgList {
+		if err = services.VerifyClientConfig(conf.Path); err != nil {
+			return fmt.Errorf("%s: %w", conf.Name(), err)
+		}
+		// Ensure log directory is valid
+		if logFile := conf.Data.LogFile; logFile != "" && logFile != "console" {
+			if err = os.MkdirAll(filepath.Dir(logFile), os.ModePerm); err != nil {
+				return err
+			}
+		}
+		if err = services.InstallService(conf.Name(), profiles.Root(), conf.Path, !conf.Data.AutoStart()); err != nil {
+			return fmt.Errorf("%s: %w", conf.Name(), err)
+		}
+	}
+	return nil
+}
+
+func cmdStop(args []string) error {
+	cfgList, err := findConfs(args)
+	if err != nil {
+		return err
+	}
+	for _, conf := range cfgList {
+		if err = services.UninstallService(conf.Path, true); err != nil {
+			return fmt.Errorf("%s: %w", conf.Name(), err)
+		}
+	}
+	return nil
+}
+
+func cmdReload(args []string) error {
+	cfgList, err := findConfs(args)
+	if err != nil {
+		return err
+	}
+	for _, conf := range cfgList {
+		if err = services.VerifyClientConfig(conf.Path); err != nil {
+			return fmt.Errorf("%s: %w", conf.Name(), err)
+		}
+		if err = services.ReloadService(conf.Path); err != nil {
+			return fmt.Errorf("%s: %w", conf.Name(), err)
+		}
+	}
+	return nil
+}
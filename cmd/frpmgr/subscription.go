@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/koho/frpmgr/pkg/consts"
+	"github.com/koho/frpmgr/pkg/profile"
+	"github.com/koho/frpmgr/pkg/util"
+	"github.com/koho/frpmgr/services"
+)
+
+// subscriptionFlags defines the flags of the refresh schedule and merge policy.
+func subscriptionFlags(fs *flag.FlagSet) (interval, policy *string) {
+	interval = fs.String("interval", "", "Refresh every `duration`, e.g. 30m (default "+profile.DefaultRefreshInterval.String()+").")
+	policy = fs.String("policy", string(profile.PolicyMerge), "Keep local edits by \"merge\", or discard them by \"override\".")
+	return
+}
+
+func cmdSubscribe(args []string) error {
+	fs := newFlagSet("subscribe")
+	interval, policy := subscriptionFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	cfgList, err := findConfs(fs.Args()[:1])
+	if err != nil {
+		return err
+	}
+	if err = profiles.Subscribe(cfgList[0].ID(), profile.Subscription{
+		URL:      fs.Arg(1),
+		Interval: *interval,
+		Policy:   profile.MergePolicy(*policy),
+	}); err != nil {
+		return err
+	}
+	// The subscription is refreshed by the management service
+	return services.EnsureManagerService(profiles.Root())
+}
+
+func cmdUnsubscribe(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no config specified")
+	}
+	cfgList, err := findConfs(args)
+	if err != nil {
+		return err
+	}
+	for _, conf := range cfgList {
+		if err = profiles.Unsubscribe(conf.ID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshResult is the result of refreshing a config.
+type refreshResult struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	profile.RefreshEvent
+}
+
+// subscribedConfs returns the configs with the given names, or all subscribed configs
+// if no name is given, with their subscriptions.
+func subscribedConfs(keys []string) ([]*profile.Profile, map[string]*profile.Subscription, error) {
+	subs, err := profiles.Subscriptions()
+	if err != nil {
+		return nil, nil, err
+	}
+	var cfgList []*profile.Profile
+	if len(keys) > 0 {
+		cfgList, err = findConfs(keys)
+	} else {
+		cfgList, err = profiles.List()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, conf := range cfgList {
+		if len(keys) > 0 && subs[conf.ID()] == nil {
+			return nil, nil, fmt.Errorf("%s: %w", conf.Name(), profile.ErrNotSubscribed)
+		}
+	}
+	return slices.DeleteFunc(cfgList, func(conf *profile.Profile) bool {
+		return subs[conf.ID()] == nil
+	}), subs, nil
+}
+
+func cmdRefresh(args []string) error {
+	fs := newFlagSet("refresh")
+	jsonOutput := fs.Bool("json", false, "Print the result in JSON format.")
+	due := fs.Bool("due", false, "Only refresh the configs that are due on their schedules.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfgList, subs, err := subscribedConfs(fs.Args())
+	if err != nil {
+		return err
+	}
+	results := make([]refreshResult, 0)
+	var errs []error
+	now := time.Now()
+	for _, conf := range cfgList {
+		if *due && !subs[conf.ID()].Due(now) {
+			continue
+		}
+		ev, err := profiles.Refresh(context.Background(), conf.ID())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", conf.Name(), err))
+		}
+		results = append(results, refreshResult{ID: conf.ID(), Name: conf.Name(), RefreshEvent: ev})
+		// Apply the changed config to the running service
+		if ev.Status == profile.RefreshUpdated && confState(conf) == consts.ConfigStateStarted {
+			if err = services.VerifyClientConfig(conf.Path); err == nil {
+				err = services.ReloadService(conf.Path)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", conf.Name(), err))
+			}
+		}
+	}
+	if *jsonOutput {
+		if err = printJSON(results); err != nil {
+			return err
+		}
+	} else {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tSTATUS\tMESSAGE")
+		for _, r := range results {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Name, r.Status, r.Message)
+		}
+		if err = tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// subscriptionInfo is the subscription of a config.
+type subscriptionInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	*profile.Subscription
+}
+
+func cmdSubscriptions(args []string) error {
+	fs := newFlagSet("subscriptions")
+	jsonOutput := fs.Bool("json", false, "Print the result in JSON format.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	cfgList, subs, err := subscribedConfs(fs.Args())
+	if err != nil {
+		return err
+	}
+	list := lo.Map(cfgList, func(conf *profile.Profile, i int) subscriptionInfo {
+		return subscriptionInfo{ID: conf.ID(), Name: conf.Name(), Subscription: subs[conf.ID()]}
+	})
+	if fs.NArg() == 1 {
+		info := list[0]
+		if *jsonOutput {
+			return printJSON(info)
+		}
+		fmt.Fprintf(os.Stdout, "Name:\t\t%s\nURL:\t\t%s\nPolicy:\t\t%s\nInterval:\t%s\n\n",
+			info.Name, info.URL, util.GetOrElse(string(info.Policy), string(profile.PolicyMerge)), info.RefreshInterval())
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "TIME\tSTATUS\tMESSAGE")
+		for _, ev := range info.History {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", ev.Time.Format(time.DateTime), ev.Status, ev.Message)
+		}
+		return tw.Flush()
+	}
+	if *jsonOutput {
+		return printJSON(list)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tURL\tLAST REFRESH\tSTATUS")
+	for _, info := range list {
+		last, status := "-", "-"
+		if ev := info.LastRefresh(); ev != nil {
+			last, status = ev.Time.Format(time.DateTime), string(ev.Status)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", info.Name, info.URL, last, status)
+	}
+	return tw.Flush()
+}
@@ -43,411 +43,491 @@ func init() {
 }
 
 var messageKeyToIndex = map[string]int{
-	"%d Files, %s":                               257,
-	"%d succeeded, %d failed.":                   61,
-	"%s Properties":                              263,
-	"* Support batch import, one link per line.": 295,
-	"A selection is required.":                   310,
-	"About":                                      10,
-	"Absolute":                                   94,
-	"Add":                                        22,
-	"Add FTP":                                    272,
-	"Add HTTP File Server":                       274,
-	"Add Proxy Server":                           276,
-	"Add Remote Desktop":                         268,
-	"Add SSH":                                    270,
-	"Add VNC":                                    269,
-	"Add Web":                                    271,
-	"Additional Scopes":                          80,
-	"Admin":                                      87,
-	"Admin Address":                              88,
-	"Advanced":                                   121,
-	"Advanced Options":                           101,
-	"All":                                        42,
-	"All Files":                                  3,
-	"Allow Users":                                150,
+	"%d Files, %s": 314,
+	"%d configs have the same names as existing configs.":           23,
+	"%d succeeded, %d failed.":                                      85,
+	"%s Properties":                                                 320,
+	"%s: overwritten config \"%s\"":                                 72,
+	"%s: skipped, config \"%s\" exists":                             73,
+	"* Append \"#sha256=<checksum>\" to a link to verify the file.": 357,
+	"* Leave the directory empty to disable scheduled backups.":     295,
+	"* Support batch import, one link per line.":                    356,
+	"A selection is required.":                                      372,
+	"About":                                                         10,
+	"Absolute":                                                      124,
+	"Add":                                                           33,
+	"Add FTP":                                                       329,
+	"Add HTTP File Server":                                          331,
+	"Add Proxy Server":                                              333,
+	"Add Remote Desktop":                                            325,
+	"Add SSH":                                                       327,
+	"Add VNC":                                                       326,
+	"Add Web":                                                       328,
+	"Additional Scopes":                                             110,
+	"Admin":                                                         117,
+	"Admin Address":                                                 118,
+	"Advanced":                                                      151,
+	"Advanced Options":                                              131,
+	"All":                                                           55,
+	"All Files":                                                     3,
+	"All data is moved to %s.":                                      288,
+	"All data will be moved, and running configs will be restarted.\nDo you want to continue?": 287,
+	"Allow Users": 182,
 	"An error occurred while checking for a software update.": 16,
-	"Annotations": 138,
-	"Are you sure that you want to delete these %d configs?":  60,
-	"Are you sure that you want to delete these %d proxies?":  287,
-	"Are you sure that you want to disable these %d proxies?": 291,
-	"Are you sure you would like to delete config \"%s\"?":    57,
-	"Are you sure you would like to delete proxy \"%s\"?":     285,
-	"Are you sure you would like to disable proxy \"%s\"?":    289,
-	"Are you sure you would like to stop config \"%s\"?":      226,
-	"Assets":                          90,
-	"Audience":                        77,
-	"Auth":                            69,
-	"Auth Method":                     70,
-	"Auto":                            163,
-	"Auto Delete":                     93,
-	"Automatically check for updates": 247,
-	"Bandwidth":                       161,
-	"Basic":                           64,
-	"Behavior":                        211,
-	"Bind Address":                    151,
-	"Bind Port":                       152,
-	"Bind port is required.":          195,
+	"Annotations": 170,
+	"Are you sure that you want to delete these %d configs?":  84,
+	"Are you sure that you want to delete these %d proxies?":  346,
+	"Are you sure that you want to disable these %d proxies?": 350,
+	"Are you sure you would like to delete config \"%s\"?":    81,
+	"Are you sure you would like to delete proxy \"%s\"?":     344,
+	"Are you sure you would like to disable proxy \"%s\"?":    348,
+	"Are you sure you would like to stop config \"%s\"?":      258,
+	"Assets":                          120,
+	"Audience":                        107,
+	"Auth":                            99,
+	"Auth Method":                     100,
+	"Auto":                            195,
+	"Auto Delete":                     123,
+	"Automatically check for updates": 305,
+	"Back Up":                         275,
+	"Backup":                          273,
+	"Backups":                         294,
+	"Bandwidth":                       193,
+	"Basic":                           94,
+	"Behavior":                        243,
+	"Bind Address":                    183,
+	"Bind Port":                       184,
+	"Bind port is required.":          227,
 	"Built on: %s":                    2,
-	"Cancel":                          19,
-	"Certificate":                     114,
+	"Cancel":                          30,
+	"Certificate":                     144,
 	"Certificate Files":               5,
-	"Certificate Key":                 116,
-	"Change Password":                 235,
-	"Check Interval":                  190,
-	"Check Timeout":                   189,
-	"Check Type":                      188,
+	"Certificate Key":                 146,
+	"Change Password":                 267,
+	"Check Interval":                  222,
+	"Check Timeout":                   221,
+	"Check Type":                      220,
 	"Check for updates":               13,
 	"Checking for updates":            12,
-	"Clear All":                       24,
-	"Client":                          160,
-	"Common Only":                     43,
-	"Compression":                     167,
-	"Config already exists":           133,
-	"Config already removed":          31,
-	"Configuration":                   27,
-	"Configuration Files":             4,
-	"Connection":                      99,
-	"Copy":                            206,
-	"Copy Access Address":             281,
-	"Copy Share Link":                 48,
-	"Copy Value":                      264,
-	"Create a Copy":                   41,
-	"Created":                         261,
-	"Custom Domains":                  156,
-	"Custom domains and subdomain should have at least one of these set.": 205,
-	"Days":                          86,
-	"Default":                       164,
-	"Defaults":                      248,
-	"Delete":                        23,
-	"Delete %d configs":             59,
-	"Delete %d proxies":             286,
-	"Delete %s configs":             30,
-	"Delete Date":                   96,
-	"Delete Days":                   97,
-	"Delete config \"%s\"":          56,
-	"Delete proxy \"%s\"":           284,
-	"Dial Timeout":                  103,
-	"Disable":                       277,
-	"Disable %d proxies":            290,
-	"Disable Assisted Addresses":    168,
-	"Disable auto-start at boot":    125,
-	"Disable custom first byte":     120,
-	"Disable proxy \"%s\"":          288,
-	"Domains":                       278,
-	"Down":                          36,
-	"Download":                      298,
-	"Download updates":              11,
-	"Edit":                          33,
-	"Edit Client - %s":              63,
-	"Edit Proxy - %s":               137,
-	"Enable":                        292,
-	"Encryption":                    166,
-	"Enter Administration Password": 301,
-	"Enter Password":                299,
-	"Error":                         265,
-	"Error message":                 282,
-	"Exit after login failure":      124,
-	"Export All Configs to ZIP":     49,
-	"External Address":              212,
-	"FRP Manager":                   294,
+	"Clear All":                       35,
+	"Client":                          192,
+	"Common Only":                     56,
+	"Compression":                     199,
+	"Config Check":                    354,
+	"Config already exists":           164,
+	"Config already removed":          44,
+	"Configs, logs and settings are stored in:": 286,
+	"Configuration":             38,
+	"Configuration Files":       4,
+	"Conflict":                  39,
+	"Connection":                129,
+	"Copy":                      238,
+	"Copy Access Address":       338,
+	"Copy Encrypted Share Link": 65,
+	"Copy Share Link":           64,
+	"Copy Value":                321,
+	"Copy Visitor Share Link":   340,
+	"Create a Copy":             54,
+	"Created":                   318,
+	"Current user only":         284,
+	"Custom Domains":            188,
+	"Custom domains and subdomain should have at least one of these set.": 237,
+	"Data Location": 285,
+	"Days":          116,
+	"Decrypt secrets, so the configs can be used on other computers": 21,
+	"Default":                    196,
+	"Defaults":                   306,
+	"Delete":                     34,
+	"Delete %d configs":          83,
+	"Delete %d proxies":          345,
+	"Delete %s configs":          43,
+	"Delete Date":                126,
+	"Delete Days":                127,
+	"Delete config \"%s\"":       80,
+	"Delete proxy \"%s\"":        343,
+	"Dial Timeout":               133,
+	"Directory":                  290,
+	"Disable":                    334,
+	"Disable %d proxies":         349,
+	"Disable Assisted Addresses": 200,
+	"Disable auto-start at boot": 156,
+	"Disable custom first byte":  150,
+	"Disable proxy \"%s\"":       347,
+	"Disable the encryption of secrets before removing the master password.": 298,
+	"Domains":          335,
+	"Down":             49,
+	"Download":         361,
+	"Download updates": 11,
+	"Edit":             46,
+	"Edit Client - %s": 93,
+	"Edit Proxy - %s":  169,
+	"Enable":           351,
+	"Encrypt secrets in configs with master password": 268,
+	"Encryption":                    198,
+	"Enter Administration Password": 364,
+	"Enter Password":                362,
+	"Error":                         322,
+	"Error message":                 341,
+	"Exit after login failure":      155,
+	"Export All Configs to ZIP":     18,
+	"External Address":              244,
+	"FRP Manager":                   353,
 	"FRP version: %s":               1,
-	"Failure Count":                 191,
-	"Fallback":                      169,
-	"File":                          73,
-	"File Format":                   254,
+	"Failed":                        91,
+	"Failure Count":                 223,
+	"Fallback":                      201,
+	"File":                          103,
+	"File Format":                   153,
 	"For FRP configuration documentation, please visit the FRP project page:": 15,
 	"For comments or to report bugs, please visit the project page:":          14,
-	"General":                                246,
-	"Group":                                  185,
-	"Group Key":                              186,
-	"HTTP File Server":                       273,
-	"HTTP Password":                          175,
-	"HTTP User":                              174,
-	"Health Check":                           187,
-	"Health check url is required.":          201,
-	"Heart Beats":                            81,
-	"Heartbeat":                              108,
-	"Host Name":                              113,
-	"Host Rewrite":                           176,
-	"Identifier":                             252,
-	"Idle Timeout":                           105,
-	"Import Config":                          44,
-	"Import from Clipboard":                  46,
-	"Import from File":                       29,
-	"Import from URL":                        45,
-	"Imported %d of %d configs.":             54,
-	"Interval":                               109,
-	"Invalid Input":                          303,
-	"Invalid local port.":                    200,
-	"Invalid remote port.":                   203,
-	"Item":                                   209,
-	"Keep Tunnel":                            165,
-	"Keepalive":                              104,
-	"Key Files":                              6,
-	"Languages":                              236,
-	"Latest":                                 208,
-	"Level":                                  84,
-	"Load Balance":                           184,
-	"Local Address":                          147,
-	"Local Directory":                        228,
-	"Local Path":                             181,
-	"Local Port":                             148,
-	"Local address is required.":             197,
-	"Local path is required.":                198,
-	"Locations":                              157,
-	"Log":                                    83,
-	"Log Level":                              249,
-	"Log retention":                          250,
-	"Manual":                                 251,
-	"Manual Settings":                        53,
-	"Master password":                        232,
-	"Max Days":                               85,
-	"Max Streams":                            107,
-	"Metadata":                               127,
-	"Modified":                               262,
-	"Move":                                   34,
-	"Move Down":                              26,
-	"Move Up":                                25,
-	"Multiplexer":                            158,
-	"NAT Discovery":                          47,
-	"NAT Type":                               210,
-	"Name":                                   20,
-	"New Client":                             62,
-	"New Config":                             52,
-	"New Configuration":                      28,
-	"New Proxy":                              136,
-	"New Version!":                           9,
-	"New master password":                    243,
-	"No":                                     214,
-	"None":                                   71,
-	"Number of Proxies":                      255,
-	"Number of TCP Connections":              258,
-	"Number of UDP Connections":              259,
-	"Number out of allowed range":            306,
-	"OK":                                     18,
-	"Off":                                    112,
-	"On":                                     111,
-	"Open File":                              39,
-	"Open Log Folder":                        207,
-	"Open Port":                              230,
-	"Other Options":                          92,
-	"Parameters":                             102,
-	"Passive Port Range":                     293,
-	"Password":                               89,
-	"Password is set.":                       245,
-	"Password mismatch":                      7,
-	"Password removed.":                      242,
-	"Please check and try again.":            8,
-	"Please enter a number from %.f to %.f.": 304,
-	"Please enter a number from %s to %s.":   305,
-	"Please enter the correct URL list.":     297,
-	"Please select one of the provided options.": 309,
-	"Plugin":                                 177,
-	"Plugin Name":                            178,
-	"Pool Count":                             106,
-	"Port":                                   229,
-	"Preferences":                            231,
-	"Properties":                             50,
-	"Protocol":                               100,
-	"Proxy Protocol":                         162,
-	"Proxy Server":                           275,
-	"Proxy URL":                              130,
-	"Proxy already exists":                   192,
-	"Public Network":                         215,
-	"Quick Add":                              266,
-	"Random":                                 139,
-	"Re-enter password":                      244,
-	"Ready":                                  296,
-	"Relative":                               95,
-	"Remote Address":                         279,
-	"Remote Desktop":                         267,
-	"Remote Port":                            149,
-	"Request headers":                        141,
-	"Requires local port or plugin.":         196,
-	"Response headers":                       142,
-	"Retry Count":                            171,
-	"Retry Interval":                         173,
-	"Role":                                   143,
-	"Route User":                             159,
-	"Running":                                217,
-	"STUN Server":                            68,
-	"Scope":                                  78,
-	"Secret":                                 76,
-	"Secret Key":                             146,
-	"Select Certificate File":                115,
-	"Select Certificate Key File":            117,
-	"Select Token File":                      75,
-	"Select Trusted CA File":                 119,
-	"Select Unix Path":                       180,
-	"Select a folder for directory listing.": 182,
-	"Select a local directory that the admin server will load resources from.": 91,
-	"Select all":                             51,
-	"Select language":                        239,
-	"Selection Required":                     308,
-	"Server":                                 144,
-	"Server Address":                         65,
-	"Server Name":                            153,
-	"Server Port":                            66,
-	"Server User":                            154,
-	"Server name is required.":               194,
-	"Service Name":                           253,
-	"Settings":                               241,
-	"Show Remote Address":                    280,
-	"Show in Folder":                         40,
-	"Skip certificate verification":          131,
-	"Source":                                 72,
-	"Source Address":                         122,
-	"Start":                                  223,
-	"Start Type":                             256,
-	"Start config \"%s\"":                    227,
-	"Started":                                260,
-	"Starting":                               219,
-	"Status":                                 221,
-	"Stop":                                   224,
-	"Stop config \"%s\"":                     225,
-	"Stopped":                                218,
-	"Stopping":                               220,
-	"Strip Prefix":                           183,
-	"Subdomain":                              155,
-	"TCP Mux":                                123,
-	"The config \"%s\" already removed.":     32,
-	"The config is currently locked.":        58,
-	"The config name \"%s\" already exists.": 134,
-	"The current display language is":        237,
-	"The file \"%s\" is not a valid ZIP file.":                                    55,
-	"The number of local ports should be the same as the number of remote ports.": 204,
-	"The password is incorrect. Re-enter password.":                               302,
-	"The plugin does not support range ports.":                                    202,
-	"The proxy name \"%s\" already exists.":                                       193,
-	"The text does not match the required pattern.":                               307,
-	"There are currently no updates available.":                                   17,
-	"This feature only supports text in INI or TOML format.":                      283,
-	"Timeout":                 110,
-	"Times/Hour":              172,
-	"To Bottom":               38,
-	"To Top":                  37,
-	"Token":                   74,
-	"Token Endpoint":          79,
-	"Token file is required.": 132,
-	"Trusted CA":              118,
-	"Type":                    140,
-	"UDP Packet Size":         128,
-	"Unable to upgrade your config file due to proxy conversion failure, please check the proxy config and try again.\n\nBad proxy: %s": 135,
-	"Unix Path":              179,
-	"Unix path is required.": 199,
-	"Unknown":                216,
-	"Up":                     35,
-	"Use legacy file format": 126,
-	"Use master password":    234,
-	"User":                   67,
-	"Value":                  21,
-	"Version: %s":            0,
-	"Visitor":                145,
-	"Wire Protocol":          129,
-	"Work Conns":             82,
-	"Yes":                    213,
-	"You can find more settings here.\nIncludes application updates, initial default values, etc.":                                  240,
-	"You can set a password to restrict access to this program.\nYou will be asked to enter it the next time you use this program.": 233,
-	"You must enter an administration password to operate the %s.":                                                                  300,
-	"You must restart program to apply the modification.":                                                                           238,
-	"Your connection to the server is encrypted":                                                                                    222,
-	"ms": 170,
-	"s":  98,
+	"General":                       304,
+	"Group":                         217,
+	"Group Key":                     218,
+	"HTTP File Server":              330,
+	"HTTP Password":                 207,
+	"HTTP User":                     206,
+	"Health Check":                  219,
+	"Health check url is required.": 233,
+	"Heart Beats":                   111,
+	"Heartbeat":                     138,
+	"Host Name":                     143,
+	"Host Rewrite":                  208,
+	"Hours":                         292,
+	"Identifier":                    310,
+	"Idle Timeout":                  135,
+	"Import Config":                 22,
+	"Import as copies":              24,
+	"Import from Clipboard":         58,
+	"Import from File":              42,
+	"Import from URL":               57,
+	"Imported %d of %d configs.":    70,
+	"Include the default values of new configs": 19,
+	"Include the store files of frp":            20,
+	"Interval":                                  139,
+	"Invalid Input":                             365,
+	"Invalid local port.":                       232,
+	"Invalid remote port.":                      235,
+	"Item":                                      241,
+	"Keep":                                      293,
+	"Keep Tunnel":                               197,
+	"Keep configs updated from the URLs":        358,
+	"Keepalive":                                 134,
+	"Key Files":                                 6,
+	"Languages":                                 269,
+	"Latest":                                    240,
+	"Level":                                     114,
+	"Load Balance":                              216,
+	"Local Address":                             179,
+	"Local Directory":                           260,
+	"Local Path":                                213,
+	"Local Port":                                180,
+	"Local address is required.":                229,
+	"Local path is required.":                   230,
+	"Location":                                  277,
+	"Locations":                                 189,
+	"Log":                                       113,
+	"Log Level":                                 307,
+	"Log retention":                             308,
+	"Manual":                                    309,
+	"Manual Settings":                           69,
+	"Master password":                           264,
+	"Max Days":                                  115,
+	"Max Streams":                               137,
+	"Metadata":                                  158,
+	"Modified":                                  319,
+	"Move":                                      47,
+	"Move Down":                                 37,
+	"Move Up":                                   36,
+	"Multiplexer":                               190,
+	"NAT Discovery":                             63,
+	"NAT Type":                                  242,
+	"Name":                                      31,
+	"New Client":                                92,
+	"New Config":                                68,
+	"New Configuration":                         41,
+	"New Proxy":                                 168,
+	"New Version!":                              9,
+	"New master password":                       300,
+	"Next to the program (portable)":            282,
+	"No":                                        246,
+	"None":                                      101,
+	"Not refreshed yet.":                        88,
+	"Number of Proxies":                         312,
+	"Number of TCP Connections":                 315,
+	"Number of UDP Connections":                 316,
+	"Number out of allowed range":               368,
+	"OK":                                        29,
+	"Off":                                       142,
+	"On":                                        141,
+	"Open File":                                 52,
+	"Open Log Folder":                           239,
+	"Open Port":                                 262,
+	"Other Options":                             122,
+	"Overwrite":                                 26,
+	"Parameters":                                132,
+	"Passive Port Range":                        352,
+	"Passphrase":                                77,
+	"Password":                                  119,
+	"Password is set.":                          302,
+	"Password mismatch":                         7,
+	"Password removed.":                         299,
+	"Please check and try again.":               8,
+	"Please enter a number from %.f to %.f.":    366,
+	"Please enter a number from %s to %s.":      367,
+	"Please enter the correct URL list.":        360,
+	"Please select one of the provided options.": 371,
+	"Plugin":                         209,
+	"Plugin Name":                    210,
+	"Pool Count":                     136,
+	"Port":                           261,
+	"Preferences":                    263,
+	"Properties":                     66,
+	"Protocol":                       130,
+	"Proxy Protocol":                 194,
+	"Proxy Server":                   332,
+	"Proxy URL":                      161,
+	"Proxy already exists":           224,
+	"Public Network":                 247,
+	"Quick Add":                      323,
+	"Random":                         171,
+	"Re-enter passphrase":            78,
+	"Re-enter password":              301,
+	"Ready":                          359,
+	"Refresh History":                61,
+	"Refresh Now":                    60,
+	"Relative":                       125,
+	"Remote Address":                 336,
+	"Remote Desktop":                 324,
+	"Remote Port":                    181,
+	"Request headers":                173,
+	"Requires local port or plugin.": 228,
+	"Response headers":               174,
+	"Restore":                        276,
+	"Restore the store files of frp": 28,
+	"Restored %d configs. Some settings take effect after restarting the program.": 281,
+	"Retry Count":                            203,
+	"Retry Interval":                         205,
+	"Role":                                   175,
+	"Route User":                             191,
+	"Running":                                249,
+	"STUN Server":                            98,
+	"Schedule":                               278,
+	"Scheduled Backup":                       289,
+	"Scope":                                  108,
+	"Secret":                                 106,
+	"Secret Key":                             178,
+	"Select Certificate File":                145,
+	"Select Certificate Key File":            147,
+	"Select Token File":                      105,
+	"Select Trusted CA File":                 149,
+	"Select Unix Path":                       212,
+	"Select a directory to save backups.":    291,
+	"Select a folder for directory listing.": 214,
+	"Select a local directory that the admin server will load resources from.": 121,
+	"Select all":                    67,
+	"Select language":               272,
+	"Selection Required":            370,
+	"Server":                        176,
+	"Server Address":                95,
+	"Server Name":                   185,
+	"Server Port":                   96,
+	"Server User":                   186,
+	"Server name is required.":      226,
+	"Service Name":                  311,
+	"Settings":                      297,
+	"Share Link":                    79,
+	"Share Selected Proxies":        339,
+	"Shared by all users":           283,
+	"Show Remote Address":           337,
+	"Show in Folder":                53,
+	"Signed Share Link":             75,
+	"Skip":                          25,
+	"Skip certificate verification": 162,
+	"Some ports or domains are also used by other configs:\n\n%s": 40,
+	"Source":                             102,
+	"Source Address":                     152,
+	"Start":                              255,
+	"Start Type":                         313,
+	"Start config \"%s\"":                259,
+	"Started":                            317,
+	"Starting":                           251,
+	"Status":                             253,
+	"Stop":                               256,
+	"Stop config \"%s\"":                 257,
+	"Stopped":                            250,
+	"Stopping":                           252,
+	"Strip Prefix":                       215,
+	"Subdomain":                          187,
+	"Subscription":                       59,
+	"TCP Mux":                            154,
+	"The backup is saved.":               279,
+	"The config \"%s\" already removed.": 45,
+	"The config \"%s\" is not imported from a URL with updates.":                                      86,
+	"The config is currently locked.":                                                                 82,
+	"The config name \"%s\" already exists.":                                                          165,
+	"The configs and settings in the backup will replace the current ones.\nDo you want to continue?": 280,
+	"The current display language is":                                                                 270,
+	"The file \"%s\" is not a valid ZIP file.":                                                        71,
+	"The following options are not supported by the legacy file format and will be lost:\n\n%s\n\nAre you sure you would like to continue?":           167,
+	"The following problems are found in the config:\n\n%s\n\nAre you sure you would like to save it?":                                                355,
+	"The number of local ports should be the same as the number of remote ports.":                                                                     236,
+	"The passphrase is incorrect. Re-enter passphrase.":                                                                                               74,
+	"The password is incorrect. Re-enter password.":                                                                                                   303,
+	"The plugin does not support range ports.":                                                                                                        234,
+	"The proxy name \"%s\" already exists.":                                                                                                           225,
+	"The share link is signed by the following public key:\n\n%s\n\nMake sure the key belongs to a trusted sender. Do you want to import the config?": 76,
+	"The text does not match the required pattern.":                                                                                                   369,
+	"There are currently no updates available.":                                                                                                       17,
+	"This feature only supports text in INI or TOML format.":                                                                                          342,
+	"Timeout":                 140,
+	"Times/Hour":              204,
+	"To Bottom":               51,
+	"To Top":                  50,
+	"Token":                   104,
+	"Token Endpoint":          109,
+	"Token file is required.": 163,
+	"Trusted CA":              148,
+	"Type":                    172,
+	"UDP Packet Size":         159,
+	"URL":                     87,
+	"Unable to upgrade your config file due to proxy conversion failure, please check the proxy config and try again.\n\nBad proxy: %s": 166,
+	"Unix Path":              211,
+	"Unix path is required.": 231,
+	"Unknown":                248,
+	"Unsubscribe":            62,
+	"Up":                     48,
+	"Up to date":             90,
+	"Updated":                89,
+	"Use legacy file format": 157,
+	"Use master password":    266,
+	"Use the default values of new configs in the file": 27,
+	"User":          97,
+	"Value":         32,
+	"Version: %s":   0,
+	"Visitor":       177,
+	"Wire Protocol": 160,
+	"Work Conns":    112,
+	"Yes":           245,
+	"You can back up all configs, logs and settings to a file,\nand restore them on this or another computer.":                      274,
+	"You can find more settings here.\nIncludes application updates, initial default values, etc.":                                  296,
+	"You can set a password to restrict access to this program.\nYou will be asked to enter it the next time you use this program.": 265,
+	"You must enter an administration password to operate the %s.":                                                                  363,
+	"You must restart program to apply the modification.":                                                                           271,
+	"Your connection to the server is encrypted":                                                                                    254,
+	"ms": 202,
+	"s":  128,
 }
 
-var en_USIndex = []uint32{ // 312 elements
+var en_USIndex = []uint32{ // 374 elements
 	// Entry 0 - 1F
 	0x00000000, 0x0000000f, 0x00000022, 0x00000032,
 	0x0000003c, 0x00000050, 0x00000062, 0x0000006c,
 	0x0000007e, 0x0000009a, 0x000000a7, 0x000000ad,
 	0x000000be, 0x000000d3, 0x000000e5, 0x00000124,
-	0x0000016c, 0x000001a4, 0x000001ce, 0x000001d1,
-	0x000001d8, 0x000001dd, 0x000001e3, 0x000001e7,
-	0x000001ee, 0x000001f8, 0x00000200, 0x0000020a,
-	0x00000218, 0x0000022a, 0x0000023b, 0x00000250,
+	0x0000016c, 0x000001a4, 0x000001ce, 0x000001e8,
+	0x00000212, 0x00000231, 0x00000270, 0x0000027e,
+	0x000002b5, 0x000002c6, 0x000002cb, 0x000002d5,
+	0x00000307, 0x00000326, 0x00000329, 0x00000330,
 	// Entry 20 - 3F
-	0x00000267, 0x0000028b, 0x00000290, 0x00000295,
-	0x00000298, 0x0000029d, 0x000002a4, 0x000002ae,
-	0x000002b8, 0x000002c7, 0x000002d5, 0x000002d9,
-	0x000002e5, 0x000002f3, 0x00000303, 0x00000319,
-	0x00000327, 0x00000337, 0x00000351, 0x0000035c,
-	0x00000367, 0x00000372, 0x00000382, 0x000003a3,
-	0x000003cd, 0x000003e3, 0x00000419, 0x00000439,
-	0x0000044e, 0x00000488, 0x000004a7, 0x000004b2,
+	0x00000335, 0x0000033b, 0x0000033f, 0x00000346,
+	0x00000350, 0x00000358, 0x00000362, 0x00000370,
+	0x00000379, 0x000003b6, 0x000003c8, 0x000003d9,
+	0x000003ee, 0x00000405, 0x00000429, 0x0000042e,
+	0x00000433, 0x00000436, 0x0000043b, 0x00000442,
+	0x0000044c, 0x00000456, 0x00000465, 0x00000473,
+	0x00000477, 0x00000483, 0x00000493, 0x000004a9,
+	0x000004b6, 0x000004c2, 0x000004d2, 0x000004de,
 	// Entry 40 - 5F
-	0x000004c6, 0x000004cc, 0x000004db, 0x000004e7,
-	0x000004ec, 0x000004f8, 0x000004fd, 0x00000509,
-	0x0000050e, 0x00000515, 0x0000051a, 0x00000520,
-	0x00000532, 0x00000539, 0x00000542, 0x00000548,
-	0x00000557, 0x00000569, 0x00000575, 0x00000580,
-	0x00000584, 0x0000058a, 0x00000593, 0x00000598,
-	0x0000059e, 0x000005ac, 0x000005b5, 0x000005bc,
-	0x00000605, 0x00000613, 0x0000061f, 0x00000628,
+	0x000004ec, 0x000004fc, 0x00000516, 0x00000521,
+	0x0000052c, 0x00000537, 0x00000547, 0x00000568,
+	0x00000592, 0x000005b4, 0x000005da, 0x0000060c,
+	0x0000061e, 0x000006ad, 0x000006b8, 0x000006cc,
+	0x000006d7, 0x000006ed, 0x00000723, 0x00000743,
+	0x00000758, 0x00000792, 0x000007b1, 0x000007ed,
+	0x000007f1, 0x00000804, 0x0000080c, 0x00000817,
+	0x0000081e, 0x00000829, 0x0000083d, 0x00000843,
 	// Entry 60 - 7F
-	0x00000631, 0x0000063d, 0x00000649, 0x0000064b,
-	0x00000656, 0x0000065f, 0x00000670, 0x0000067b,
-	0x00000688, 0x00000692, 0x0000069f, 0x000006aa,
-	0x000006b6, 0x000006c0, 0x000006c9, 0x000006d1,
-	0x000006d4, 0x000006d8, 0x000006e2, 0x000006ee,
-	0x00000706, 0x00000716, 0x00000732, 0x0000073d,
-	0x00000754, 0x0000076e, 0x00000777, 0x00000786,
-	0x0000078e, 0x000007a7, 0x000007c2, 0x000007d9,
+	0x00000852, 0x0000085e, 0x00000863, 0x0000086f,
+	0x00000874, 0x00000880, 0x00000885, 0x0000088c,
+	0x00000891, 0x00000897, 0x000008a9, 0x000008b0,
+	0x000008b9, 0x000008bf, 0x000008ce, 0x000008e0,
+	0x000008ec, 0x000008f7, 0x000008fb, 0x00000901,
+	0x0000090a, 0x0000090f, 0x00000915, 0x00000923,
+	0x0000092c, 0x00000933, 0x0000097c, 0x0000098a,
+	0x00000996, 0x0000099f, 0x000009a8, 0x000009b4,
 	// Entry 80 - 9F
-	0x000007e2, 0x000007f2, 0x00000800, 0x0000080a,
-	0x00000828, 0x00000840, 0x00000856, 0x0000087e,
-	0x00000901, 0x0000090b, 0x0000091e, 0x0000092a,
-	0x00000931, 0x00000936, 0x00000946, 0x00000957,
-	0x0000095c, 0x00000963, 0x0000096b, 0x00000976,
-	0x00000984, 0x0000098f, 0x0000099b, 0x000009a7,
-	0x000009b4, 0x000009be, 0x000009ca, 0x000009d6,
-	0x000009e0, 0x000009ef, 0x000009f9, 0x00000a05,
+	0x000009c0, 0x000009c2, 0x000009cd, 0x000009d6,
+	0x000009e7, 0x000009f2, 0x000009ff, 0x00000a09,
+	0x00000a16, 0x00000a21, 0x00000a2d, 0x00000a37,
+	0x00000a40, 0x00000a48, 0x00000a4b, 0x00000a4f,
+	0x00000a59, 0x00000a65, 0x00000a7d, 0x00000a8d,
+	0x00000aa9, 0x00000ab4, 0x00000acb, 0x00000ae5,
+	0x00000aee, 0x00000afd, 0x00000b09, 0x00000b11,
+	0x00000b2a, 0x00000b45, 0x00000b5c, 0x00000b65,
 	// Entry A0 - BF
-	0x00000a10, 0x00000a17, 0x00000a21, 0x00000a30,
-	0x00000a35, 0x00000a3d, 0x00000a49, 0x00000a54,
-	0x00000a60, 0x00000a7b, 0x00000a84, 0x00000a87,
-	0x00000a93, 0x00000a9e, 0x00000aad, 0x00000ab7,
-	0x00000ac5, 0x00000ad2, 0x00000ad9, 0x00000ae5,
-	0x00000aef, 0x00000b00, 0x00000b0b, 0x00000b32,
-	0x00000b3f, 0x00000b4c, 0x00000b52, 0x00000b5c,
-	0x00000b69, 0x00000b74, 0x00000b82, 0x00000b91,
+	0x00000b75, 0x00000b83, 0x00000b8d, 0x00000bab,
+	0x00000bc3, 0x00000bd9, 0x00000c01, 0x00000c84,
+	0x00000d09, 0x00000d13, 0x00000d26, 0x00000d32,
+	0x00000d39, 0x00000d3e, 0x00000d4e, 0x00000d5f,
+	0x00000d64, 0x00000d6b, 0x00000d73, 0x00000d7e,
+	0x00000d8c, 0x00000d97, 0x00000da3, 0x00000daf,
+	0x00000dbc, 0x00000dc6, 0x00000dd2, 0x00000dde,
+	0x00000de8, 0x00000df7, 0x00000e01, 0x00000e0d,
 	// Entry C0 - DF
-	0x00000b9f, 0x00000bb4, 0x00000bdb, 0x00000bf4,
-	0x00000c0b, 0x00000c2a, 0x00000c45, 0x00000c5d,
-	0x00000c74, 0x00000c88, 0x00000ca6, 0x00000ccf,
-	0x00000ce4, 0x00000d30, 0x00000d74, 0x00000d79,
-	0x00000d89, 0x00000d90, 0x00000d95, 0x00000d9e,
-	0x00000da7, 0x00000db8, 0x00000dbc, 0x00000dbf,
-	0x00000dce, 0x00000dd6, 0x00000dde, 0x00000de6,
-	0x00000def, 0x00000df8, 0x00000dff, 0x00000e2a,
+	0x00000e18, 0x00000e1f, 0x00000e29, 0x00000e38,
+	0x00000e3d, 0x00000e45, 0x00000e51, 0x00000e5c,
+	0x00000e68, 0x00000e83, 0x00000e8c, 0x00000e8f,
+	0x00000e9b, 0x00000ea6, 0x00000eb5, 0x00000ebf,
+	0x00000ecd, 0x00000eda, 0x00000ee1, 0x00000eed,
+	0x00000ef7, 0x00000f08, 0x00000f13, 0x00000f3a,
+	0x00000f47, 0x00000f54, 0x00000f5a, 0x00000f64,
+	0x00000f71, 0x00000f7c, 0x00000f8a, 0x00000f99,
 	// Entry E0 - FF
-	0x00000e30, 0x00000e35, 0x00000e49, 0x00000e7d,
-	0x00000e92, 0x00000ea2, 0x00000ea7, 0x00000eb1,
-	0x00000ebd, 0x00000ecd, 0x00000f4a, 0x00000f5e,
-	0x00000f6e, 0x00000f78, 0x00000f98, 0x00000fcc,
-	0x00000fdc, 0x00001038, 0x00001041, 0x00001053,
-	0x00001067, 0x00001079, 0x0000108a, 0x00001092,
-	0x000010b2, 0x000010bb, 0x000010c5, 0x000010d3,
-	0x000010da, 0x000010e5, 0x000010f2, 0x000010fe,
+	0x00000fa7, 0x00000fbc, 0x00000fe3, 0x00000ffc,
+	0x00001013, 0x00001032, 0x0000104d, 0x00001065,
+	0x0000107c, 0x00001090, 0x000010ae, 0x000010d7,
+	0x000010ec, 0x00001138, 0x0000117c, 0x00001181,
+	0x00001191, 0x00001198, 0x0000119d, 0x000011a6,
+	0x000011af, 0x000011c0, 0x000011c4, 0x000011c7,
+	0x000011d6, 0x000011de, 0x000011e6, 0x000011ee,
+	0x000011f7, 0x00001200, 0x00001207, 0x00001232,
 	// Entry 100 - 11F
-	0x00001110, 0x0000111b, 0x0000112e, 0x00001148,
-	0x00001162, 0x0000116a, 0x00001172, 0x0000117b,
-	0x0000118c, 0x00001197, 0x0000119d, 0x000011a7,
-	0x000011b6, 0x000011c9, 0x000011d1, 0x000011d9,
-	0x000011e1, 0x000011e9, 0x000011fa, 0x0000120f,
-	0x0000121c, 0x0000122d, 0x00001235, 0x0000123d,
-	0x0000124c, 0x00001260, 0x00001274, 0x00001282,
-	0x000012b9, 0x000012ce, 0x00001303, 0x00001318,
+	0x00001238, 0x0000123d, 0x00001251, 0x00001285,
+	0x0000129a, 0x000012aa, 0x000012af, 0x000012b9,
+	0x000012c5, 0x000012d5, 0x00001352, 0x00001366,
+	0x00001376, 0x000013a6, 0x000013b0, 0x000013d0,
+	0x00001404, 0x00001414, 0x0000141b, 0x00001483,
+	0x0000148b, 0x00001493, 0x0000149c, 0x000014a5,
+	0x000014ba, 0x00001519, 0x00001569, 0x00001588,
+	0x0000159c, 0x000015ae, 0x000015bc, 0x000015e6,
 	// Entry 120 - 13F
-	0x00001352, 0x00001368, 0x0000139e, 0x000013b4,
-	0x000013ef, 0x000013f6, 0x00001409, 0x00001415,
-	0x00001440, 0x00001446, 0x00001469, 0x00001472,
-	0x00001481, 0x000014c1, 0x000014df, 0x0000150d,
-	0x0000151b, 0x00001548, 0x00001573, 0x0000158f,
-	0x000015bd, 0x000015d0, 0x000015fb, 0x00001614,
-} // Size: 1272 bytes
+	0x0000163e, 0x0000165a, 0x0000166b, 0x00001675,
+	0x00001699, 0x0000169f, 0x000016a4, 0x000016ac,
+	0x000016e6, 0x00001742, 0x0000174b, 0x00001792,
+	0x000017a4, 0x000017b8, 0x000017ca, 0x000017db,
+	0x00001809, 0x00001811, 0x00001831, 0x0000183a,
+	0x00001844, 0x00001852, 0x00001859, 0x00001864,
+	0x00001871, 0x00001883, 0x0000188e, 0x000018a1,
+	0x000018bb, 0x000018d5, 0x000018dd, 0x000018e5,
+	// Entry 140 - 15F
+	0x000018ee, 0x000018ff, 0x0000190a, 0x00001910,
+	0x0000191a, 0x00001929, 0x0000193c, 0x00001944,
+	0x0000194c, 0x00001954, 0x0000195c, 0x0000196d,
+	0x00001982, 0x0000198f, 0x000019a0, 0x000019a8,
+	0x000019b0, 0x000019bf, 0x000019d3, 0x000019e7,
+	0x000019fe, 0x00001a16, 0x00001a24, 0x00001a5b,
+	0x00001a70, 0x00001aa5, 0x00001aba, 0x00001af4,
+	0x00001b0a, 0x00001b40, 0x00001b56, 0x00001b91,
+	// Entry 160 - 17F
+	0x00001b98, 0x00001bab, 0x00001bb7, 0x00001bc4,
+	0x00001c24, 0x00001c4f, 0x00001c8b, 0x00001cae,
+	0x00001cb4, 0x00001cd7, 0x00001ce0, 0x00001cef,
+	0x00001d2f, 0x00001d4d, 0x00001d5b, 0x00001d88,
+	0x00001db3, 0x00001dcf, 0x00001dfd, 0x00001e10,
+	0x00001e3b, 0x00001e54,
+} // Size: 1520 bytes
 
-const en_USData string = "" + // Size: 5652 bytes
+const en_USData string = "" + // Size: 7764 bytes
 	"\x02Version: %[1]s\x02FRP version: %[1]s\x02Built on: %[1]s\x02All Files" +
 	"\x02Configuration Files\x02Certificate Files\x02Key Files\x02Password mi" +
 	"smatch\x02Please check and try again.\x02New Version!\x02About\x02Downlo" +
@@ -455,186 +535,237 @@ const en_USData string = "" + // Size: 5652 bytes
 	"or to report bugs, please visit the project page:\x02For FRP configurati" +
 	"on documentation, please visit the FRP project page:\x02An error occurre" +
 	"d while checking for a software update.\x02There are currently no update" +
-	"s available.\x02OK\x02Cancel\x02Name\x02Value\x02Add\x02Delete\x02Clear " +
-	"All\x02Move Up\x02Move Down\x02Configuration\x02New Configuration\x02Imp" +
-	"ort from File\x02Delete %[1]s configs\x02Config already removed\x02The c" +
-	"onfig \x22%[1]s\x22 already removed.\x02Edit\x02Move\x02Up\x02Down\x02To" +
-	" Top\x02To Bottom\x02Open File\x02Show in Folder\x02Create a Copy\x02All" +
-	"\x02Common Only\x02Import Config\x02Import from URL\x02Import from Clipb" +
-	"oard\x02NAT Discovery\x02Copy Share Link\x02Export All Configs to ZIP" +
-	"\x02Properties\x02Select all\x02New Config\x02Manual Settings\x02Importe" +
-	"d %[1]d of %[2]d configs.\x02The file \x22%[1]s\x22 is not a valid ZIP f" +
-	"ile.\x02Delete config \x22%[1]s\x22\x02Are you sure you would like to de" +
-	"lete config \x22%[1]s\x22?\x02The config is currently locked.\x02Delete " +
-	"%[1]d configs\x02Are you sure that you want to delete these %[1]d config" +
-	"s?\x02%[1]d succeeded, %[2]d failed.\x02New Client\x02Edit Client - %[1]" +
-	"s\x02Basic\x02Server Address\x02Server Port\x02User\x02STUN Server\x02Au" +
-	"th\x02Auth Method\x02None\x02Source\x02File\x02Token\x02Select Token Fil" +
-	"e\x02Secret\x02Audience\x02Scope\x02Token Endpoint\x02Additional Scopes" +
-	"\x02Heart Beats\x02Work Conns\x02Log\x02Level\x02Max Days\x02Days\x02Adm" +
-	"in\x02Admin Address\x02Password\x02Assets\x02Select a local directory th" +
-	"at the admin server will load resources from.\x02Other Options\x02Auto D" +
-	"elete\x02Absolute\x02Relative\x02Delete Date\x02Delete Days\x02s\x02Conn" +
-	"ection\x02Protocol\x02Advanced Options\x02Parameters\x02Dial Timeout\x02" +
-	"Keepalive\x02Idle Timeout\x02Pool Count\x02Max Streams\x02Heartbeat\x02I" +
-	"nterval\x02Timeout\x02On\x02Off\x02Host Name\x02Certificate\x02Select Ce" +
-	"rtificate File\x02Certificate Key\x02Select Certificate Key File\x02Trus" +
-	"ted CA\x02Select Trusted CA File\x02Disable custom first byte\x02Advance" +
-	"d\x02Source Address\x02TCP Mux\x02Exit after login failure\x02Disable au" +
-	"to-start at boot\x02Use legacy file format\x02Metadata\x02UDP Packet Siz" +
-	"e\x02Wire Protocol\x02Proxy URL\x02Skip certificate verification\x02Toke" +
-	"n file is required.\x02Config already exists\x02The config name \x22%[1]" +
-	"s\x22 already exists.\x02Unable to upgrade your config file due to proxy" +
-	" conversion failure, please check the proxy config and try again.\x0a" +
-	"\x0aBad proxy: %[1]s\x02New Proxy\x02Edit Proxy - %[1]s\x02Annotations" +
-	"\x02Random\x02Type\x02Request headers\x02Response headers\x02Role\x02Ser" +
-	"ver\x02Visitor\x02Secret Key\x02Local Address\x02Local Port\x02Remote Po" +
-	"rt\x02Allow Users\x02Bind Address\x02Bind Port\x02Server Name\x02Server " +
-	"User\x02Subdomain\x02Custom Domains\x02Locations\x02Multiplexer\x02Route" +
-	" User\x02Client\x02Bandwidth\x02Proxy Protocol\x02Auto\x02Default\x02Kee" +
-	"p Tunnel\x02Encryption\x02Compression\x02Disable Assisted Addresses\x02F" +
-	"allback\x02ms\x02Retry Count\x02Times/Hour\x02Retry Interval\x02HTTP Use" +
-	"r\x02HTTP Password\x02Host Rewrite\x02Plugin\x02Plugin Name\x02Unix Path" +
-	"\x02Select Unix Path\x02Local Path\x02Select a folder for directory list" +
-	"ing.\x02Strip Prefix\x02Load Balance\x02Group\x02Group Key\x02Health Che" +
-	"ck\x02Check Type\x02Check Timeout\x02Check Interval\x02Failure Count\x02" +
-	"Proxy already exists\x02The proxy name \x22%[1]s\x22 already exists.\x02" +
-	"Server name is required.\x02Bind port is required.\x02Requires local por" +
-	"t or plugin.\x02Local address is required.\x02Local path is required." +
-	"\x02Unix path is required.\x02Invalid local port.\x02Health check url is" +
-	" required.\x02The plugin does not support range ports.\x02Invalid remote" +
-	" port.\x02The number of local ports should be the same as the number of " +
-	"remote ports.\x02Custom domains and subdomain should have at least one o" +
-	"f these set.\x02Copy\x02Open Log Folder\x02Latest\x02Item\x02NAT Type" +
-	"\x02Behavior\x02External Address\x02Yes\x02No\x02Public Network\x02Unkno" +
-	"wn\x02Running\x02Stopped\x02Starting\x02Stopping\x02Status\x02Your conne" +
-	"ction to the server is encrypted\x02Start\x02Stop\x02Stop config \x22%[1" +
-	"]s\x22\x02Are you sure you would like to stop config \x22%[1]s\x22?\x02S" +
-	"tart config \x22%[1]s\x22\x02Local Directory\x02Port\x02Open Port\x02Pre" +
-	"ferences\x02Master password\x02You can set a password to restrict access" +
-	" to this program.\x0aYou will be asked to enter it the next time you use" +
-	" this program.\x02Use master password\x02Change Password\x02Languages" +
-	"\x02The current display language is\x02You must restart program to apply" +
-	" the modification.\x02Select language\x02You can find more settings here" +
-	".\x0aIncludes application updates, initial default values, etc.\x02Setti" +
-	"ngs\x02Password removed.\x02New master password\x02Re-enter password\x02" +
-	"Password is set.\x02General\x02Automatically check for updates\x02Defaul" +
-	"ts\x02Log Level\x02Log retention\x02Manual\x02Identifier\x02Service Name" +
-	"\x02File Format\x02Number of Proxies\x02Start Type\x02%[1]d Files, %[2]s" +
-	"\x02Number of TCP Connections\x02Number of UDP Connections\x02Started" +
-	"\x02Created\x02Modified\x02%[1]s Properties\x02Copy Value\x02Error\x02Qu" +
-	"ick Add\x02Remote Desktop\x02Add Remote Desktop\x02Add VNC\x02Add SSH" +
-	"\x02Add Web\x02Add FTP\x02HTTP File Server\x02Add HTTP File Server\x02Pr" +
-	"oxy Server\x02Add Proxy Server\x02Disable\x02Domains\x02Remote Address" +
-	"\x02Show Remote Address\x02Copy Access Address\x02Error message\x02This " +
-	"feature only supports text in INI or TOML format.\x02Delete proxy \x22%[" +
-	"1]s\x22\x02Are you sure you would like to delete proxy \x22%[1]s\x22?" +
-	"\x02Delete %[1]d proxies\x02Are you sure that you want to delete these %" +
-	"[1]d proxies?\x02Disable proxy \x22%[1]s\x22\x02Are you sure you would l" +
-	"ike to disable proxy \x22%[1]s\x22?\x02Disable %[1]d proxies\x02Are you " +
-	"sure that you want to disable these %[1]d proxies?\x02Enable\x02Passive " +
-	"Port Range\x02FRP Manager\x02* Support batch import, one link per line." +
-	"\x02Ready\x02Please enter the correct URL list.\x02Download\x02Enter Pas" +
-	"sword\x02You must enter an administration password to operate the %[1]s." +
-	"\x02Enter Administration Password\x02The password is incorrect. Re-enter" +
-	" password.\x02Invalid Input\x02Please enter a number from %.[1]f to %.[2" +
-	"]f.\x02Please enter a number from %[1]s to %[2]s.\x02Number out of allow" +
-	"ed range\x02The text does not match the required pattern.\x02Selection R" +
-	"equired\x02Please select one of the provided options.\x02A selection is " +
-	"required."
+	"s available.\x02Export All Configs to ZIP\x02Include the default values " +
+	"of new configs\x02Include the store files of frp\x02Decrypt secrets, so " +
+	"the configs can be used on other computers\x02Import Config\x02%[1]d con" +
+	"figs have the same names as existing configs.\x02Import as copies\x02Ski" +
+	"p\x02Overwrite\x02Use the default values of new configs in the file\x02R" +
+	"estore the store files of frp\x02OK\x02Cancel\x02Name\x02Value\x02Add" +
+	"\x02Delete\x02Clear All\x02Move Up\x02Move Down\x02Configuration\x02Conf" +
+	"lict\x02Some ports or domains are also used by other configs:\x0a\x0a%[1" +
+	"]s\x02New Configuration\x02Import from File\x02Delete %[1]s configs\x02C" +
+	"onfig already removed\x02The config \x22%[1]s\x22 already removed.\x02Ed" +
+	"it\x02Move\x02Up\x02Down\x02To Top\x02To Bottom\x02Open File\x02Show in " +
+	"Folder\x02Create a Copy\x02All\x02Common Only\x02Import from URL\x02Impo" +
+	"rt from Clipboard\x02Subscription\x02Refresh Now\x02Refresh History\x02U" +
+	"nsubscribe\x02NAT Discovery\x02Copy Share Link\x02Copy Encrypted Share L" +
+	"ink\x02Properties\x02Select all\x02New Config\x02Manual Settings\x02Impo" +
+	"rted %[1]d of %[2]d configs.\x02The file \x22%[1]s\x22 is not a valid ZI" +
+	"P file.\x02%[1]s: overwritten config \x22%[2]s\x22\x02%[1]s: skipped, co" +
+	"nfig \x22%[2]s\x22 exists\x02The passphrase is incorrect. Re-enter passp" +
+	"hrase.\x02Signed Share Link\x02The share link is signed by the following" +
+	" public key:\x0a\x0a%[1]s\x0a\x0aMake sure the key belongs to a trusted " +
+	"sender. Do you want to import the config?\x02Passphrase\x02Re-enter pass" +
+	"phrase\x02Share Link\x02Delete config \x22%[1]s\x22\x02Are you sure you " +
+	"would like to delete config \x22%[1]s\x22?\x02The config is currently lo" +
+	"cked.\x02Delete %[1]d configs\x02Are you sure that you want to delete th" +
+	"ese %[1]d configs?\x02%[1]d succeeded, %[2]d failed.\x02The config \x22%" +
+	"[1]s\x22 is not imported from a URL with updates.\x02URL\x02Not refreshe" +
+	"d yet.\x02Updated\x02Up to date\x02Failed\x02New Client\x02Edit Client -" +
+	" %[1]s\x02Basic\x02Server Address\x02Server Port\x02User\x02STUN Server" +
+	"\x02Auth\x02Auth Method\x02None\x02Source\x02File\x02Token\x02Select Tok" +
+	"en File\x02Secret\x02Audience\x02Scope\x02Token Endpoint\x02Additional S" +
+	"copes\x02Heart Beats\x02Work Conns\x02Log\x02Level\x02Max Days\x02Days" +
+	"\x02Admin\x02Admin Address\x02Password\x02Assets\x02Select a local direc" +
+	"tory that the admin server will load resources from.\x02Other Options" +
+	"\x02Auto Delete\x02Absolute\x02Relative\x02Delete Date\x02Delete Days" +
+	"\x02s\x02Connection\x02Protocol\x02Advanced Options\x02Parameters\x02Dia" +
+	"l Timeout\x02Keepalive\x02Idle Timeout\x02Pool Count\x02Max Streams\x02H" +
+	"eartbeat\x02Interval\x02Timeout\x02On\x02Off\x02Host Name\x02Certificate" +
+	"\x02Select Certificate File\x02Certificate Key\x02Select Certificate Key" +
+	" File\x02Trusted CA\x02Select Trusted CA File\x02Disable custom first by" +
+	"te\x02Advanced\x02Source Address\x02File Format\x02TCP Mux\x02Exit after" +
+	" login failure\x02Disable auto-start at boot\x02Use legacy file format" +
+	"\x02Metadata\x02UDP Packet Size\x02Wire Protocol\x02Proxy URL\x02Skip ce" +
+	"rtificate verification\x02Token file is required.\x02Config already exis" +
+	"ts\x02The config name \x22%[1]s\x22 already exists.\x02Unable to upgrade" +
+	" your config file due to proxy conversion failure, please check the prox" +
+	"y config and try again.\x0a\x0aBad proxy: %[1]s\x02The following options" +
+	" are not supported by the legacy file format and will be lost:\x0a\x0a%[" +
+	"1]s\x0a\x0aAre you sure you would like to continue?\x02New Proxy\x02Edit" +
+	" Proxy - %[1]s\x02Annotations\x02Random\x02Type\x02Request headers\x02Re" +
+	"sponse headers\x02Role\x02Server\x02Visitor\x02Secret Key\x02Local Addre" +
+	"ss\x02Local Port\x02Remote Port\x02Allow Users\x02Bind Address\x02Bind P" +
+	"ort\x02Server Name\x02Server User\x02Subdomain\x02Custom Domains\x02Loca" +
+	"tions\x02Multiplexer\x02Route User\x02Client\x02Bandwidth\x02Proxy Proto" +
+	"col\x02Auto\x02Default\x02Keep Tunnel\x02Encryption\x02Compression\x02Di" +
+	"sable Assisted Addresses\x02Fallback\x02ms\x02Retry Count\x02Times/Hour" +
+	"\x02Retry Interval\x02HTTP User\x02HTTP Password\x02Host Rewrite\x02Plug" +
+	"in\x02Plugin Name\x02Unix Path\x02Select Unix Path\x02Local Path\x02Sele" +
+	"ct a folder for directory listing.\x02Strip Prefix\x02Load Balance\x02Gr" +
+	"oup\x02Group Key\x02Health Check\x02Check Type\x02Check Timeout\x02Check" +
+	" Interval\x02Failure Count\x02Proxy already exists\x02The proxy name " +
+	"\x22%[1]s\x22 already exists.\x02Server name is required.\x02Bind port i" +
+	"s required.\x02Requires local port or plugin.\x02Local address is requir" +
+	"ed.\x02Local path is required.\x02Unix path is required.\x02Invalid loca" +
+	"l port.\x02Health check url is required.\x02The plugin does not support " +
+	"range ports.\x02Invalid remote port.\x02The number of local ports should" +
+	" be the same as the number of remote ports.\x02Custom domains and subdom" +
+	"ain should have at least one of these set.\x02Copy\x02Open Log Folder" +
+	"\x02Latest\x02Item\x02NAT Type\x02Behavior\x02External Address\x02Yes" +
+	"\x02No\x02Public Network\x02Unknown\x02Running\x02Stopped\x02Starting" +
+	"\x02Stopping\x02Status\x02Your connection to the server is encrypted\x02" +
+	"Start\x02Stop\x02Stop config \x22%[1]s\x22\x02Are you sure you would lik" +
+	"e to stop config \x22%[1]s\x22?\x02Start config \x22%[1]s\x22\x02Local D" +
+	"irectory\x02Port\x02Open Port\x02Preferences\x02Master password\x02You c" +
+	"an set a password to restrict access to this program.\x0aYou will be ask" +
+	"ed to enter it the next time you use this program.\x02Use master passwor" +
+	"d\x02Change Password\x02Encrypt secrets in configs with master password" +
+	"\x02Languages\x02The current display language is\x02You must restart pro" +
+	"gram to apply the modification.\x02Select language\x02Backup\x02You can " +
+	"back up all configs, logs and settings to a file,\x0aand restore them on" +
+	" this or another computer.\x02Back Up\x02Restore\x02Location\x02Schedule" +
+	"\x02The backup is saved.\x02The configs and settings in the backup will " +
+	"replace the current ones.\x0aDo you want to continue?\x02Restored %[1]d " +
+	"configs. Some settings take effect after restarting the program.\x02Next" +
+	" to the program (portable)\x02Shared by all users\x02Current user only" +
+	"\x02Data Location\x02Configs, logs and settings are stored in:\x02All da" +
+	"ta will be moved, and running configs will be restarted.\x0aDo you want " +
+	"to continue?\x02All data is moved to %[1]s.\x02Scheduled Backup\x02Direc" +
+	"tory\x02Select a directory to save backups.\x02Hours\x02Keep\x02Backups" +
+	"\x02* Leave the directory empty to disable scheduled backups.\x02You can" +
+	" find more settings here.\x0aIncludes application updates, initial defau" +
+	"lt values, etc.\x02Settings\x02Disable the encryption of secrets before " +
+	"removing the master password.\x02Password removed.\x02New master passwor" +
+	"d\x02Re-enter password\x02Password is set.\x02The password is incorrect." +
+	" Re-enter password.\x02General\x02Automatically check for updates\x02Def" +
+	"aults\x02Log Level\x02Log retention\x02Manual\x02Identifier\x02Service N" +
+	"ame\x02Number of Proxies\x02Start Type\x02%[1]d Files, %[2]s\x02Number o" +
+	"f TCP Connections\x02Number of UDP Connections\x02Started\x02Created\x02" +
+	"Modified\x02%[1]s Properties\x02Copy Value\x02Error\x02Quick Add\x02Remo" +
+	"te Desktop\x02Add Remote Desktop\x02Add VNC\x02Add SSH\x02Add Web\x02Add" +
+	" FTP\x02HTTP File Server\x02Add HTTP File Server\x02Proxy Server\x02Add " +
+	"Proxy Server\x02Disable\x02Domains\x02Remote Address\x02Show Remote Addr" +
+	"ess\x02Copy Access Address\x02Share Selected Proxies\x02Copy Visitor Sha" +
+	"re Link\x02Error message\x02This feature only supports text in INI or TO" +
+	"ML format.\x02Delete proxy \x22%[1]s\x22\x02Are you sure you would like " +
+	"to delete proxy \x22%[1]s\x22?\x02Delete %[1]d proxies\x02Are you sure t" +
+	"hat you want to delete these %[1]d proxies?\x02Disable proxy \x22%[1]s" +
+	"\x22\x02Are you sure you would like to disable proxy \x22%[1]s\x22?\x02D" +
+	"isable %[1]d proxies\x02Are you sure that you want to disable these %[1]" +
+	"d proxies?\x02Enable\x02Passive Port Range\x02FRP Manager\x02Config Chec" +
+	"k\x02The following problems are found in the config:\x0a\x0a%[1]s\x0a" +
+	"\x0aAre you sure you would like to save it?\x02* Support batch import, o" +
+	"ne link per line.\x02* Append \x22#sha256=<checksum>\x22 to a link to ve" +
+	"rify the file.\x02Keep configs updated from the URLs\x02Ready\x02Please " +
+	"enter the correct URL list.\x02Download\x02Enter Password\x02You must en" +
+	"ter an administration password to operate the %[1]s.\x02Enter Administra" +
+	"tion Password\x02Invalid Input\x02Please enter a number from %.[1]f to %" +
+	".[2]f.\x02Please enter a number from %[1]s to %[2]s.\x02Number out of al" +
+	"lowed range\x02The text does not match the required pattern.\x02Selectio" +
+	"n Required\x02Please select one of the provided options.\x02A selection " +
+	"is required."
 
-var es_ESIndex = []uint32{ // 312 elements
+var es_ESIndex = []uint32{ // 374 elements
 	// Entry 0 - 1F
 	0x00000000, 0x00000010, 0x00000024, 0x00000041,
 	0x00000054, 0x0000006f, 0x00000087, 0x00000096,
 	0x000000ae, 0x000000d3, 0x000000e3, 0x000000e9,
 	0x000000f3, 0x000000ff, 0x00000116, 0x00000160,
-	0x000001b9, 0x000001f7, 0x00000227, 0x0000022f,
-	0x00000238, 0x0000023f, 0x00000249, 0x00000251,
-	0x00000258, 0x00000265, 0x00000278, 0x0000028a,
-	0x00000299, 0x000002ae, 0x000002c5, 0x000002e4,
+	0x000001b9, 0x000001f7, 0x00000227, 0x00000250,
+	0x00000292, 0x000002c0, 0x00000306, 0x0000031e,
+	0x0000036b, 0x00000380, 0x00000387, 0x00000394,
+	0x000003df, 0x0000040f, 0x00000417, 0x00000420,
 	// Entry 20 - 3F
-	0x00000300, 0x0000032a, 0x00000331, 0x00000337,
-	0x0000033e, 0x00000344, 0x0000034f, 0x0000035b,
-	0x0000036b, 0x00000381, 0x00000391, 0x00000397,
-	0x000003a3, 0x000003bb, 0x000003ce, 0x000003ea,
-	0x000003fc, 0x00000414, 0x0000043d, 0x00000449,
-	0x0000045b, 0x00000468, 0x00000479, 0x000004a3,
-	0x000004d4, 0x000004f4, 0x00000534, 0x00000563,
-	0x00000582, 0x000005c7, 0x000005e8, 0x000005f6,
+	0x00000427, 0x00000431, 0x00000439, 0x00000440,
+	0x0000044d, 0x00000460, 0x00000472, 0x00000481,
+	0x0000048b, 0x000004d6, 0x000004eb, 0x00000502,
+	0x00000521, 0x0000053d, 0x00000567, 0x0000056e,
+	0x00000574, 0x0000057b, 0x00000581, 0x0000058c,
+	0x00000598, 0x000005a8, 0x000005be, 0x000005ce,
+	0x000005d4, 0x000005e0, 0x000005f3, 0x0000060f,
+	0x0000061c, 0x0000062d, 0x0000064a, 0x00000660,
 	// Entry 40 - 5F
-	0x0000060d, 0x00000615, 0x0000062d, 0x00000640,
-	0x00000648, 0x00000656, 0x0000065b, 0x00000663,
-	0x0000066b, 0x00000672, 0x0000067a, 0x00000685,
-	0x000006a2, 0x000006aa, 0x000006b4, 0x000006bc,
-	0x000006d0, 0x000006e5, 0x000006fa, 0x0000070f,
-	0x00000718, 0x0000071e, 0x0000072d, 0x00000733,
-	0x00000739, 0x00000744, 0x0000074a, 0x00000752,
-	0x000007b4, 0x000007c3, 0x000007dc, 0x000007e5,
+	0x00000672, 0x0000068a, 0x000006af, 0x000006bb,
+	0x000006cd, 0x000006da, 0x000006eb, 0x00000715,
+	0x00000746, 0x00000778, 0x000007ac, 0x000007fd,
+	0x00000817, 0x000008c9, 0x000008de, 0x00000907,
+	0x0000091d, 0x0000093d, 0x0000097d, 0x000009ac,
+	0x000009cb, 0x00000a10, 0x00000a31, 0x00000a7d,
+	0x00000a81, 0x00000a9c, 0x00000aa8, 0x00000ab0,
+	0x00000ab6, 0x00000ac4, 0x00000adb, 0x00000ae3,
 	// Entry 60 - 7F
-	0x000007ee, 0x000007fd, 0x0000080c, 0x0000080e,
-	0x00000818, 0x00000822, 0x00000834, 0x00000840,
-	0x00000852, 0x0000085c, 0x00000872, 0x00000882,
-	0x00000896, 0x000008aa, 0x000008b4, 0x000008c2,
-	0x000008cb, 0x000008d3, 0x000008e8, 0x000008f4,
-	0x00000917, 0x0000092c, 0x00000958, 0x00000968,
-	0x0000098c, 0x000009b1, 0x000009ba, 0x000009d2,
-	0x000009da, 0x00000a08, 0x00000a35, 0x00000a5a,
+	0x00000afb, 0x00000b0e, 0x00000b16, 0x00000b24,
+	0x00000b29, 0x00000b31, 0x00000b39, 0x00000b40,
+	0x00000b48, 0x00000b53, 0x00000b70, 0x00000b78,
+	0x00000b82, 0x00000b8a, 0x00000b9e, 0x00000bb3,
+	0x00000bc8, 0x00000bdd, 0x00000be6, 0x00000bec,
+	0x00000bfb, 0x00000c01, 0x00000c07, 0x00000c12,
+	0x00000c18, 0x00000c20, 0x00000c82, 0x00000c91,
+	0x00000caa, 0x00000cb3, 0x00000cbc, 0x00000ccb,
 	// Entry 80 - 9F
-	0x00000a64, 0x00000a7c, 0x00000a8f, 0x00000a9c,
-	0x00000ac4, 0x00000ae5, 0x00000b01, 0x00000b30,
-	0x00000beb, 0x00000bf7, 0x00000c0c, 0x00000c18,
-	0x00000c22, 0x00000c27, 0x00000c3d, 0x00000c54,
-	0x00000c59, 0x00000c62, 0x00000c6c, 0x00000c7a,
-	0x00000c8b, 0x00000c98, 0x00000ca6, 0x00000cb8,
-	0x00000ccd, 0x00000cde, 0x00000cf2, 0x00000d07,
-	0x00000d12, 0x00000d2a, 0x00000d33, 0x00000d3f,
+	0x00000cda, 0x00000cdc, 0x00000ce6, 0x00000cf0,
+	0x00000d02, 0x00000d0e, 0x00000d20, 0x00000d2a,
+	0x00000d40, 0x00000d50, 0x00000d64, 0x00000d78,
+	0x00000d82, 0x00000d90, 0x00000d99, 0x00000da1,
+	0x00000db6, 0x00000dc2, 0x00000de5, 0x00000dfa,
+	0x00000e26, 0x00000e36, 0x00000e5a, 0x00000e7f,
+	0x00000e88, 0x00000ea0, 0x00000eb3, 0x00000ebb,
+	0x00000ee9, 0x00000f16, 0x00000f3b, 0x00000f45,
 	// Entry A0 - BF
-	0x00000d4f, 0x00000d57, 0x00000d63, 0x00000d73,
-	0x00000d78, 0x00000d84, 0x00000d94, 0x00000d9c,
-	0x00000da8, 0x00000dcb, 0x00000dd4, 0x00000de0,
-	0x00000df6, 0x00000e01, 0x00000e18, 0x00000e25,
-	0x00000e36, 0x00000e4a, 0x00000e53, 0x00000e5a,
-	0x00000e64, 0x00000e7f, 0x00000e8a, 0x00000ebf,
-	0x00000ecf, 0x00000ee3, 0x00000ee9, 0x00000ef8,
-	0x00000f09, 0x00000f0e, 0x00000f22, 0x00000f2c,
+	0x00000f5d, 0x00000f70, 0x00000f7d, 0x00000fa5,
+	0x00000fc6, 0x00000fe2, 0x00001011, 0x000010cc,
+	0x00001159, 0x00001165, 0x0000117a, 0x00001186,
+	0x00001190, 0x00001195, 0x000011ab, 0x000011c2,
+	0x000011c7, 0x000011d0, 0x000011da, 0x000011e8,
+	0x000011f9, 0x00001206, 0x00001214, 0x00001226,
+	0x0000123b, 0x0000124c, 0x00001260, 0x00001275,
+	0x00001280, 0x00001298, 0x000012a1, 0x000012ad,
 	// Entry C0 - DF
-	0x00000f3f, 0x00000f52, 0x00000f78, 0x00000f9f,
-	0x00000fc3, 0x00000fe8, 0x00001006, 0x0000101e,
-	0x00001038, 0x00001051, 0x00001080, 0x000010ab,
-	0x000010c5, 0x0000111a, 0x00001174, 0x0000117b,
-	0x0000118a, 0x00001192, 0x00001198, 0x000011a4,
-	0x000011b3, 0x000011c6, 0x000011ca, 0x000011cd,
-	0x000011da, 0x000011e6, 0x000011ed, 0x000011f6,
-	0x00001201, 0x00001208, 0x0000120f, 0x00001239,
+	0x000012bd, 0x000012c5, 0x000012d1, 0x000012e1,
+	0x000012e6, 0x000012f2, 0x00001302, 0x0000130a,
+	0x00001316, 0x00001339, 0x00001342, 0x0000134e,
+	0x00001364, 0x0000136f, 0x00001386, 0x00001393,
+	0x000013a4, 0x000013b8, 0x000013c1, 0x000013c8,
+	0x000013d2, 0x000013ed, 0x000013f8, 0x0000142d,
+	0x0000143d, 0x00001451, 0x00001457, 0x00001466,
+	0x00001477, 0x0000147c, 0x00001490, 0x0000149a,
 	// Entry E0 - FF
-	0x00001242, 0x0000124d, 0x0000126c, 0x000012ab,
-	0x000012ca, 0x000012db, 0x000012e2, 0x000012f1,
-	0x000012fe, 0x00001312, 0x000013a2, 0x000013bb,
-	0x000013d2, 0x000013da, 0x00001400, 0x0000143a,
-	0x0000144f, 0x000014cf, 0x000014d7, 0x000014ee,
-	0x00001508, 0x00001528, 0x0000154a, 0x00001552,
-	0x0000157a, 0x0000158a, 0x0000159c, 0x000015b4,
-	0x000015bb, 0x000015c9, 0x000015dd, 0x000015f0,
+	0x000014ad, 0x000014c0, 0x000014e6, 0x0000150d,
+	0x00001531, 0x00001556, 0x00001574, 0x0000158c,
+	0x000015a6, 0x000015bf, 0x000015ee, 0x00001619,
+	0x00001633, 0x00001688, 0x000016e2, 0x000016e9,
+	0x000016f8, 0x00001700, 0x00001706, 0x00001712,
+	0x00001721, 0x00001734, 0x00001738, 0x0000173b,
+	0x00001748, 0x00001754, 0x0000175b, 0x00001764,
+	0x0000176f, 0x00001776, 0x0000177d, 0x000017a7,
 	// Entry 100 - 11F
-	0x00001603, 0x00001612, 0x00001628, 0x00001642,
-	0x0000165c, 0x00001665, 0x0000166c, 0x00001677,
-	0x0000168c, 0x00001699, 0x0000169f, 0x000016af,
-	0x000016c1, 0x000016db, 0x000016e7, 0x000016f3,
-	0x000016ff, 0x0000170b, 0x00001725, 0x00001747,
-	0x00001756, 0x0000176d, 0x0000177a, 0x00001783,
-	0x00001795, 0x000017af, 0x000017cb, 0x000017dc,
-	0x00001813, 0x0000182a, 0x00001861, 0x00001878,
+	0x000017b0, 0x000017bb, 0x000017da, 0x00001819,
+	0x00001838, 0x00001849, 0x00001850, 0x0000185f,
+	0x0000186c, 0x00001880, 0x00001910, 0x00001929,
+	0x00001940, 0x00001986, 0x0000198e, 0x000019b4,
+	0x000019ee, 0x00001a03, 0x00001a16, 0x00001a9f,
+	0x00001aab, 0x00001ab5, 0x00001ac0, 0x00001aca,
+	0x00001aec, 0x00001b54, 0x00001bb8, 0x00001bd6,
+	0x00001bf8, 0x00001c0f, 0x00001c27, 0x00001c5f,
 	// Entry 120 - 13F
-	0x000018b4, 0x000018cf, 0x00001908, 0x00001921,
-	0x0000195d, 0x00001967, 0x0000197f, 0x00001994,
-	0x000019cb, 0x000019d1, 0x000019f6, 0x00001a00,
-	0x00001a1a, 0x00001a5e, 0x00001a88, 0x00001ac7,
-	0x00001ad8, 0x00001aff, 0x00001b24, 0x00001b46,
-	0x00001b75, 0x00001b8a, 0x00001bb9, 0x00001bd5,
-} // Size: 1272 bytes
+	0x00001cc3, 0x00001ce8, 0x00001d06, 0x00001d11,
+	0x00001d50, 0x00001d56, 0x00001d60, 0x00001d67,
+	0x00001db8, 0x00001e38, 0x00001e40, 0x00001e8d,
+	0x00001ea4, 0x00001ebe, 0x00001ede, 0x00001f00,
+	0x00001f3f, 0x00001f47, 0x00001f6f, 0x00001f7f,
+	0x00001f91, 0x00001fa9, 0x00001fb0, 0x00001fbe,
+	0x00001fd2, 0x00001fe5, 0x00001ff4, 0x0000200a,
+	0x00002024, 0x0000203e, 0x00002047, 0x0000204e,
+	// Entry 140 - 15F
+	0x00002059, 0x0000206e, 0x0000207b, 0x00002081,
+	0x00002091, 0x000020a3, 0x000020bd, 0x000020c9,
+	0x000020d5, 0x000020e1, 0x000020ed, 0x00002107,
+	0x00002129, 0x00002138, 0x0000214f, 0x0000215c,
+	0x00002165, 0x00002177, 0x00002191, 0x000021ad,
+	0x000021d1, 0x000021fb, 0x0000220c, 0x00002243,
+	0x0000225a, 0x00002291, 0x000022a8, 0x000022e4,
+	0x000022ff, 0x00002338, 0x00002351, 0x0000238d,
+	// Entry 160 - 17F
+	0x00002397, 0x000023af, 0x000023c4, 0x000023e7,
+	0x00002454, 0x0000248b, 0x000024dd, 0x00002515,
+	0x0000251b, 0x00002540, 0x0000254a, 0x00002564,
+	0x000025a8, 0x000025d2, 0x000025e3, 0x0000260a,
+	0x0000262f, 0x00002651, 0x00002680, 0x00002695,
+	0x000026c4, 0x000026e0,
+} // Size: 1520 bytes
 
-const es_ESData string = "" + // Size: 7125 bytes
+const es_ESData string = "" + // Size: 9952 bytes
 	"\x02Versión: %[1]s\x02Versión FRP: %[1]s\x02Fecha de compilación: %[1]s" +
 	"\x02Todos los archivos\x02Archivos de configuración\x02Archivos de certi" +
 	"ficado\x02Archivos clave\x02Contraseña no coincide\x02Por favor revisa e" +
@@ -643,532 +774,675 @@ const es_ESData string = "" + // Size: 7125 bytes
 	" visite la página del proyecto:\x02Para ver la documentación de configur" +
 	"ación de FRP, visite la página del proyecto FRP:\x02Se produjo un error " +
 	"al buscar una actualización de software.\x02Actualmente no hay actualiza" +
-	"ciones disponibles.\x02Aceptar\x02Cancelar\x02Nombre\x02Valorizar\x02Agr" +
-	"egar\x02Borrar\x02Limpiar todo\x02Mover hacia arriba\x02Mover hacia abaj" +
-	"o\x02Configuración\x02Nueva Configuración\x02Importar desde archivo\x02E" +
-	"liminar %[1]s configuraciones\x02Configuración ya eliminada\x02La config" +
-	"uración \x22%[1]s\x22 ya se eliminó.\x02Editar\x02Mover\x02Arriba\x02Aba" +
-	"jo\x02Hasta cima\x02Hasta fondo\x02Abrir documento\x02Mostrar en la carp" +
-	"eta\x02Crear una copia\x02Todos\x02Solo común\x02Importar configuración" +
-	"\x02Importar desde URL\x02Importar desde portapapeles\x02Detección de NA" +
-	"T\x02Copiar compartir enlace\x02Exportar todas las configuraciones a ZIP" +
-	"\x02Propiedades\x02Seleccionar todos\x02Nueva Config\x02Ajustes manuales" +
-	"\x02Importado %[1]d de %[2]d configuraciones.\x02El archivo \x22%[1]s" +
-	"\x22 no es un archivo ZIP válido.\x02Eliminar configuración \x22%[1]s" +
-	"\x22\x02¿Está seguro de que desea eliminar la configuración \x22%[1]s" +
-	"\x22?\x02La configuración está actualmente bloqueada.\x02Eliminar %[1]d " +
-	"configuraciones\x02¿Está seguro de que desea eliminar estas configuracio" +
-	"nes de %[1]d?\x02%[1]d tuvo éxito, %[2]d falló.\x02Nuevo Cliente\x02Edit" +
-	"ar Cliente - %[1]s\x02Básico\x02Dirección del servidor\x02Puerto de serv" +
-	"icio\x02Usuario\x02Servidor STUN\x02Auth\x02Método\x02Ninguna\x02Fuente" +
-	"\x02Archivo\x02Simbólico\x02Seleccionar archivo de token\x02Secreto\x02A" +
-	"udiencia\x02Alcance\x02Dirección de token\x02Alcances adicionales\x02Lat" +
-	"idos del corazón\x02Conexión de trabajo\x02Registro\x02Nivel\x02Días máx" +
-	"imos\x02Días\x02Admin\x02Dirección\x02Clave\x02Recurso\x02Seleccione un " +
-	"directorio local desde el que el servidor de administración cargará los " +
-	"recursos.\x02Otras opciones\x02Eliminación automática\x02Absoluto\x02Rel" +
-	"ativo\x02Eliminar fecha\x02Eliminar días\x02s\x02Conexión\x02Protocolo" +
-	"\x02Opciones Avanzada\x02Parámetros\x02Conexión agotado\x02Keepalive\x02" +
-	"Tiempo de inactividad\x02Conectar cuenta\x02Corrientes máximas\x02Latido" +
-	" del corazón\x02Intervalo\x02Tiempo muerto\x02Encender\x02Apagado\x02Nom" +
-	"bre de anfitrión\x02Certificado\x02Seleccionar archivo de certificado" +
-	"\x02Clave de certificado\x02Seleccionar archivo de clave de certificado" +
-	"\x02CA de confianza\x02Seleccionar archivo CA de confianza\x02Desactivar" +
-	" primer byte personalizado\x02Avanzado\x02Dirección de la fuente\x02Mux " +
-	"TCP\x02Salir después de fallar el inicio de sesión\x02Desactivar el inic" +
-	"io automático al arrancar\x02Utilizar formato de archivo heredado\x02Met" +
-	"adatos\x02Tamaño del paquete UDP\x02Protocolo de cable\x02URL de proxy" +
-	"\x02Omitir la verificación del certificado\x02Se requiere el archivo de " +
-	"token.\x02La configuración ya existe\x02El nombre de configuración \x22%" +
-	"[1]s\x22 ya existe.\x02No se puede actualizar su archivo de configuració" +
-	"n debido a un error en la conversión del proxy. Verifique la configuraci" +
-	"ón del proxy e inténtelo nuevamente.\x0a\x0aProxy incorrecto: %[1]s\x02" +
-	"Nuevo Proxy\x02Editar Proxy - %[1]s\x02Anotaciones\x02Aleatorio\x02Tipo" +
-	"\x02Solicitar encabezados\x02Cabeceras de respuesta\x02Role\x02Servidor" +
-	"\x02Visitante\x02Llave secreta\x02Dirección local\x02Puerto local\x02Pue" +
-	"rto remoto\x02Permitir usuarios\x02Dirección de enlace\x02Puerto de enla" +
-	"ce\x02Nombre del servidor\x02Usuario del servidor\x02Subdominio\x02Domin" +
-	"ios personalizados\x02Ruta URL\x02Multiplexor\x02Usuario de ruta\x02Clie" +
-	"nte\x02Banda ancha\x02Protocolo proxy\x02Auto\x02Por defecto\x02Mantener" +
-	" túnel\x02Cifrado\x02Compresión\x02Deshabilitar direcciones asistidas" +
-	"\x02Repuesto\x02milisegundo\x02Número de reintentos\x02Veces/Hora\x02Int" +
-	"ervalo de reintento\x02Usuario HTTP\x02Contraseña HTTP\x02Reescritura de" +
-	" host\x02Enchufar\x02Nombre\x02Ruta Unix\x02Seleccione la ruta de Unix" +
-	"\x02Ruta local\x02Seleccione una carpeta para la lista de directorios." +
-	"\x02Prefijo de tira\x02Equilibrio de carga\x02Grupo\x02Clave de grupo" +
-	"\x02Chequeo de salud\x02Tipo\x02Se acabó el tiempo\x02Intervalo\x02Recue" +
-	"nto de fallas\x02El proxy ya existe\x02El nombre de proxy \x22%[1]s\x22 " +
-	"ya existe.\x02El nombre del servidor es obligatorio.\x02Se requiere puer" +
-	"to de vinculación.\x02Requiere puerto local o complemento.\x02Se requier" +
-	"e dirección local.\x02Se requiere ruta local.\x02Se requiere la ruta Uni" +
-	"x.\x02Puerto local no válido.\x02Se requiere la URL de verificación de e" +
-	"stado.\x02El complemento no admite puertos de rango.\x02Puerto remoto no" +
-	" válido.\x02La cantidad de puertos locales debe ser la misma que la cant" +
-	"idad de puertos remotos.\x02Los dominios y subdominios personalizados de" +
-	"ben tener al menos uno de estos configurados.\x02Copiar\x02Abrir registr" +
-	"o\x02Último\x02Ítem\x02Tipo de NAT\x02Comportamiento\x02Dirección extern" +
-	"a\x02Sí\x02No\x02Red pública\x02Desconocido\x02Correr\x02Detenido\x02Com" +
-	"enzando\x02Parada\x02Estado\x02Su conexión al servidor está encriptada" +
-	"\x02Comienzo\x02Deténgase\x02Detener configuración \x22%[1]s\x22\x02¿Est" +
-	"á seguro de que desea detener la configuración \x22%[1]s\x22?\x02Inicia" +
-	"r configuración \x22%[1]s\x22\x02Directorio local\x02Puerto\x02Puerto ab" +
-	"ierto\x02Preferencias\x02Contraseña maestra\x02Puede establecer una cont" +
-	"raseña para restringir el acceso a este programa.\x0aSe le pedirá que lo" +
-	" ingrese la próxima vez que use este programa.\x02Usar contraseña maestr" +
-	"a\x02Cambiar la contraseña\x02Idiomas\x02El idioma de visualización actu" +
-	"al es\x02Debe reiniciar el programa para aplicar la modificación.\x02Sel" +
-	"eccione el idioma\x02Puedes encontrar más configuraciones aquí.\x0aInclu" +
+	"ciones disponibles.\x02Exportar todas las configuraciones a ZIP\x02Inclu" +
+	"ir los valores predeterminados de las nuevas configuraciones\x02Incluir " +
+	"los archivos de almacenamiento de frp\x02Descifrar los secretos para usa" +
+	"r las configuraciones en otros equipos\x02Importar configuración\x02%[1]" +
+	"d configuraciones tienen el mismo nombre que configuraciones existentes." +
+	"\x02Importar como copias\x02Omitir\x02Sobrescribir\x02Usar los valores p" +
+	"redeterminados de las nuevas configuraciones del archivo\x02Restaurar lo" +
+	"s archivos de almacenamiento de frp\x02Aceptar\x02Cancelar\x02Nombre\x02" +
+	"Valorizar\x02Agregar\x02Borrar\x02Limpiar todo\x02Mover hacia arriba\x02" +
+	"Mover hacia abajo\x02Configuración\x02Conflicto\x02Algunos puertos o dom" +
+	"inios también los usan otras configuraciones:\x0a\x0a%[1]s\x02Nueva Conf" +
+	"iguración\x02Importar desde archivo\x02Eliminar %[1]s configuraciones" +
+	"\x02Configuración ya eliminada\x02La configuración \x22%[1]s\x22 ya se e" +
+	"liminó.\x02Editar\x02Mover\x02Arriba\x02Abajo\x02Hasta cima\x02Hasta fon" +
+	"do\x02Abrir documento\x02Mostrar en la carpeta\x02Crear una copia\x02Tod" +
+	"os\x02Solo común\x02Importar desde URL\x02Importar desde portapapeles" +
+	"\x02Suscripción\x02Actualizar ahora\x02Historial de actualizaciones\x02C" +
+	"ancelar suscripción\x02Detección de NAT\x02Copiar compartir enlace\x02Co" +
+	"piar enlace cifrado para compartir\x02Propiedades\x02Seleccionar todos" +
+	"\x02Nueva Config\x02Ajustes manuales\x02Importado %[1]d de %[2]d configu" +
+	"raciones.\x02El archivo \x22%[1]s\x22 no es un archivo ZIP válido.\x02%[" +
+	"1]s: se sobrescribió la configuración \x22%[2]s\x22\x02%[1]s: omitido, l" +
+	"a configuración \x22%[2]s\x22 ya existe\x02La frase de contraseña es inc" +
+	"orrecta. Escriba la frase de contraseña otra vez.\x02Enlace compartido f" +
+	"irmado\x02El enlace compartido está firmado por la siguiente clave públi" +
+	"ca:\x0a\x0a%[1]s\x0a\x0aAsegúrese de que la clave pertenece a un remiten" +
+	"te de confianza. ¿Desea importar la configuración?\x02Frase de contraseñ" +
+	"a\x02Escriba la frase de contraseña otra vez\x02Enlace para compartir" +
+	"\x02Eliminar configuración \x22%[1]s\x22\x02¿Está seguro de que desea el" +
+	"iminar la configuración \x22%[1]s\x22?\x02La configuración está actualme" +
+	"nte bloqueada.\x02Eliminar %[1]d configuraciones\x02¿Está seguro de que " +
+	"desea eliminar estas configuraciones de %[1]d?\x02%[1]d tuvo éxito, %[2]" +
+	"d falló.\x02La configuración \x22%[1]s\x22 no se importó desde una URL c" +
+	"on actualizaciones.\x02URL\x02Aún no se ha actualizado.\x02Actualizada" +
+	"\x02Al día\x02Error\x02Nuevo Cliente\x02Editar Cliente - %[1]s\x02Básico" +
+	"\x02Dirección del servidor\x02Puerto de servicio\x02Usuario\x02Servidor " +
+	"STUN\x02Auth\x02Método\x02Ninguna\x02Fuente\x02Archivo\x02Simbólico\x02S" +
+	"eleccionar archivo de token\x02Secreto\x02Audiencia\x02Alcance\x02Direcc" +
+	"ión de token\x02Alcances adicionales\x02Latidos del corazón\x02Conexión " +
+	"de trabajo\x02Registro\x02Nivel\x02Días máximos\x02Días\x02Admin\x02Dire" +
+	"cción\x02Clave\x02Recurso\x02Seleccione un directorio local desde el que" +
+	" el servidor de administración cargará los recursos.\x02Otras opciones" +
+	"\x02Eliminación automática\x02Absoluto\x02Relativo\x02Eliminar fecha\x02" +
+	"Eliminar días\x02s\x02Conexión\x02Protocolo\x02Opciones Avanzada\x02Pará" +
+	"metros\x02Conexión agotado\x02Keepalive\x02Tiempo de inactividad\x02Cone" +
+	"ctar cuenta\x02Corrientes máximas\x02Latido del corazón\x02Intervalo\x02" +
+	"Tiempo muerto\x02Encender\x02Apagado\x02Nombre de anfitrión\x02Certifica" +
+	"do\x02Seleccionar archivo de certificado\x02Clave de certificado\x02Sele" +
+	"ccionar archivo de clave de certificado\x02CA de confianza\x02Selecciona" +
+	"r archivo CA de confianza\x02Desactivar primer byte personalizado\x02Ava" +
+	"nzado\x02Dirección de la fuente\x02Formato de archivo\x02Mux TCP\x02Sali" +
+	"r después de fallar el inicio de sesión\x02Desactivar el inicio automáti" +
+	"co al arrancar\x02Utilizar formato de archivo heredado\x02Metadatos\x02T" +
+	"amaño del paquete UDP\x02Protocolo de cable\x02URL de proxy\x02Omitir la" +
+	" verificación del certificado\x02Se requiere el archivo de token.\x02La " +
+	"configuración ya existe\x02El nombre de configuración \x22%[1]s\x22 ya e" +
+	"xiste.\x02No se puede actualizar su archivo de configuración debido a un" +
+	" error en la conversión del proxy. Verifique la configuración del proxy " +
+	"e inténtelo nuevamente.\x0a\x0aProxy incorrecto: %[1]s\x02Las siguientes" +
+	" opciones no son compatibles con el formato de archivo heredado y se per" +
+	"derán:\x0a\x0a%[1]s\x0a\x0a¿Está seguro de que desea continuar?\x02Nuevo" +
+	" Proxy\x02Editar Proxy - %[1]s\x02Anotaciones\x02Aleatorio\x02Tipo\x02So" +
+	"licitar encabezados\x02Cabeceras de respuesta\x02Role\x02Servidor\x02Vis" +
+	"itante\x02Llave secreta\x02Dirección local\x02Puerto local\x02Puerto rem" +
+	"oto\x02Permitir usuarios\x02Dirección de enlace\x02Puerto de enlace\x02N" +
+	"ombre del servidor\x02Usuario del servidor\x02Subdominio\x02Dominios per" +
+	"sonalizados\x02Ruta URL\x02Multiplexor\x02Usuario de ruta\x02Cliente\x02" +
+	"Banda ancha\x02Protocolo proxy\x02Auto\x02Por defecto\x02Mantener túnel" +
+	"\x02Cifrado\x02Compresión\x02Deshabilitar direcciones asistidas\x02Repue" +
+	"sto\x02milisegundo\x02Número de reintentos\x02Veces/Hora\x02Intervalo de" +
+	" reintento\x02Usuario HTTP\x02Contraseña HTTP\x02Reescritura de host\x02" +
+	"Enchufar\x02Nombre\x02Ruta Unix\x02Seleccione la ruta de Unix\x02Ruta lo" +
+	"cal\x02Seleccione una carpeta para la lista de directorios.\x02Prefijo d" +
+	"e tira\x02Equilibrio de carga\x02Grupo\x02Clave de grupo\x02Chequeo de s" +
+	"alud\x02Tipo\x02Se acabó el tiempo\x02Intervalo\x02Recuento de fallas" +
+	"\x02El proxy ya existe\x02El nombre de proxy \x22%[1]s\x22 ya existe." +
+	"\x02El nombre del servidor es obligatorio.\x02Se requiere puerto de vinc" +
+	"ulación.\x02Requiere puerto local o complemento.\x02Se requiere direcció" +
+	"n local.\x02Se requiere ruta local.\x02Se requiere la ruta Unix.\x02Puer" +
+	"to local no válido.\x02Se requiere la URL de verificación de estado.\x02" +
+	"El complemento no admite puertos de rango.\x02Puerto remoto no válido." +
+	"\x02La cantidad de puertos locales debe ser la misma que la cantidad de " +
+	"puertos remotos.\x02Los dominios y subdominios personalizados deben tene" +
+	"r al menos uno de estos configurados.\x02Copiar\x02Abrir registro\x02Últ" +
+	"imo\x02Ítem\x02Tipo de NAT\x02Comportamiento\x02Dirección externa\x02Sí" +
+	"\x02No\x02Red pública\x02Desconocido\x02Correr\x02Detenido\x02Comenzando" +
+	"\x02Parada\x02Estado\x02Su conexión al servidor está encriptada\x02Comie" +
+	"nzo\x02Deténgase\x02Detener configuración \x22%[1]s\x22\x02¿Está seguro " +
+	"de que desea detener la configuración \x22%[1]s\x22?\x02Iniciar configur" +
+	"ación \x22%[1]s\x22\x02Directorio local\x02Puerto\x02Puerto abierto\x02P" +
+	"referencias\x02Contraseña maestra\x02Puede establecer una contraseña par" +
+	"a restringir el acceso a este programa.\x0aSe le pedirá que lo ingrese l" +
+	"a próxima vez que use este programa.\x02Usar contraseña maestra\x02Cambi" +
+	"ar la contraseña\x02Cifrar los secretos de las configuraciones con la co" +
+	"ntraseña maestra\x02Idiomas\x02El idioma de visualización actual es\x02D" +
+	"ebe reiniciar el programa para aplicar la modificación.\x02Seleccione el" +
+	" idioma\x02Copia de seguridad\x02Puede hacer una copia de seguridad de t" +
+	"odas las configuraciones, registros y ajustes en un archivo,\x0ay restau" +
+	"rarla en este u otro equipo.\x02Hacer copia\x02Restaurar\x02Ubicación" +
+	"\x02Programar\x02Se guardó la copia de seguridad.\x02Las configuraciones" +
+	" y ajustes de la copia de seguridad reemplazarán a los actuales.\x0a¿Des" +
+	"ea continuar?\x02Se restauraron %[1]d configuraciones. Algunos ajustes s" +
+	"e aplican después de reiniciar el programa.\x02Junto al programa (portát" +
+	"il)\x02Compartida por todos los usuarios\x02Solo el usuario actual\x02Ub" +
+	"icación de los datos\x02Las configuraciones, registros y ajustes se guar" +
+	"dan en:\x02Se moverán todos los datos y se reiniciarán las configuracion" +
+	"es en ejecución.\x0a¿Desea continuar?\x02Todos los datos se movieron a %" +
+	"[1]s.\x02Copia de seguridad programada\x02Directorio\x02Seleccione un di" +
+	"rectorio para guardar las copias de seguridad.\x02Horas\x02Conservar\x02" +
+	"copias\x02* Deje el directorio vacío para desactivar las copias de segur" +
+	"idad programadas.\x02Puedes encontrar más configuraciones aquí.\x0aInclu" +
 	"ye actualizaciones de la aplicación, valores predeterminados iniciales, " +
-	"etc.\x02Ajustes\x02Contraseña eliminada.\x02Nueva contraseña maestra\x02" +
-	"Escriba la contraseña otra vez\x02La contraseña está configurada.\x02Gen" +
-	"eral\x02Buscar actualizaciones automáticamente\x02Predeterminados\x02Niv" +
-	"el de registro\x02Retención de registros\x02Manual\x02Identificador\x02N" +
-	"ombre del servicio\x02Formato de archivo\x02Número de proxies\x02Tipo de" +
-	" inicio\x02%[1]d archivos, %[2]s\x02Número de conexiones TCP\x02Número d" +
-	"e conexiones UDP\x02Empezado\x02Creado\x02Modificado\x02Propiedades de %" +
-	"[1]s\x02Copiar valor\x02Error\x02Añadir rápido\x02Escritorio remoto\x02A" +
-	"gregar escritorio remoto\x02Agregar VNC\x02Agregar SSH\x02Agregar Web" +
-	"\x02Agregar FTP\x02Servidor de archivos HTTP\x02Agregar servidor de arch" +
-	"ivos HTTP\x02Servidor proxy\x02Agregar servidor proxy\x02Deshabilitar" +
-	"\x02Dominios\x02Dirección remota\x02Mostrar dirección remota\x02Copiar d" +
-	"irección de acceso\x02Mensaje de error\x02Esta función solo admite texto" +
-	" en formato INI o TOML.\x02Eliminar proxy \x22%[1]s\x22\x02¿Está seguro " +
-	"de que desea eliminar el proxy \x22%[1]s\x22?\x02Eliminar %[1]d proxies" +
-	"\x02¿Estás seguro de que deseas eliminar estos %[1]d proxies?\x02Deshabi" +
-	"litar proxy \x22%[1]s\x22\x02¿Está seguro de que desea desactivar el pro" +
-	"xy \x22%[1]s\x22?\x02Desactivar %[1]d proxies\x02¿Está seguro de que des" +
-	"ea desactivar estos %[1]d proxies?\x02Habilitar\x02Gama de puertos pasiv" +
-	"os\x02Administrador de FRP\x02* Admite importación por lotes, un enlace " +
-	"por línea.\x02Listo\x02Introduzca la lista de URL correcta.\x02Descargar" +
-	"\x02Introducir la contraseña\x02Debe ingresar una contraseña de administ" +
-	"ración para operar %[1]s.\x02Ingrese la contraseña de administración\x02" +
-	"La contraseña es incorrecta. Escriba la contraseña otra vez.\x02Entrada " +
-	"invalida\x02Ingrese un número de %.[1]f a %.[2]f.\x02Ingrese un número d" +
-	"e %[1]s a %[2]s.\x02Número fuera del rango permitido\x02El texto no coin" +
-	"cide con el patrón requerido.\x02Selección requerida\x02Seleccione una d" +
-	"e las opciones proporcionadas.\x02Se requiere una selección."
+	"etc.\x02Ajustes\x02Desactive el cifrado de los secretos antes de quitar " +
+	"la contraseña maestra.\x02Contraseña eliminada.\x02Nueva contraseña maes" +
+	"tra\x02Escriba la contraseña otra vez\x02La contraseña está configurada." +
+	"\x02La contraseña es incorrecta. Escriba la contraseña otra vez.\x02Gene" +
+	"ral\x02Buscar actualizaciones automáticamente\x02Predeterminados\x02Nive" +
+	"l de registro\x02Retención de registros\x02Manual\x02Identificador\x02No" +
+	"mbre del servicio\x02Número de proxies\x02Tipo de inicio\x02%[1]d archiv" +
+	"os, %[2]s\x02Número de conexiones TCP\x02Número de conexiones UDP\x02Emp" +
+	"ezado\x02Creado\x02Modificado\x02Propiedades de %[1]s\x02Copiar valor" +
+	"\x02Error\x02Añadir rápido\x02Escritorio remoto\x02Agregar escritorio re" +
+	"moto\x02Agregar VNC\x02Agregar SSH\x02Agregar Web\x02Agregar FTP\x02Serv" +
+	"idor de archivos HTTP\x02Agregar servidor de archivos HTTP\x02Servidor p" +
+	"roxy\x02Agregar servidor proxy\x02Deshabilitar\x02Dominios\x02Dirección " +
+	"remota\x02Mostrar dirección remota\x02Copiar dirección de acceso\x02Comp" +
+	"artir los proxies seleccionados\x02Copiar enlace de visitante para compa" +
+	"rtir\x02Mensaje de error\x02Esta función solo admite texto en formato IN" +
+	"I o TOML.\x02Eliminar proxy \x22%[1]s\x22\x02¿Está seguro de que desea e" +
+	"liminar el proxy \x22%[1]s\x22?\x02Eliminar %[1]d proxies\x02¿Estás segu" +
+	"ro de que deseas eliminar estos %[1]d proxies?\x02Deshabilitar proxy " +
+	"\x22%[1]s\x22\x02¿Está seguro de que desea desactivar el proxy \x22%[1]s" +
+	"\x22?\x02Desactivar %[1]d proxies\x02¿Está seguro de que desea desactiva" +
+	"r estos %[1]d proxies?\x02Habilitar\x02Gama de puertos pasivos\x02Admini" +
+	"strador de FRP\x02Comprobación de la configuración\x02Se encontraron los" +
+	" siguientes problemas en la configuración:\x0a\x0a%[1]s\x0a\x0a¿Está seg" +
+	"uro de que desea guardarla?\x02* Admite importación por lotes, un enlace" +
+	" por línea.\x02* Añada \x22#sha256=<suma de comprobación>\x22 a un enlac" +
+	"e para verificar el archivo.\x02Mantener las configuraciones actualizada" +
+	"s desde las URL\x02Listo\x02Introduzca la lista de URL correcta.\x02Desc" +
+	"argar\x02Introducir la contraseña\x02Debe ingresar una contraseña de adm" +
+	"inistración para operar %[1]s.\x02Ingrese la contraseña de administració" +
+	"n\x02Entrada invalida\x02Ingrese un número de %.[1]f a %.[2]f.\x02Ingres" +
+	"e un número de %[1]s a %[2]s.\x02Número fuera del rango permitido\x02El " +
+	"texto no coincide con el patrón requerido.\x02Selección requerida\x02Sel" +
+	"eccione una de las opciones proporcionadas.\x02Se requiere una selección" +
+	"."
 
-var ja_JPIndex = []uint32{ // 312 elements
+var ja_JPIndex = []uint32{ // 374 elements
 	// Entry 0 - 1F
 	0x00000000, 0x00000018, 0x00000034, 0x0000004f,
 	0x00000068, 0x0000007b, 0x00000091, 0x000000a7,
 	0x000000c3, 0x000000eb, 0x00000107, 0x0000010b,
 	0x00000127, 0x00000143, 0x00000159, 0x000001c9,
-	0x0000022d, 0x00000282, 0x000002c2, 0x000002c5,
-	0x000002d5, 0x000002dc, 0x000002e0, 0x000002e7,
-	0x000002ee, 0x00000301, 0x0000030e, 0x0000031b,
-	0x00000322, 0x00000332, 0x00000354, 0x00000370,
+	0x0000022d, 0x00000282, 0x000002c2, 0x000002f0,
+	0x00000318, 0x00000341, 0x000003a5, 0x000003be,
+	0x000003fb, 0x0000041d, 0x0000042a, 0x00000434,
+	0x00000471, 0x0000049d, 0x000004a0, 0x000004b0,
 	// Entry 20 - 3F
-	0x0000039b, 0x000003d1, 0x000003d8, 0x000003df,
-	0x000003ec, 0x000003f9, 0x00000409, 0x00000419,
-	0x0000042f, 0x00000445, 0x0000045e, 0x00000465,
-	0x00000478, 0x00000491, 0x000004aa, 0x000004d5,
-	0x000004e0, 0x000004fc, 0x0000052a, 0x0000053a,
-	0x0000054a, 0x0000055a, 0x00000567, 0x000005a8,
-	0x000005f5, 0x00000610, 0x0000064a, 0x00000678,
-	0x00000694, 0x000006dc, 0x00000701, 0x0000071d,
+	0x000004b7, 0x000004bb, 0x000004c2, 0x000004c9,
+	0x000004dc, 0x000004e9, 0x000004f6, 0x000004fd,
+	0x00000504, 0x00000563, 0x00000573, 0x00000595,
+	0x000005b1, 0x000005dc, 0x00000612, 0x00000619,
+	0x00000620, 0x0000062d, 0x0000063a, 0x0000064a,
+	0x0000065a, 0x00000670, 0x00000686, 0x0000069f,
+	0x000006a6, 0x000006b9, 0x000006d2, 0x000006fd,
+	0x00000719, 0x00000729, 0x00000736, 0x0000075b,
 	// Entry 40 - 5F
-	0x00000741, 0x00000748, 0x00000761, 0x00000774,
-	0x00000781, 0x00000792, 0x00000799, 0x000007a6,
-	0x000007ad, 0x000007c0, 0x000007cd, 0x000007da,
-	0x000007fc, 0x00000806, 0x00000810, 0x00000817,
-	0x0000082a, 0x0000083d, 0x0000084d, 0x0000085a,
-	0x00000861, 0x0000086b, 0x00000878, 0x0000087c,
-	0x00000886, 0x0000089c, 0x000008ac, 0x000008b3,
-	0x0000091a, 0x00000930, 0x0000093d, 0x00000944,
+	0x00000766, 0x00000782, 0x000007b0, 0x000007c0,
+	0x000007d0, 0x000007e0, 0x000007ed, 0x0000082e,
+	0x0000087b, 0x000008ad, 0x000008fa, 0x0000095e,
+	0x0000097d, 0x00000a43, 0x00000a56, 0x00000a75,
+	0x00000a85, 0x00000aa0, 0x00000ada, 0x00000b08,
+	0x00000b24, 0x00000b6c, 0x00000b91, 0x00000bf0,
+	0x00000bf4, 0x00000c19, 0x00000c26, 0x00000c2d,
+	0x00000c34, 0x00000c50, 0x00000c74, 0x00000c7b,
 	// Entry 60 - 7F
-	0x0000094b, 0x00000955, 0x00000962, 0x00000964,
-	0x0000096b, 0x0000097b, 0x00000994, 0x000009a7,
-	0x000009c0, 0x000009d0, 0x000009ef, 0x00000a05,
-	0x00000a1b, 0x00000a2e, 0x00000a35, 0x00000a48,
-	0x00000a4f, 0x00000a56, 0x00000a63, 0x00000a6d,
-	0x00000a8c, 0x00000a9c, 0x00000aca, 0x00000add,
-	0x00000b0f, 0x00000b40, 0x00000b47, 0x00000b5d,
-	0x00000b67, 0x00000b86, 0x00000bb1, 0x00000bdc,
+	0x00000c94, 0x00000ca7, 0x00000cb4, 0x00000cc5,
+	0x00000ccc, 0x00000cd9, 0x00000ce0, 0x00000cf3,
+	0x00000d00, 0x00000d0d, 0x00000d2f, 0x00000d39,
+	0x00000d43, 0x00000d4a, 0x00000d5d, 0x00000d70,
+	0x00000d80, 0x00000d8d, 0x00000d94, 0x00000d9e,
+	0x00000dab, 0x00000daf, 0x00000db9, 0x00000dcf,
+	0x00000ddf, 0x00000de6, 0x00000e4d, 0x00000e63,
+	0x00000e70, 0x00000e77, 0x00000e7e, 0x00000e88,
 	// Entry 80 - 9F
-	0x00000bec, 0x00000c05, 0x00000c1e, 0x00000c2e,
-	0x00000c56, 0x00000c81, 0x00000ca3, 0x00000cd6,
-	0x00000da3, 0x00000db9, 0x00000dd7, 0x00000dde,
-	0x00000deb, 0x00000df5, 0x00000e11, 0x00000e2d,
-	0x00000e34, 0x00000e3e, 0x00000e4b, 0x00000e55,
-	0x00000e6e, 0x00000e84, 0x00000e9a, 0x00000eb6,
-	0x00000ecf, 0x00000ee5, 0x00000ef5, 0x00000f0e,
-	0x00000f21, 0x00000f3a, 0x00000f51, 0x00000f67,
+	0x00000e95, 0x00000e97, 0x00000e9e, 0x00000eae,
+	0x00000ec7, 0x00000eda, 0x00000ef3, 0x00000f03,
+	0x00000f22, 0x00000f38, 0x00000f4e, 0x00000f61,
+	0x00000f68, 0x00000f7b, 0x00000f82, 0x00000f89,
+	0x00000f96, 0x00000fa0, 0x00000fbf, 0x00000fcf,
+	0x00000ffd, 0x00001010, 0x00001042, 0x00001073,
+	0x0000107a, 0x00001090, 0x000010a3, 0x000010ad,
+	0x000010cc, 0x000010f7, 0x00001122, 0x00001132,
 	// Entry A0 - BF
-	0x00000f7d, 0x00000f90, 0x00000f9a, 0x00000fb6,
-	0x00000fbd, 0x00000fc7, 0x00000fe3, 0x00000fed,
-	0x00000ff4, 0x0000101f, 0x00001026, 0x00001030,
-	0x00001043, 0x0000104e, 0x0000105e, 0x00001070,
-	0x00001085, 0x0000109e, 0x000010ae, 0x000010c1,
-	0x000010cd, 0x000010e2, 0x000010f5, 0x00001135,
-	0x00001154, 0x00001161, 0x0000116e, 0x00001184,
-	0x00001191, 0x0000119b, 0x000011ae, 0x000011c1,
+	0x0000114b, 0x00001164, 0x00001174, 0x0000119c,
+	0x000011c7, 0x000011e9, 0x0000121c, 0x000012e9,
+	0x0000138a, 0x000013a0, 0x000013be, 0x000013c5,
+	0x000013d2, 0x000013dc, 0x000013f8, 0x00001414,
+	0x0000141b, 0x00001425, 0x00001432, 0x0000143c,
+	0x00001455, 0x0000146b, 0x00001481, 0x0000149d,
+	0x000014b6, 0x000014cc, 0x000014dc, 0x000014f5,
+	0x00001508, 0x00001521, 0x00001538, 0x0000154e,
 	// Entry C0 - DF
-	0x000011cb, 0x000011f3, 0x0000122c, 0x0000124e,
-	0x00001276, 0x000012b6, 0x000012e1, 0x00001306,
-	0x00001324, 0x0000134c, 0x0000137a, 0x000013c0,
-	0x000013e8, 0x0000144e, 0x000014dd, 0x000014e7,
-	0x00001503, 0x0000150a, 0x00001511, 0x0000151f,
-	0x00001526, 0x00001539, 0x00001540, 0x0000154a,
-	0x00001566, 0x00001576, 0x00001586, 0x0000158d,
-	0x00001594, 0x0000159b, 0x000015a2, 0x000015d9,
+	0x00001564, 0x00001577, 0x00001581, 0x0000159d,
+	0x000015a4, 0x000015ae, 0x000015ca, 0x000015d4,
+	0x000015db, 0x00001606, 0x0000160d, 0x00001617,
+	0x0000162a, 0x00001635, 0x00001645, 0x00001657,
+	0x0000166c, 0x00001685, 0x00001695, 0x000016a8,
+	0x000016b4, 0x000016c9, 0x000016dc, 0x0000171c,
+	0x0000173b, 0x00001748, 0x00001755, 0x0000176b,
+	0x00001778, 0x00001782, 0x00001795, 0x000017a8,
 	// Entry E0 - FF
-	0x000015e3, 0x000015ed, 0x00001611, 0x0000164b,
-	0x0000166f, 0x0000167c, 0x00001686, 0x00001696,
-	0x000016a3, 0x000016bf, 0x0000177b, 0x000017a6,
-	0x000017c5, 0x000017cc, 0x000017e5, 0x0000183d,
-	0x00001853, 0x000018f1, 0x000018f8, 0x00001923,
-	0x00001948, 0x00001952, 0x00001980, 0x00001987,
-	0x000019bb, 0x000019cb, 0x000019db, 0x000019e8,
-	0x000019f8, 0x00001a02, 0x00001a12, 0x00001a25,
+	0x000017b2, 0x000017da, 0x00001813, 0x00001835,
+	0x0000185d, 0x0000189d, 0x000018c8, 0x000018ed,
+	0x0000190b, 0x00001933, 0x00001961, 0x000019a7,
+	0x000019cf, 0x00001a35, 0x00001ac4, 0x00001ace,
+	0x00001aea, 0x00001af1, 0x00001af8, 0x00001b06,
+	0x00001b0d, 0x00001b20, 0x00001b27, 0x00001b31,
+	0x00001b4d, 0x00001b5d, 0x00001b6d, 0x00001b74,
+	0x00001b7b, 0x00001b82, 0x00001b89, 0x00001bc0,
 	// Entry 100 - 11F
-	0x00001a38, 0x00001a57, 0x00001a72, 0x00001a7f,
-	0x00001a8c, 0x00001a99, 0x00001aa6, 0x00001ab3,
-	0x00001acb, 0x00001ad8, 0x00001ae2, 0x00001af5,
-	0x00001b14, 0x00001b42, 0x00001b4f, 0x00001b5c,
-	0x00001b69, 0x00001b76, 0x00001b94, 0x00001bbb,
-	0x00001bd4, 0x00001bf6, 0x00001bfd, 0x00001c0d,
-	0x00001c26, 0x00001c48, 0x00001c6d, 0x00001c86,
-	0x00001ce2, 0x00001d0c, 0x00001d4c, 0x00001d6e,
+	0x00001bca, 0x00001bd4, 0x00001bf8, 0x00001c32,
+	0x00001c56, 0x00001c63, 0x00001c6d, 0x00001c7d,
+	0x00001c8a, 0x00001ca6, 0x00001d62, 0x00001d8d,
+	0x00001dac, 0x00001dfb, 0x00001e02, 0x00001e1b,
+	0x00001e73, 0x00001e89, 0x00001e9c, 0x00001f49,
+	0x00001f5c, 0x00001f63, 0x00001f6a, 0x00001f7d,
+	0x00001fa8, 0x0000201a, 0x00002090, 0x000020c4,
+	0x000020e6, 0x00002102, 0x00002115, 0x00002143,
 	// Entry 120 - 13F
-	0x00001dbc, 0x00001de6, 0x00001e29, 0x00001e54,
-	0x00001ea5, 0x00001eac, 0x00001ec8, 0x00001edc,
-	0x00001f3b, 0x00001f42, 0x00001f76, 0x00001f89,
-	0x00001fa8, 0x00002003, 0x00002025, 0x0000206f,
-	0x0000207c, 0x000020bf, 0x00002100, 0x00002119,
-	0x00002156, 0x00002163, 0x000021af, 0x000021c8,
-} // Size: 1272 bytes
+	0x000021ac, 0x000021e4, 0x000021fd, 0x00002210,
+	0x00002262, 0x00002269, 0x00002273, 0x00002277,
+	0x000022d1, 0x0000236f, 0x00002376, 0x000023e9,
+	0x00002414, 0x00002439, 0x00002443, 0x00002471,
+	0x000024bb, 0x000024c2, 0x000024f6, 0x00002506,
+	0x00002516, 0x00002523, 0x00002533, 0x0000253d,
+	0x0000254d, 0x00002560, 0x0000257f, 0x0000259a,
+	0x000025a7, 0x000025b4, 0x000025c1, 0x000025ce,
+	// Entry 140 - 15F
+	0x000025db, 0x000025f3, 0x00002600, 0x0000260a,
+	0x0000261d, 0x0000263c, 0x0000266a, 0x00002677,
+	0x00002684, 0x00002691, 0x0000269e, 0x000026bc,
+	0x000026e3, 0x000026fc, 0x0000271e, 0x00002725,
+	0x00002735, 0x0000274e, 0x00002770, 0x00002795,
+	0x000027b7, 0x000027e2, 0x000027fb, 0x00002857,
+	0x00002881, 0x000028c1, 0x000028e3, 0x00002931,
+	0x0000295b, 0x0000299e, 0x000029c9, 0x00002a1a,
+	// Entry 160 - 17F
+	0x00002a21, 0x00002a3d, 0x00002a51, 0x00002a67,
+	0x00002ac6, 0x00002b25, 0x00002b98, 0x00002bc4,
+	0x00002bcb, 0x00002bff, 0x00002c12, 0x00002c31,
+	0x00002c8c, 0x00002cae, 0x00002cbb, 0x00002cfe,
+	0x00002d3f, 0x00002d58, 0x00002d95, 0x00002da2,
+	0x00002dee, 0x00002e07,
+} // Size: 1520 bytes
 
-const ja_JPData string = "" + // Size: 8648 bytes
+const ja_JPData string = "" + // Size: 11783 bytes
 	"\x02バージョン：%[1]s\x02FRP バージョン：%[1]s\x02コンパイル日：%[1]s\x02すべてのファイル\x02設定ファイル" +
 	"\x02証明書ファイル\x02秘密鍵ファイル\x02パスワードの不一致\x02もう一度確認してください。\x02新しいバージョン！\x02約" +
 	"\x02更新をダウンロード\x02アップデートの確認\x02更新を確認する\x02コメントやバグの報告については、プロジェクトページにアクセスし" +
 	"てください：\x02FRP のドキュメントについては、FRP プロジェクト ページをご覧ください：\x02ソフトウェアアップデートの確認中に" +
-	"エラーが発生しました。\x02現在、利用可能なアップデートはありません。\x02OK\x02キャンセル\x02名前\x02値\x02追加" +
-	"\x02削除\x02すべてクリア\x02上へ移動\x02下へ移動\x02設定\x02新しい設定\x02ファイルからインポート\x02%[1]s " +
-	"個の設定を削除\x02設定はすでに削除されています\x02設定「%[1]s」は既に削除されています。\x02編集\x02移動\x02下へ移動" +
-	"\x02下へ移動\x02一番上まで\x02一番下まで\x02ファイルを開く\x02フォルダで見て\x02コピーを作成する\x02全て\x02共通" +
-	"設定のみ\x02設定のインポート\x02URLからインポート\x02クリップボードからインポート\x02NAT 検出\x02共有リンクをコピ" +
-	"ー\x02すべての設定をZIPにエクスポート\x02プロパティ\x02すべて選択\x02新しい設定\x02手動設定\x14\x02\x80" +
-	"\x01\x00;\x02%[2]d 中の %[1]d 設定をインポートしました。\x02ファイル「%[1]s」は有効な ZIP ファイルではあ" +
-	"りません。\x02設定「%[1]s」を削除\x02設定「%[1]s」を削除してもよろしいですか?\x02設定は現在ロックされています。" +
-	"\x02%[1]d 個の設定を削除\x02これらの %[1]d 個の設定を削除してもよろしいですか?\x02%[1]d 件成功、%[2]d 件失" +
-	"敗。\x02新しいクライアント\x02クライアントの編集 - %[1]s\x02基本\x02サーバーアドレス\x02サーバポート\x02ユー" +
-	"ザー\x02STUNサーバー\x02認証\x02認証方法\x02なし\x02データソース\x02ファイル\x02トークン\x02トークンファ" +
-	"イルを選択\x02秘密鍵\x02受信者\x02範囲\x02トークンのURL\x02追加スコープ\x02接続を維持\x02作業接続\x02ログ" +
-	"\x02レベル\x02最大日数\x02日\x02管理者\x02管理者アドレス\x02パスワード\x02資産\x02管理サーバーがリソースをロード" +
-	"するローカルディレクトリを選択します。\x02別のオプション\x02自動削除\x02絶対\x02相対\x02削除日\x02日を削除\x02s" +
-	"\x02接続\x02プロトコル\x02高度なオプション\x02パラメーター\x02接続タイムアウト\x02接続を維持\x02アイドルタイムアウト" +
-	"\x02接続プールの数\x02最大ストリーム\x02ハートビート\x02間隔\x02タイムアウト\x02有効\x02無効\x02ホスト名\x02" +
-	"証明書\x02証明書ファイルを選択\x02証明書キー\x02証明書キーファイルを選択します\x02信頼できる CA\x02信頼できる CA " +
-	"ファイルを選択します\x02カスタムの先頭バイトを無効にする\x02高度\x02送信元アドレス\x02多重化\x02ログイン失敗後に終了" +
-	"\x02起動時に自動起動を無効にする\x02従来のファイル形式を使用する\x02メタデータ\x02UDPパケットサイズ\x02ワイヤプロトコル" +
-	"\x02プロキシURL\x02証明書の検証をスキップする\x02トークンファイルが必要です。\x02設定はすでに存在します\x02設定名「%[1" +
-	"]s」はすでに存在します。\x02プロキシ変換が失敗したため、設定ファイルをアップグレードできません。プロキシ設定を確認して、もう一度試してくだ" +
-	"さい。\x0a\x0a不正なプロキシ: %[1]s\x02新しいプロキシ\x02プロキシの編集 - %[1]s\x02注釈\x02ランダム" +
-	"\x02タイプ\x02リクエストヘッダー\x02レスポンスヘッダー\x02役割\x02サーバ\x02ビジター\x02秘密鍵\x02ローカルアドレ" +
-	"ス\x02ローカルポート\x02リモートポート\x02ユーザーを許可する\x02バインドアドレス\x02バインドポート\x02サーバー名" +
-	"\x02サーバーユーザー\x02サブドメイン\x02カスタムドメイン\x02URL ルーティング\x02マルチプレクサ\x02ルートユーザー" +
-	"\x02クライアント\x02帯域幅\x02プロキシプロトコル\x02自動\x02既定値\x02トンネルを維持する\x02暗号化\x02圧縮" +
-	"\x02アシストアドレスを無効にする\x02代替\x02ミリ秒\x02リトライ回数\x02回/時間\x02再試行間隔\x02HTTP ユーザー" +
-	"\x02HTTP パスワード\x02ホストの書き換え\x02プラグイン\x02プラグイン名\x02Unix パス\x02Unix パスを選択" +
-	"\x02ローカルパス\x02ディレクトリリストのフォルダを選択します。\x02プレフィックスを削除\x02負荷平衡\x02グループ\x02グルー" +
-	"プ秘密鍵\x02健康診断\x02タイプ\x02タイムアウト\x02チェック間隔\x02失敗数\x02プロキシはすでに存在します\x02プロキ" +
-	"シ名「%[1]s」はすでに存在します。\x02サービス名は必須です。\x02バインドポートは必須です。\x02ローカルポートまたはプラグイン" +
-	"が必要です。\x02ローカルアドレスは必須です。\x02ローカルパスは必須です。\x02Unix パスは必須です。\x02ローカルポートが無" +
-	"効です。\x02ヘルスチェックのURLは必須です。\x02プラグインは範囲ポートをサポートしていません。\x02無効なリモートポートです。" +
-	"\x02ローカル ポートの数はリモート ポートの数と同じである必要があります。\x02カスタム ドメインとサブドメインには、これらのうち少なくと" +
-	"も 1 つが設定されている必要があります。\x02コピー\x02ログフォルダを開く\x02最新\x02項目\x02NAT タイプ\x02挙動" +
-	"\x02外部アドレス\x02はい\x02いいえ\x02公共のネットワーク\x02わからない\x02ランニング\x02停止\x02起動\x02停止" +
-	"\x02状態\x02サーバーへの接続は暗号化されています\x02始める\x02止まる\x02設定「%[1]s」を停止します\x02設定「%[1]" +
-	"s」を停止してもよろしいですか?\x02設定「%[1]s」を開始します\x02フォルダ\x02ポート\x02ポート開放\x02環境設定\x02マ" +
-	"スターパスワード\x02パスワードを設定して、このプログラムへのアクセスを制限できます。\x0a次回このプログラムを使用するときに入力するよ" +
-	"う求められます。\x02マスターパスワードを使用する\x02パスワードを変更する\x02言語\x02現在の表示言語は\x02変更を適用するに" +
-	"は、プログラムを再起動する必要があります。\x02言語を選択する\x02その他の設定については、こちらをご覧ください。\x0aアプリケーショ" +
-	"ンの更新、初期デフォルト値などが含まれます。\x02設定\x02パスワードが解除されました。\x02新しいマスターパスワード\x02再入力" +
-	"\x02パスワードが設定されています。\x02一般\x02アップデートを自動的にチェックする\x02デフォルト\x02ログレベル\x02ログ保持" +
-	"\x02マニュアル\x02識別子\x02サービス名\x02ファイル形式\x02プロキシの数\x02スタートアップの種類\x02%[1]d ファイ" +
-	"ル、%[2]s\x02TCP接続数\x02UDP接続数\x02起動時間\x02作成時間\x02修正時間\x02%[1]sのプロパティ\x02" +
-	"コピー値\x02エラー\x02クイック追加\x02リモートデスクトップ\x02リモートデスクトップを追加する\x02VNCを追加\x02SS" +
-	"Hを追加\x02Webを追加\x02FTPを追加\x02HTTP ファイルサーバー\x02HTTP ファイルサーバーの追加\x02プロキシサーバ" +
-	"ー\x02プロキシサーバーの追加\x02無効\x02ドメイン名\x02リモートアドレス\x02リモートアドレスを表示\x02アクセスアドレス" +
-	"のコピー\x02エラーメッセージ\x02この機能は、INI または TOML 形式のテキストのみをサポートします。\x02プロキシ「%[1]" +
-	"s」を削除します\x02プロキシ「%[1]s」を削除してもよろしいですか?\x02%[1]d 個のプロキシを削除\x02これらの %[1]d 個" +
-	"のプロキシを削除してもよろしいですか?\x02プロキシ「%[1]s」を無効にする\x02プロキシ「%[1]s」を無効にしてもよろしいですか?" +
-	"\x02%[1]d 個のプロキシを無効にする\x02これらの %[1]d 個のプロキシを無効にしてもよろしいですか?\x02有効\x02パッシブ" +
-	"ポート範囲\x02FRP マネージャ\x02* バッチインポートをサポートします、1行に1つのリンクがあります。\x02準備\x02正しいU" +
-	"RLリストを入力してください。\x02ダウンロード\x02パスワードを入力する\x02%[1]s を操作するには、管理パスワードを入力する必要が" +
-	"あります。\x02管理者パスワードを入力\x02パスワードが正しくありません。 パスワード再入力。\x02無効入力\x02%.[1]f から" +
-	" %.[2]f までの数字を入力してください。\x02%[1]s から %[2]s までの数値を入力してください。\x02許容範囲外の数値" +
-	"\x02テキストが必要なパターンと一致しません。\x02選択必須\x02提供されたオプションのいずれかを選択してください。\x02選択が必要です" +
-	"。"
+	"エラーが発生しました。\x02現在、利用可能なアップデートはありません。\x02すべての設定をZIPにエクスポート\x02新しい設定の既定値" +
+	"を含める\x02frp のストアファイルを含める\x02シークレットを復号して、他のコンピューターで設定を使えるようにする\x02設定のイン" +
+	"ポート\x02%[1]d 個の設定が既存の設定と同じ名前です。\x02コピーとしてインポート\x02スキップ\x02上書き\x02ファイル内" +
+	"の新しい設定の既定値を使用する\x02frp のストアファイルを復元する\x02OK\x02キャンセル\x02名前\x02値\x02追加" +
+	"\x02削除\x02すべてクリア\x02上へ移動\x02下へ移動\x02設定\x02競合\x02一部のポートまたはドメインは他の設定でも使用され" +
+	"ています：\x0a\x0a%[1]s\x02新しい設定\x02ファイルからインポート\x02%[1]s 個の設定を削除\x02設定はすでに削" +
+	"除されています\x02設定「%[1]s」は既に削除されています。\x02編集\x02移動\x02下へ移動\x02下へ移動\x02一番上まで" +
+	"\x02一番下まで\x02ファイルを開く\x02フォルダで見て\x02コピーを作成する\x02全て\x02共通設定のみ\x02URLからインポー" +
+	"ト\x02クリップボードからインポート\x02サブスクリプション\x02今すぐ更新\x02更新履歴\x02サブスクリプションを解除\x02N" +
+	"AT 検出\x02共有リンクをコピー\x02暗号化された共有リンクをコピー\x02プロパティ\x02すべて選択\x02新しい設定\x02手動設定" +
+	"\x14\x02\x80\x01\x00;\x02%[2]d 中の %[1]d 設定をインポートしました。\x02ファイル「%[1]s」は有効な" +
+	" ZIP ファイルではありません。\x02%[1]s：設定「%[2]s」を上書きしました\x02%[1]s：スキップしました。設定「%[2]s」" +
+	"は既に存在します\x02パスフレーズが正しくありません。パスフレーズを再入力してください。\x02署名付きの共有リンク\x02この共有リンク" +
+	"は次の公開鍵で署名されています:\x0a\x0a%[1]s\x0a\x0a鍵が信頼できる送信者のものであることを確認してください。設定をイン" +
+	"ポートしますか?\x02パスフレーズ\x02パスフレーズの再入力\x02共有リンク\x02設定「%[1]s」を削除\x02設定「%[1]s」" +
+	"を削除してもよろしいですか?\x02設定は現在ロックされています。\x02%[1]d 個の設定を削除\x02これらの %[1]d 個の設定を" +
+	"削除してもよろしいですか?\x02%[1]d 件成功、%[2]d 件失敗。\x02設定「%[1]s」は更新を有効にした URL からインポー" +
+	"トされていません。\x02URL\x02まだ更新されていません。\x02更新済み\x02最新\x02失敗\x02新しいクライアント\x02ク" +
+	"ライアントの編集 - %[1]s\x02基本\x02サーバーアドレス\x02サーバポート\x02ユーザー\x02STUNサーバー\x02認証" +
+	"\x02認証方法\x02なし\x02データソース\x02ファイル\x02トークン\x02トークンファイルを選択\x02秘密鍵\x02受信者" +
+	"\x02範囲\x02トークンのURL\x02追加スコープ\x02接続を維持\x02作業接続\x02ログ\x02レベル\x02最大日数\x02日" +
+	"\x02管理者\x02管理者アドレス\x02パスワード\x02資産\x02管理サーバーがリソースをロードするローカルディレクトリを選択します。" +
+	"\x02別のオプション\x02自動削除\x02絶対\x02相対\x02削除日\x02日を削除\x02s\x02接続\x02プロトコル\x02高度" +
+	"なオプション\x02パラメーター\x02接続タイムアウト\x02接続を維持\x02アイドルタイムアウト\x02接続プールの数\x02最大スト" +
+	"リーム\x02ハートビート\x02間隔\x02タイムアウト\x02有効\x02無効\x02ホスト名\x02証明書\x02証明書ファイルを選択" +
+	"\x02証明書キー\x02証明書キーファイルを選択します\x02信頼できる CA\x02信頼できる CA ファイルを選択します\x02カスタムの" +
+	"先頭バイトを無効にする\x02高度\x02送信元アドレス\x02ファイル形式\x02多重化\x02ログイン失敗後に終了\x02起動時に自動起" +
+	"動を無効にする\x02従来のファイル形式を使用する\x02メタデータ\x02UDPパケットサイズ\x02ワイヤプロトコル\x02プロキシUR" +
+	"L\x02証明書の検証をスキップする\x02トークンファイルが必要です。\x02設定はすでに存在します\x02設定名「%[1]s」はすでに存在し" +
+	"ます。\x02プロキシ変換が失敗したため、設定ファイルをアップグレードできません。プロキシ設定を確認して、もう一度試してください。\x0a" +
+	"\x0a不正なプロキシ: %[1]s\x02次のオプションは従来のファイル形式ではサポートされていないため、失われます：\x0a\x0a%[1]" +
+	"s\x0a\x0a続行してもよろしいですか?\x02新しいプロキシ\x02プロキシの編集 - %[1]s\x02注釈\x02ランダム\x02タイ" +
+	"プ\x02リクエストヘッダー\x02レスポンスヘッダー\x02役割\x02サーバ\x02ビジター\x02秘密鍵\x02ローカルアドレス" +
+	"\x02ローカルポート\x02リモートポート\x02ユーザーを許可する\x02バインドアドレス\x02バインドポート\x02サーバー名\x02サ" +
+	"ーバーユーザー\x02サブドメイン\x02カスタムドメイン\x02URL ルーティング\x02マルチプレクサ\x02ルートユーザー\x02ク" +
+	"ライアント\x02帯域幅\x02プロキシプロトコル\x02自動\x02既定値\x02トンネルを維持する\x02暗号化\x02圧縮\x02アシ" +
+	"ストアドレスを無効にする\x02代替\x02ミリ秒\x02リトライ回数\x02回/時間\x02再試行間隔\x02HTTP ユーザー\x02H" +
+	"TTP パスワード\x02ホストの書き換え\x02プラグイン\x02プラグイン名\x02Unix パス\x02Unix パスを選択\x02ローカ" +
+	"ルパス\x02ディレクトリリストのフォルダを選択します。\x02プレフィックスを削除\x02負荷平衡\x02グループ\x02グループ秘密鍵" +
+	"\x02健康診断\x02タイプ\x02タイムアウト\x02チェック間隔\x02失敗数\x02プロキシはすでに存在します\x02プロキシ名「%[1" +
+	"]s」はすでに存在します。\x02サービス名は必須です。\x02バインドポートは必須です。\x02ローカルポートまたはプラグインが必要です。" +
+	"\x02ローカルアドレスは必須です。\x02ローカルパスは必須です。\x02Unix パスは必須です。\x02ローカルポートが無効です。\x02" +
+	"ヘルスチェックのURLは必須です。\x02プラグインは範囲ポートをサポートしていません。\x02無効なリモートポートです。\x02ローカル " +
+	"ポートの数はリモート ポートの数と同じである必要があります。\x02カスタム ドメインとサブドメインには、これらのうち少なくとも 1 つが設" +
+	"定されている必要があります。\x02コピー\x02ログフォルダを開く\x02最新\x02項目\x02NAT タイプ\x02挙動\x02外部ア" +
+	"ドレス\x02はい\x02いいえ\x02公共のネットワーク\x02わからない\x02ランニング\x02停止\x02起動\x02停止\x02状" +
+	"態\x02サーバーへの接続は暗号化されています\x02始める\x02止まる\x02設定「%[1]s」を停止します\x02設定「%[1]s」を" +
+	"停止してもよろしいですか?\x02設定「%[1]s」を開始します\x02フォルダ\x02ポート\x02ポート開放\x02環境設定\x02マス" +
+	"ターパスワード\x02パスワードを設定して、このプログラムへのアクセスを制限できます。\x0a次回このプログラムを使用するときに入力するよう" +
+	"求められます。\x02マスターパスワードを使用する\x02パスワードを変更する\x02マスターパスワードで設定内のシークレットを暗号化する" +
+	"\x02言語\x02現在の表示言語は\x02変更を適用するには、プログラムを再起動する必要があります。\x02言語を選択する\x02バックアップ" +
+	"\x02すべての設定、ログ、環境設定をファイルにバックアップし、\x0aこのコンピューターまたは別のコンピューターで復元できます。\x02バック" +
+	"アップ\x02復元\x02場所\x02スケジュール\x02バックアップを保存しました。\x02バックアップ内の設定と環境設定で現在のものが置" +
+	"き換えられます。\x0a続行しますか?\x02%[1]d 個の設定を復元しました。一部の設定はプログラムの再起動後に有効になります。\x02" +
+	"プログラムと同じ場所（ポータブル）\x02すべてのユーザーで共有\x02現在のユーザーのみ\x02データの場所\x02設定、ログ、環境設定の" +
+	"保存先：\x02すべてのデータが移動され、実行中の設定は再起動されます。\x0a続行しますか?\x02すべてのデータを %[1]s に移動し" +
+	"ました。\x02定期バックアップ\x02ディレクトリ\x02バックアップを保存するディレクトリを選択してください。\x02時間\x02保持数" +
+	"\x02個\x02* ディレクトリを空にすると定期バックアップは無効になります。\x02その他の設定については、こちらをご覧ください。\x0aア" +
+	"プリケーションの更新、初期デフォルト値などが含まれます。\x02設定\x02マスターパスワードを解除する前に、シークレットの暗号化を無効にし" +
+	"てください。\x02パスワードが解除されました。\x02新しいマスターパスワード\x02再入力\x02パスワードが設定されています。\x02" +
+	"パスワードが正しくありません。 パスワード再入力。\x02一般\x02アップデートを自動的にチェックする\x02デフォルト\x02ログレベル" +
+	"\x02ログ保持\x02マニュアル\x02識別子\x02サービス名\x02プロキシの数\x02スタートアップの種類\x02%[1]d ファイル、" +
+	"%[2]s\x02TCP接続数\x02UDP接続数\x02起動時間\x02作成時間\x02修正時間\x02%[1]sのプロパティ\x02コピー値" +
+	"\x02エラー\x02クイック追加\x02リモートデスクトップ\x02リモートデスクトップを追加する\x02VNCを追加\x02SSHを追加" +
+	"\x02Webを追加\x02FTPを追加\x02HTTP ファイルサーバー\x02HTTP ファイルサーバーの追加\x02プロキシサーバー" +
+	"\x02プロキシサーバーの追加\x02無効\x02ドメイン名\x02リモートアドレス\x02リモートアドレスを表示\x02アクセスアドレスのコピ" +
+	"ー\x02選択したプロキシを共有\x02ビジターの共有リンクをコピー\x02エラーメッセージ\x02この機能は、INI または TOML 形" +
+	"式のテキストのみをサポートします。\x02プロキシ「%[1]s」を削除します\x02プロキシ「%[1]s」を削除してもよろしいですか?" +
+	"\x02%[1]d 個のプロキシを削除\x02これらの %[1]d 個のプロキシを削除してもよろしいですか?\x02プロキシ「%[1]s」を無効" +
+	"にする\x02プロキシ「%[1]s」を無効にしてもよろしいですか?\x02%[1]d 個のプロキシを無効にする\x02これらの %[1]d " +
+	"個のプロキシを無効にしてもよろしいですか?\x02有効\x02パッシブポート範囲\x02FRP マネージャ\x02設定のチェック\x02設定" +
+	"に次の問題が見つかりました：\x0a\x0a%[1]s\x0a\x0a保存してもよろしいですか?\x02* バッチインポートをサポートします" +
+	"、1行に1つのリンクがあります。\x02* ファイルを検証するには、リンクの末尾に「#sha256=<チェックサム>」を追加します。\x02" +
+	"URL から設定を最新の状態に保つ\x02準備\x02正しいURLリストを入力してください。\x02ダウンロード\x02パスワードを入力する" +
+	"\x02%[1]s を操作するには、管理パスワードを入力する必要があります。\x02管理者パスワードを入力\x02無効入力\x02%.[1]f " +
+	"から %.[2]f までの数字を入力してください。\x02%[1]s から %[2]s までの数値を入力してください。\x02許容範囲外の数" +
+	"値\x02テキストが必要なパターンと一致しません。\x02選択必須\x02提供されたオプションのいずれかを選択してください。\x02選択が必" +
+	"要です。"
 
-var ko_KRIndex = []uint32{ // 312 elements
+var ko_KRIndex = []uint32{ // 374 elements
 	// Entry 0 - 1F
 	0x00000000, 0x0000000e, 0x00000020, 0x00000035,
 	0x00000043, 0x00000051, 0x00000062, 0x00000070,
 	0x00000081, 0x000000aa, 0x000000bc, 0x000000c7,
 	0x000000e1, 0x000000f5, 0x00000109, 0x00000162,
-	0x000001b3, 0x00000205, 0x0000023b, 0x00000242,
-	0x00000249, 0x00000250, 0x00000254, 0x00000261,
-	0x00000268, 0x00000279, 0x00000287, 0x00000298,
-	0x0000029f, 0x000002aa, 0x000002c4, 0x000002de,
+	0x000001b3, 0x00000205, 0x0000023b, 0x00000264,
+	0x00000283, 0x0000029f, 0x000002f0, 0x00000304,
+	0x00000343, 0x0000035d, 0x0000036a, 0x00000377,
+	0x000003a7, 0x000003c3, 0x000003ca, 0x000003d1,
 	// Entry 20 - 3F
-	0x000002f9, 0x00000329, 0x00000336, 0x00000343,
-	0x00000351, 0x00000362, 0x00000373, 0x00000381,
-	0x0000038f, 0x000003a0, 0x000003b1, 0x000003b8,
-	0x000003d0, 0x000003e4, 0x000003fb, 0x0000041b,
-	0x00000426, 0x0000043b, 0x00000464, 0x0000046b,
-	0x00000479, 0x0000048a, 0x00000498, 0x000004cc,
-	0x00000504, 0x0000051a, 0x00000546, 0x0000056c,
-	0x00000586, 0x000005b6, 0x000005f0, 0x00000604,
+	0x000003d8, 0x000003dc, 0x000003e9, 0x000003f0,
+	0x00000401, 0x0000040f, 0x00000420, 0x00000427,
+	0x0000042e, 0x00000489, 0x00000494, 0x000004ae,
+	0x000004c8, 0x000004e3, 0x00000513, 0x00000520,
+	0x0000052d, 0x0000053b, 0x0000054c, 0x0000055d,
+	0x0000056b, 0x00000579, 0x0000058a, 0x0000059b,
+	0x000005a2, 0x000005ba, 0x000005d1, 0x000005f1,
+	0x000005f8, 0x0000060d, 0x00000622, 0x00000630,
 	// Entry 40 - 5F
-	0x00000623, 0x00000630, 0x0000063e, 0x0000064c,
-	0x00000656, 0x00000662, 0x00000669, 0x00000677,
-	0x0000067e, 0x0000068f, 0x00000696, 0x0000069d,
-	0x000006b2, 0x000006bd, 0x000006cb, 0x000006d2,
-	0x000006dd, 0x000006eb, 0x000006f6, 0x00000704,
-	0x0000070e, 0x00000715, 0x00000723, 0x00000727,
-	0x00000731, 0x00000742, 0x0000074f, 0x00000756,
-	0x000007a9, 0x000007b7, 0x000007c5, 0x000007cc,
+	0x0000063b, 0x00000650, 0x00000672, 0x00000679,
+	0x00000687, 0x00000698, 0x000006a6, 0x000006da,
+	0x00000712, 0x0000073e, 0x00000776, 0x000007cb,
+	0x000007e3, 0x00000898, 0x000008a6, 0x000008be,
+	0x000008cc, 0x000008e2, 0x0000090e, 0x00000934,
+	0x0000094e, 0x0000097e, 0x000009b8, 0x00000a0f,
+	0x00000a13, 0x00000a3c, 0x00000a4c, 0x00000a5a,
+	0x00000a61, 0x00000a75, 0x00000a94, 0x00000aa1,
 	// Entry 60 - 7F
-	0x000007d6, 0x000007e4, 0x000007ef, 0x000007f1,
-	0x000007f8, 0x000007ff, 0x0000080d, 0x0000081a,
-	0x0000082f, 0x00000836, 0x0000084b, 0x00000856,
-	0x00000867, 0x00000874, 0x0000087b, 0x00000888,
-	0x0000088f, 0x00000896, 0x000008a7, 0x000008b1,
-	0x000008c9, 0x000008d7, 0x000008f3, 0x0000090b,
-	0x00000931, 0x0000095a, 0x00000964, 0x00000972,
-	0x0000097c, 0x00000998, 0x000009be, 0x000009dd,
+	0x00000aaf, 0x00000abd, 0x00000ac7, 0x00000ad3,
+	0x00000ada, 0x00000ae8, 0x00000aef, 0x00000b00,
+	0x00000b07, 0x00000b0e, 0x00000b23, 0x00000b2e,
+	0x00000b3c, 0x00000b43, 0x00000b4e, 0x00000b5c,
+	0x00000b67, 0x00000b75, 0x00000b7f, 0x00000b86,
+	0x00000b94, 0x00000b98, 0x00000ba2, 0x00000bb3,
+	0x00000bc0, 0x00000bc7, 0x00000c1a, 0x00000c28,
+	0x00000c36, 0x00000c3d, 0x00000c47, 0x00000c55,
 	// Entry 80 - 9F
-	0x000009ed, 0x000009ff, 0x00000a16, 0x00000a24,
-	0x00000a48, 0x00000a6a, 0x00000a89, 0x00000ac0,
-	0x00000b6d, 0x00000b7b, 0x00000b94, 0x00000b9b,
-	0x00000ba8, 0x00000baf, 0x00000bbd, 0x00000bcb,
-	0x00000bd2, 0x00000bd9, 0x00000be3, 0x00000bee,
-	0x00000bfc, 0x00000c0a, 0x00000c18, 0x00000c29,
-	0x00000c3a, 0x00000c4b, 0x00000c59, 0x00000c6a,
-	0x00000c7b, 0x00000c96, 0x00000ca4, 0x00000cb4,
+	0x00000c60, 0x00000c62, 0x00000c69, 0x00000c70,
+	0x00000c7e, 0x00000c8b, 0x00000ca0, 0x00000ca7,
+	0x00000cbc, 0x00000cc7, 0x00000cd8, 0x00000ce5,
+	0x00000cec, 0x00000cf9, 0x00000d00, 0x00000d07,
+	0x00000d18, 0x00000d22, 0x00000d3a, 0x00000d48,
+	0x00000d64, 0x00000d7c, 0x00000da2, 0x00000dcb,
+	0x00000dd5, 0x00000de3, 0x00000df1, 0x00000dfb,
+	0x00000e17, 0x00000e3d, 0x00000e5c, 0x00000e6c,
 	// Entry A0 - BF
-	0x00000cc5, 0x00000cd5, 0x00000cdf, 0x00000cf6,
-	0x00000cfd, 0x00000d07, 0x00000d15, 0x00000d1f,
-	0x00000d26, 0x00000d41, 0x00000d48, 0x00000d52,
-	0x00000d63, 0x00000d6e, 0x00000d7f, 0x00000d8e,
-	0x00000da0, 0x00000db4, 0x00000dc1, 0x00000dd5,
-	0x00000de1, 0x00000df4, 0x00000e02, 0x00000e3e,
-	0x00000e52, 0x00000e60, 0x00000e67, 0x00000e79,
-	0x00000e87, 0x00000e8e, 0x00000e9c, 0x00000ea3,
+	0x00000e7e, 0x00000e95, 0x00000ea3, 0x00000ec7,
+	0x00000ee9, 0x00000f08, 0x00000f3f, 0x00000fec,
+	0x00001068, 0x00001076, 0x0000108f, 0x00001096,
+	0x000010a3, 0x000010aa, 0x000010b8, 0x000010c6,
+	0x000010cd, 0x000010d4, 0x000010de, 0x000010e9,
+	0x000010f7, 0x00001105, 0x00001113, 0x00001124,
+	0x00001135, 0x00001146, 0x00001154, 0x00001165,
+	0x00001176, 0x00001191, 0x0000119f, 0x000011af,
 	// Entry C0 - DF
-	0x00000eb1, 0x00000ed3, 0x00000f0d, 0x00000f39,
-	0x00000f5e, 0x00000f94, 0x00000fb6, 0x00000fd8,
-	0x00000ff8, 0x00001020, 0x00001046, 0x00001082,
-	0x000010aa, 0x000010ec, 0x00001159, 0x00001160,
-	0x00001175, 0x0000117c, 0x00001183, 0x0000118e,
-	0x00001195, 0x000011a3, 0x000011a7, 0x000011b1,
-	0x000011c5, 0x000011d9, 0x000011e3, 0x000011ed,
-	0x000011f4, 0x000011fb, 0x00001202, 0x00001236,
+	0x000011c0, 0x000011d0, 0x000011da, 0x000011f1,
+	0x000011f8, 0x00001202, 0x00001210, 0x0000121a,
+	0x00001221, 0x0000123c, 0x00001243, 0x0000124d,
+	0x0000125e, 0x00001269, 0x0000127a, 0x00001289,
+	0x0000129b, 0x000012af, 0x000012bc, 0x000012d0,
+	0x000012dc, 0x000012ef, 0x000012fd, 0x00001339,
+	0x0000134d, 0x0000135b, 0x00001362, 0x00001374,
+	0x00001382, 0x00001389, 0x00001397, 0x0000139e,
 	// Entry E0 - FF
-	0x0000123d, 0x00001244, 0x0000125a, 0x00001286,
-	0x0000129c, 0x000012b0, 0x000012b7, 0x000012c5,
-	0x000012cc, 0x000012e3, 0x0000139f, 0x000013bd,
-	0x000013d1, 0x000013d8, 0x000013f0, 0x0000143c,
-	0x0000144a, 0x000014cb, 0x000014d2, 0x000014f3,
-	0x0000150e, 0x00001525, 0x00001550, 0x0000155d,
-	0x0000157e, 0x00001588, 0x00001596, 0x000015a4,
-	0x000015ae, 0x000015b8, 0x000015c9, 0x000015d7,
+	0x000013ac, 0x000013ce, 0x00001408, 0x00001434,
+	0x00001459, 0x0000148f, 0x000014b1, 0x000014d3,
+	0x000014f3, 0x0000151b, 0x00001541, 0x0000157d,
+	0x000015a5, 0x000015e7, 0x00001654, 0x0000165b,
+	0x00001670, 0x00001677, 0x0000167e, 0x00001689,
+	0x00001690, 0x0000169e, 0x000016a2, 0x000016ac,
+	0x000016c0, 0x000016d4, 0x000016de, 0x000016e8,
+	0x000016ef, 0x000016f6, 0x000016fd, 0x00001731,
 	// Entry 100 - 11F
-	0x000015e5, 0x000015f3, 0x0000160a, 0x00001619,
-	0x00001628, 0x00001636, 0x00001644, 0x00001652,
-	0x0000165f, 0x0000166a, 0x00001671, 0x0000167f,
-	0x00001693, 0x000016ae, 0x000016b9, 0x000016c4,
-	0x000016cf, 0x000016da, 0x000016ed, 0x00001707,
-	0x00001718, 0x00001730, 0x00001737, 0x00001741,
-	0x0000174f, 0x00001764, 0x0000177c, 0x0000178d,
-	0x000017d3, 0x000017ec, 0x0000181b, 0x00001838,
+	0x00001738, 0x0000173f, 0x00001755, 0x00001781,
+	0x00001797, 0x000017ab, 0x000017b2, 0x000017c0,
+	0x000017c7, 0x000017de, 0x0000189a, 0x000018b8,
+	0x000018cc, 0x00001908, 0x0000190f, 0x00001927,
+	0x00001973, 0x00001981, 0x00001988, 0x00001a07,
+	0x00001a0e, 0x00001a15, 0x00001a1c, 0x00001a23,
+	0x00001a44, 0x00001a9f, 0x00001b13, 0x00001b2f,
+	0x00001b4a, 0x00001b5e, 0x00001b6f, 0x00001b98,
 	// Entry 120 - 13F
-	0x0000186b, 0x0000188a, 0x000018bf, 0x000018e2,
-	0x0000191f, 0x00001926, 0x0000193e, 0x0000194c,
-	0x00001995, 0x000019a3, 0x000019cc, 0x000019d9,
-	0x000019ea, 0x00001a31, 0x00001a4c, 0x00001a9f,
-	0x00001ab0, 0x00001ae8, 0x00001b22, 0x00001b44,
-	0x00001b7d, 0x00001b8b, 0x00001bbe, 0x00001bd9,
-} // Size: 1272 bytes
+	0x00001c03, 0x00001c39, 0x00001c47, 0x00001c54,
+	0x00001c89, 0x00001c90, 0x00001c97, 0x00001c9b,
+	0x00001cea, 0x00001d6b, 0x00001d72, 0x00001dcc,
+	0x00001ded, 0x00001e08, 0x00001e1f, 0x00001e4a,
+	0x00001e9d, 0x00001eaa, 0x00001ecb, 0x00001ed5,
+	0x00001ee3, 0x00001ef1, 0x00001efb, 0x00001f05,
+	0x00001f16, 0x00001f24, 0x00001f32, 0x00001f49,
+	0x00001f58, 0x00001f67, 0x00001f75, 0x00001f83,
+	// Entry 140 - 15F
+	0x00001f91, 0x00001f9e, 0x00001fa9, 0x00001fb0,
+	0x00001fbe, 0x00001fd2, 0x00001fed, 0x00001ff8,
+	0x00002003, 0x0000200e, 0x00002019, 0x0000202c,
+	0x00002046, 0x00002057, 0x0000206f, 0x00002076,
+	0x00002080, 0x0000208e, 0x000020a3, 0x000020bb,
+	0x000020d6, 0x000020f5, 0x00002106, 0x0000214c,
+	0x00002165, 0x00002194, 0x000021b1, 0x000021e4,
+	0x00002203, 0x00002238, 0x0000225b, 0x00002298,
+	// Entry 160 - 17F
+	0x0000229f, 0x000022b7, 0x000022c5, 0x000022d3,
+	0x0000232a, 0x00002373, 0x000023c7, 0x000023f3,
+	0x00002401, 0x0000242a, 0x00002437, 0x00002448,
+	0x0000248f, 0x000024aa, 0x000024bb, 0x000024f3,
+	0x0000252d, 0x0000254f, 0x00002588, 0x00002596,
+	0x000025c9, 0x000025e4,
+} // Size: 1520 bytes
 
-const ko_KRData string = "" + // Size: 7129 bytes
+const ko_KRData string = "" + // Size: 9700 bytes
 	"\x02버전: %[1]s\x02FRP 버전: %[1]s\x02빌드 날짜: %[1]s\x02모든 파일\x02구성 파일\x02인증서 " +
 	"파일\x02열쇠 파일\x02암호 불일치\x02확인하고 다시 시도해 주세요.\x02새로운 버전!\x02에 대한\x02업데이트 다" +
 	"운로드\x02업데이트 확인\x02업데이트 확인\x02의견을 보거나 버그를 보고하려면 프로젝트 페이지를 방문하세요:\x02FRP" +
 	" 구성 문서를 보려면 FRP 프로젝트 페이지를 방문하십시오:\x02소프트웨어 업데이트를 확인하는 동안 오류가 발생했습니다.\x02" +
-	"현재 사용 가능한 업데이트가 없습니다.\x02확인\x02취소\x02이름\x02값\x02추가하다\x02삭제\x02모두 지우기" +
-	"\x02위로 이동\x02아래로 이동\x02구성\x02새 구성\x02파일에서 가져오기\x02%[1]s개의 구성 삭제\x02구성이 이" +
-	"미 삭제됨\x02\x22%[1]s\x22 구성이 이미 제거되었습니다.\x02편집하다\x02이동하기\x02위로 이동\x02아래로" +
-	" 이동\x02상단에 고정\x02맨 아래로\x02파일 열기\x02폴더에 표시\x02복사본 생성\x02모두\x02일반 구성만 해당" +
-	"\x02구성 가져오기\x02URL에서 가져오기\x02클립보드에서 가져오기\x02NAT 검색\x02공유 링크 복사\x02모든 구성을" +
-	" ZIP 으로 내보내기\x02속성\x02전체 선택\x02구성 만들기\x02수동 설정\x02%[2]d개 구성 중 %[1]d개를 가져" +
-	"왔습니다.\x02\x22%[1]s\x22 파일은 유효한 ZIP 파일이 아닙니다.\x02\x22%[1]s\x22 구성 삭제" +
-	"\x02\x22%[1]s\x22 구성을 삭제하시겠습니까?\x02구성이 현재 잠겨 있습니다.\x02%[1]d개의 구성 삭제\x02%" +
-	"[1]d개의 구성을 삭제하시겠습니까?\x02%[1]d개가 성공했고, %[2]d개가 실패했습니다.\x02새 클라이언트\x02클라이언" +
-	"트 편집 - %[1]s\x02기초적인\x02서버 주소\x02서버 포트\x02사용자\x02STUN 서버\x02인증\x02인증 방" +
-	"법\x02없음\x02데이터 소스\x02파일\x02토큰\x02토큰 파일 선택\x02비밀 키\x02받는 사람\x02범위\x02토큰" +
-	" URL\x02추가 범위\x02대기 중\x02작동 연결\x02통나무\x02수준\x02최대 일수\x02날\x02관리자\x02관리자 " +
-	"주소\x02비밀번호\x02자산\x02관리 서버가 리소스를 로드할 로컬 디렉토리를 선택하십시오.\x02다른 옵션\x02자동 삭제" +
-	"\x02절대\x02상대적\x02날짜 삭제\x02삭제 일\x02s\x02연결\x02규약\x02고급 옵션\x02매개변수\x02연결 시" +
-	"간 초과\x02유지\x02유휴 시간 초과\x02연결 수\x02최대 스트림\x02심장박동\x02간격\x02타임아웃\x02켜다" +
-	"\x02폐쇄\x02호스트 이름\x02자격증\x02인증서 파일 선택\x02인증서 키\x02인증서 키 파일 선택\x02신뢰할 수 있는" +
-	" CA\x02신뢰할 수 있는 CA 파일 선택\x02맞춤 첫 번째 바이트 비활성화\x02고급의\x02소스 주소\x02다중화\x02로" +
-	"그인 실패 후 종료\x02부팅 시 자동 시작 비활성화\x02레거시 파일 형식 사용\x02메타데이터\x02UDP 패킷 크기" +
-	"\x02와이어 프로토콜\x02프록시 URL\x02인증서 확인을 건너뛰세요\x02토큰 파일이 필요합니다.\x02구성이 이미 있습니다" +
-	".\x02구성 이름 \x22%[1]s\x22 이(가) 이미 존재합니다.\x02프록시 변환 실패로 인해 구성 파일을 업그레이드할 수" +
-	" 없습니다. 프록시 구성을 확인하고 다시 시도하세요.\x0a\x0a잘못된 프록시: %[1]s\x02새 프록시\x02프록시 편집 -" +
-	" %[1]s\x02주석\x02무작위의\x02유형\x02요청 헤더\x02응답 헤더\x02역할\x02서버\x02방문객\x02비밀 키" +
-	"\x02지역 주소\x02로컬 포트\x02원격 포트\x02사용자 허용\x02바인드 주소\x02바인드 포트\x02서버 이름\x02서버" +
-	" 사용자\x02하위 도메인\x02사용자 정의 도메인\x02URL 라우팅\x02멀티플렉서\x02경로 사용자\x02클라이언트\x02대" +
-	"역폭\x02프록시 프로토콜\x02자동\x02기본값\x02터널 유지\x02암호화\x02압축\x02보조 주소 비활성화\x02폴백" +
-	"\x02밀리초\x02재시도 횟수\x02회/시간\x02재시도 간격\x02HTTP 사용자\x02HTTP 비밀번호\x02호스트 재작성" +
-	"\x02플러그인\x02플러그인 이름\x02Unix 경로\x02선택 Unix 경로\x02로컬 경로\x02디렉토리 목록에 대한 폴더를" +
-	" 선택하십시오.\x02스트립 접두사\x02부하 분산\x02그룹\x02그룹 비밀 키\x02건강 체크\x02유형\x02시간 초과" +
-	"\x02간격\x02실패 횟수\x02프록시가 이미 있습니다.\x02프록시 이름 \x22%[1]s\x22 이(가) 이미 존재합니다." +
-	"\x02서비스 이름은 필수 항목입니다.\x02바인드 포트가 필요합니다.\x02로컬 포트 또는 플러그인이 필요합니다.\x02현지 주" +
-	"소가 필요합니다.\x02로컬 경로가 필요합니다.\x02Unix 경로가 필요합니다.\x02로컬 포트가 잘못되었습니다.\x02상태" +
-	" 확인 URL이 필요합니다.\x02플러그인은 범위 포트를 지원하지 않습니다.\x02원격 포트가 잘못되었습니다.\x02로컬 포트 수" +
-	"는 원격 포트 수와 동일해야 합니다.\x02사용자 정의 도메인 및 하위 도메인에는 이러한 세트가 하나 이상 있어야 합니다." +
-	"\x02복사\x02로그 폴더 열기\x02최신\x02안건\x02NAT 유형\x02행실\x02외부 주소\x02예\x02아니요\x02공" +
-	"용 네트워크\x02알려지지 않은\x02달리기\x02중지됨\x02시작\x02멎는\x02상태\x02서버에 대한 연결이 암호화되었습" +
-	"니다\x02시작\x02중지\x02\x22%[1]s\x22 구성 중지\x02\x22%[1]s\x22 구성을 중지하시겠습니까?" +
-	"\x02구성 \x22%[1]s\x22 시작\x02로컬 디렉토리\x02포트\x02오픈 포트\x02옵션\x02마스터 비밀번호\x02이" +
-	" 프로그램에 대한 액세스를 제한하기 위해 암호를 설정할 수 있습니다.\x0a다음에 이 프로그램을 사용할 때 입력하라는 메시지가 표" +
-	"시됩니다.\x02마스터 비밀번호 사용\x02비밀번호 변경\x02언어\x02현재 표시 언어는\x02수정 사항을 적용하려면 프로그" +
-	"램을 재시작해야 합니다.\x02언어 선택\x02더 많은 설정은 여기에서 확인할 수 있습니다.\x0a애플리케이션 업데이트, 기본" +
-	"값 등이 포함됩니다.\x02설정\x02암호가 제거되었습니다.\x02새 마스터 비밀번호\x02비밀번호 재입력\x02비밀번호가 설" +
-	"정되어 있습니다.\x02일반적인\x02자동으로 업데이트 확인\x02기본값\x02로그 수준\x02로그 보존\x02매뉴얼\x02식" +
-	"별자\x02서비스 이름\x02파일 형식\x02프록시 수\x02시작 유형\x02%[1]d개 파일, %[2]s\x02TCP 연결 " +
-	"수\x02UDP 연결 수\x02시작 시간\x02창조 시간\x02수정 시간\x02%[1]s 속성\x02복사 값\x02오류\x02" +
-	"빠른 추가\x02원격 데스크탑\x02원격 데스크톱 추가\x02VNC 추가\x02SSH 추가\x02Web 추가\x02FTP 추가" +
-	"\x02HTTP 파일 서버\x02HTTP 파일 서버 추가\x02프록시 서버\x02프록시 서버 추가\x02폐쇄\x02도메인\x02원" +
-	"격 주소\x02원격 주소 표시\x02액세스 주소 복사\x02오류 메시지\x02이 기능은 INI 또는 TOML 형식의 텍스트만 " +
-	"지원합니다.\x02프록시 \x22%[1]s\x22 삭제\x02\x22%[1]s\x22 프록시를 삭제하시겠습니까?\x02%[1]" +
-	"d개의 프록시 삭제\x02%[1]d개의 프록시를 삭제하시겠습니까?\x02프록시 \x22%[1]s\x22 비활성화\x02\x22%[" +
-	"1]s\x22 프록시를 비활성화하시겠습니까?\x02%[1]d개의 프록시 비활성화\x02이 %[1]d개의 프록시를 비활성화하시겠습니" +
-	"까?\x02켜다\x02패시브 포트 범위\x02FRP 관리자\x02* 한 줄에 하나의 링크로 일괄 가져오기를 지원합니다.\x02" +
-	"준비가 된\x02올바른 URL 목록을 입력하세요.\x02다운로드\x02암호를 입력\x02%[1]s을(를) 작동하려면 관리 암호" +
-	"를 입력해야 합니다.\x02관리 비밀번호 입력\x02비밀번호가 올바르지 않습니다. 비밀번호를 다시 입력하세요.\x02잘못된 입" +
-	"력\x02%.[1]f에서 %.[2]f까지의 숫자를 입력하세요.\x02%[1]s에서 %[2]s 사이의 숫자를 입력하십시오." +
-	"\x02허용 범위를 벗어난 숫자\x02텍스트가 필수 패턴과 일치하지 않습니다.\x02선택 필수\x02제공된 옵션 중 하나를 선택하" +
-	"십시오.\x02선택이 필요합니다."
+	"현재 사용 가능한 업데이트가 없습니다.\x02모든 구성을 ZIP 으로 내보내기\x02새 구성의 기본값 포함\x02frp 저장소" +
+	" 파일 포함\x02다른 컴퓨터에서 구성을 사용할 수 있도록 비밀 정보 복호화\x02구성 가져오기\x02%[1]d개의 구성이 기존 " +
+	"구성과 이름이 같습니다.\x02사본으로 가져오기\x02건너뛰기\x02덮어쓰기\x02파일에 있는 새 구성의 기본값 사용\x02f" +
+	"rp 저장소 파일 복원\x02확인\x02취소\x02이름\x02값\x02추가하다\x02삭제\x02모두 지우기\x02위로 이동\x02" +
+	"아래로 이동\x02구성\x02충돌\x02일부 포트 또는 도메인을 다른 구성에서도 사용하고 있습니다:\x0a\x0a%[1]s" +
+	"\x02새 구성\x02파일에서 가져오기\x02%[1]s개의 구성 삭제\x02구성이 이미 삭제됨\x02\x22%[1]s\x22 구성" +
+	"이 이미 제거되었습니다.\x02편집하다\x02이동하기\x02위로 이동\x02아래로 이동\x02상단에 고정\x02맨 아래로" +
+	"\x02파일 열기\x02폴더에 표시\x02복사본 생성\x02모두\x02일반 구성만 해당\x02URL에서 가져오기\x02클립보드에서" +
+	" 가져오기\x02구독\x02지금 새로 고침\x02새로 고침 기록\x02구독 취소\x02NAT 검색\x02공유 링크 복사\x02암호" +
+	"화된 공유 링크 복사\x02속성\x02전체 선택\x02구성 만들기\x02수동 설정\x02%[2]d개 구성 중 %[1]d개를 가" +
+	"져왔습니다.\x02\x22%[1]s\x22 파일은 유효한 ZIP 파일이 아닙니다.\x02%[1]s: \x22%[2]s\x22 " +
+	"구성을 덮어썼습니다\x02%[1]s: 건너뜀, \x22%[2]s\x22 구성이 이미 있습니다\x02암호 문구가 올바르지 않습니" +
+	"다. 암호 문구를 다시 입력하세요.\x02서명된 공유 링크\x02이 공유 링크는 다음 공개 키로 서명되었습니다.\x0a\x0a" +
+	"%[1]s\x0a\x0a키가 신뢰할 수 있는 보낸 사람의 것인지 확인하세요. 구성을 가져오시겠습니까?\x02암호 문구\x02암호 " +
+	"문구 재입력\x02공유 링크\x02\x22%[1]s\x22 구성 삭제\x02\x22%[1]s\x22 구성을 삭제하시겠습니까?" +
+	"\x02구성이 현재 잠겨 있습니다.\x02%[1]d개의 구성 삭제\x02%[1]d개의 구성을 삭제하시겠습니까?\x02%[1]d개가" +
+	" 성공했고, %[2]d개가 실패했습니다.\x02\x22%[1]s\x22 구성은 업데이트를 사용하는 URL에서 가져오지 않았습니다." +
+	"\x02URL\x02아직 새로 고치지 않았습니다.\x02업데이트됨\x02최신 상태\x02실패\x02새 클라이언트\x02클라이언트 " +
+	"편집 - %[1]s\x02기초적인\x02서버 주소\x02서버 포트\x02사용자\x02STUN 서버\x02인증\x02인증 방법" +
+	"\x02없음\x02데이터 소스\x02파일\x02토큰\x02토큰 파일 선택\x02비밀 키\x02받는 사람\x02범위\x02토큰 UR" +
+	"L\x02추가 범위\x02대기 중\x02작동 연결\x02통나무\x02수준\x02최대 일수\x02날\x02관리자\x02관리자 주소" +
+	"\x02비밀번호\x02자산\x02관리 서버가 리소스를 로드할 로컬 디렉토리를 선택하십시오.\x02다른 옵션\x02자동 삭제\x02" +
+	"절대\x02상대적\x02날짜 삭제\x02삭제 일\x02s\x02연결\x02규약\x02고급 옵션\x02매개변수\x02연결 시간 " +
+	"초과\x02유지\x02유휴 시간 초과\x02연결 수\x02최대 스트림\x02심장박동\x02간격\x02타임아웃\x02켜다\x02" +
+	"폐쇄\x02호스트 이름\x02자격증\x02인증서 파일 선택\x02인증서 키\x02인증서 키 파일 선택\x02신뢰할 수 있는 C" +
+	"A\x02신뢰할 수 있는 CA 파일 선택\x02맞춤 첫 번째 바이트 비활성화\x02고급의\x02소스 주소\x02파일 형식\x02다" +
+	"중화\x02로그인 실패 후 종료\x02부팅 시 자동 시작 비활성화\x02레거시 파일 형식 사용\x02메타데이터\x02UDP 패" +
+	"킷 크기\x02와이어 프로토콜\x02프록시 URL\x02인증서 확인을 건너뛰세요\x02토큰 파일이 필요합니다.\x02구성이 이" +
+	"미 있습니다.\x02구성 이름 \x22%[1]s\x22 이(가) 이미 존재합니다.\x02프록시 변환 실패로 인해 구성 파일을 " +
+	"업그레이드할 수 없습니다. 프록시 구성을 확인하고 다시 시도하세요.\x0a\x0a잘못된 프록시: %[1]s\x02다음 옵션은 " +
+	"레거시 파일 형식에서 지원되지 않으므로 손실됩니다:\x0a\x0a%[1]s\x0a\x0a계속하시겠습니까?\x02새 프록시" +
+	"\x02프록시 편집 - %[1]s\x02주석\x02무작위의\x02유형\x02요청 헤더\x02응답 헤더\x02역할\x02서버\x02" +
+	"방문객\x02비밀 키\x02지역 주소\x02로컬 포트\x02원격 포트\x02사용자 허용\x02바인드 주소\x02바인드 포트" +
+	"\x02서버 이름\x02서버 사용자\x02하위 도메인\x02사용자 정의 도메인\x02URL 라우팅\x02멀티플렉서\x02경로 사용" +
+	"자\x02클라이언트\x02대역폭\x02프록시 프로토콜\x02자동\x02기본값\x02터널 유지\x02암호화\x02압축\x02보조" +
+	" 주소 비활성화\x02폴백\x02밀리초\x02재시도 횟수\x02회/시간\x02재시도 간격\x02HTTP 사용자\x02HTTP 비밀" +
+	"번호\x02호스트 재작성\x02플러그인\x02플러그인 이름\x02Unix 경로\x02선택 Unix 경로\x02로컬 경로\x02" +
+	"디렉토리 목록에 대한 폴더를 선택하십시오.\x02스트립 접두사\x02부하 분산\x02그룹\x02그룹 비밀 키\x02건강 체크" +
+	"\x02유형\x02시간 초과\x02간격\x02실패 횟수\x02프록시가 이미 있습니다.\x02프록시 이름 \x22%[1]s\x22 " +
+	"이(가) 이미 존재합니다.\x02서비스 이름은 필수 항목입니다.\x02바인드 포트가 필요합니다.\x02로컬 포트 또는 플러그인" +
+	"이 필요합니다.\x02현지 주소가 필요합니다.\x02로컬 경로가 필요합니다.\x02Unix 경로가 필요합니다.\x02로컬 포트" +
+	"가 잘못되었습니다.\x02상태 확인 URL이 필요합니다.\x02플러그인은 범위 포트를 지원하지 않습니다.\x02원격 포트가 잘" +
+	"못되었습니다.\x02로컬 포트 수는 원격 포트 수와 동일해야 합니다.\x02사용자 정의 도메인 및 하위 도메인에는 이러한 세트" +
+	"가 하나 이상 있어야 합니다.\x02복사\x02로그 폴더 열기\x02최신\x02안건\x02NAT 유형\x02행실\x02외부 주" +
+	"소\x02예\x02아니요\x02공용 네트워크\x02알려지지 않은\x02달리기\x02중지됨\x02시작\x02멎는\x02상태" +
+	"\x02서버에 대한 연결이 암호화되었습니다\x02시작\x02중지\x02\x22%[1]s\x22 구성 중지\x02\x22%[1]s" +
+	"\x22 구성을 중지하시겠습니까?\x02구성 \x22%[1]s\x22 시작\x02로컬 디렉토리\x02포트\x02오픈 포트\x02옵" +
+	"션\x02마스터 비밀번호\x02이 프로그램에 대한 액세스를 제한하기 위해 암호를 설정할 수 있습니다.\x0a다음에 이 프로그램" +
+	"을 사용할 때 입력하라는 메시지가 표시됩니다.\x02마스터 비밀번호 사용\x02비밀번호 변경\x02마스터 비밀번호로 구성의 비" +
+	"밀 정보 암호화\x02언어\x02현재 표시 언어는\x02수정 사항을 적용하려면 프로그램을 재시작해야 합니다.\x02언어 선택" +
+	"\x02백업\x02모든 구성, 로그 및 설정을 파일로 백업하고\x0a이 컴퓨터나 다른 컴퓨터에서 복원할 수 있습니다.\x02백업" +
+	"\x02복원\x02위치\x02예약\x02백업이 저장되었습니다.\x02백업의 구성 및 설정이 현재 항목을 대체합니다.\x0a계속하시" +
+	"겠습니까?\x02%[1]d개의 구성을 복원했습니다. 일부 설정은 프로그램을 다시 시작한 후에 적용됩니다.\x02프로그램 옆(포" +
+	"터블)\x02모든 사용자가 공유\x02현재 사용자만\x02데이터 위치\x02구성, 로그 및 설정 저장 위치:\x02모든 데이터" +
+	"가 이동되고 실행 중인 구성이 다시 시작됩니다.\x0a계속하시겠습니까?\x02모든 데이터를 %[1]s(으)로 이동했습니다." +
+	"\x02예약 백업\x02디렉터리\x02백업을 저장할 디렉터리를 선택하세요.\x02시간\x02보관\x02개\x02* 예약 백업을 사" +
+	"용하지 않으려면 디렉터리를 비워 두세요.\x02더 많은 설정은 여기에서 확인할 수 있습니다.\x0a애플리케이션 업데이트, 기본" +
+	"값 등이 포함됩니다.\x02설정\x02마스터 비밀번호를 제거하기 전에 비밀 정보 암호화를 해제하세요.\x02암호가 제거되었습니" +
+	"다.\x02새 마스터 비밀번호\x02비밀번호 재입력\x02비밀번호가 설정되어 있습니다.\x02비밀번호가 올바르지 않습니다. 비" +
+	"밀번호를 다시 입력하세요.\x02일반적인\x02자동으로 업데이트 확인\x02기본값\x02로그 수준\x02로그 보존\x02매뉴얼" +
+	"\x02식별자\x02서비스 이름\x02프록시 수\x02시작 유형\x02%[1]d개 파일, %[2]s\x02TCP 연결 수\x02U" +
+	"DP 연결 수\x02시작 시간\x02창조 시간\x02수정 시간\x02%[1]s 속성\x02복사 값\x02오류\x02빠른 추가" +
+	"\x02원격 데스크탑\x02원격 데스크톱 추가\x02VNC 추가\x02SSH 추가\x02Web 추가\x02FTP 추가\x02HTT" +
+	"P 파일 서버\x02HTTP 파일 서버 추가\x02프록시 서버\x02프록시 서버 추가\x02폐쇄\x02도메인\x02원격 주소" +
+	"\x02원격 주소 표시\x02액세스 주소 복사\x02선택한 프록시 공유\x02방문객 공유 링크 복사\x02오류 메시지\x02이 기" +
+	"능은 INI 또는 TOML 형식의 텍스트만 지원합니다.\x02프록시 \x22%[1]s\x22 삭제\x02\x22%[1]s" +
+	"\x22 프록시를 삭제하시겠습니까?\x02%[1]d개의 프록시 삭제\x02%[1]d개의 프록시를 삭제하시겠습니까?\x02프록시 " +
+	"\x22%[1]s\x22 비활성화\x02\x22%[1]s\x22 프록시를 비활성화하시겠습니까?\x02%[1]d개의 프록시 비활성화" +
+	"\x02이 %[1]d개의 프록시를 비활성화하시겠습니까?\x02켜다\x02패시브 포트 범위\x02FRP 관리자\x02구성 검사" +
+	"\x02구성에서 다음 문제가 발견되었습니다:\x0a\x0a%[1]s\x0a\x0a저장하시겠습니까?\x02* 한 줄에 하나의 링크로" +
+	" 일괄 가져오기를 지원합니다.\x02* 파일을 확인하려면 링크 뒤에 \x22#sha256=<체크섬>\x22을 추가하세요.\x02U" +
+	"RL에서 구성을 최신 상태로 유지\x02준비가 된\x02올바른 URL 목록을 입력하세요.\x02다운로드\x02암호를 입력\x02%" +
+	"[1]s을(를) 작동하려면 관리 암호를 입력해야 합니다.\x02관리 비밀번호 입력\x02잘못된 입력\x02%.[1]f에서 %.[2" +
+	"]f까지의 숫자를 입력하세요.\x02%[1]s에서 %[2]s 사이의 숫자를 입력하십시오.\x02허용 범위를 벗어난 숫자\x02텍스" +
+	"트가 필수 패턴과 일치하지 않습니다.\x02선택 필수\x02제공된 옵션 중 하나를 선택하십시오.\x02선택이 필요합니다."
 
-var zh_CNIndex = []uint32{ // 312 elements
+var zh_CNIndex = []uint32{ // 374 elements
 	// Entry 0 - 1F
 	0x00000000, 0x0000000f, 0x00000022, 0x00000037,
 	0x00000044, 0x00000051, 0x0000005e, 0x0000006b,
 	0x0000007b, 0x00000091, 0x000000a1, 0x000000a8,
 	0x000000b5, 0x000000c8, 0x000000d5, 0x00000112,
-	0x00000150, 0x0000016f, 0x0000018e, 0x00000195,
-	0x0000019c, 0x000001a3, 0x000001a7, 0x000001ae,
-	0x000001b5, 0x000001c2, 0x000001c9, 0x000001d0,
-	0x000001d7, 0x000001e4, 0x000001f4, 0x0000020b,
+	0x00000150, 0x0000016f, 0x0000018e, 0x000001b1,
+	0x000001cd, 0x000001e8, 0x00000225, 0x00000232,
+	0x0000025e, 0x00000271, 0x00000278, 0x0000027f,
+	0x000002a4, 0x000002bf, 0x000002c6, 0x000002cd,
 	// Entry 20 - 3F
-	0x0000021b, 0x0000023c, 0x00000243, 0x0000024a,
-	0x00000251, 0x00000258, 0x0000025f, 0x00000266,
-	0x00000273, 0x00000289, 0x00000296, 0x0000029d,
-	0x000002ad, 0x000002ba, 0x000002c9, 0x000002dc,
-	0x000002e7, 0x000002fa, 0x0000031d, 0x00000324,
-	0x0000032b, 0x00000338, 0x00000345, 0x00000378,
-	0x000003a6, 0x000003be, 0x000003fd, 0x0000041c,
-	0x00000433, 0x0000045c, 0x00000483, 0x00000493,
+	0x000002d4, 0x000002d8, 0x000002df, 0x000002e6,
+	0x000002f3, 0x000002fa, 0x00000301, 0x00000308,
+	0x0000030f, 0x00000347, 0x00000354, 0x00000364,
+	0x0000037b, 0x0000038b, 0x000003ac, 0x000003b3,
+	0x000003ba, 0x000003c1, 0x000003c8, 0x000003cf,
+	0x000003d6, 0x000003e3, 0x000003f9, 0x00000406,
+	0x0000040d, 0x0000041d, 0x0000042c, 0x0000043f,
+	0x00000446, 0x00000453, 0x00000460, 0x0000046d,
 	// Entry 40 - 5F
-	0x000004ab, 0x000004b2, 0x000004c2, 0x000004d2,
-	0x000004dc, 0x000004e8, 0x000004ef, 0x000004fc,
-	0x00000500, 0x00000507, 0x0000050e, 0x00000515,
-	0x00000528, 0x0000052f, 0x00000536, 0x0000053d,
-	0x0000054a, 0x00000557, 0x00000564, 0x00000571,
-	0x00000578, 0x0000057f, 0x0000058c, 0x00000590,
-	0x00000597, 0x000005a4, 0x000005ab, 0x000005b8,
-	0x000005ec, 0x000005f9, 0x00000606, 0x0000060d,
+	0x00000478, 0x0000048b, 0x000004a4, 0x000004ab,
+	0x000004b2, 0x000004bf, 0x000004cc, 0x000004ff,
+	0x0000052d, 0x00000550, 0x0000057f, 0x000005a1,
+	0x000005ba, 0x00000630, 0x00000637, 0x00000644,
+	0x00000651, 0x00000669, 0x000006a8, 0x000006c7,
+	0x000006de, 0x00000707, 0x0000072e, 0x00000769,
+	0x0000076d, 0x0000077d, 0x00000787, 0x00000794,
+	0x0000079b, 0x000007ab, 0x000007c3, 0x000007ca,
 	// Entry 60 - 7F
-	0x00000614, 0x00000621, 0x0000062e, 0x00000632,
-	0x00000639, 0x00000640, 0x0000064d, 0x00000654,
-	0x00000661, 0x0000066e, 0x0000067b, 0x0000068b,
-	0x0000069b, 0x000006a2, 0x000006a9, 0x000006b0,
-	0x000006b7, 0x000006be, 0x000006cb, 0x000006d8,
-	0x000006eb, 0x000006f8, 0x00000711, 0x00000721,
-	0x0000073a, 0x00000753, 0x0000075a, 0x0000076a,
-	0x00000777, 0x00000793, 0x000007a9, 0x000007bf,
+	0x000007da, 0x000007ea, 0x000007f4, 0x00000800,
+	0x00000807, 0x00000814, 0x00000818, 0x0000081f,
+	0x00000826, 0x0000082d, 0x00000840, 0x00000847,
+	0x0000084e, 0x00000855, 0x00000862, 0x0000086f,
+	0x0000087c, 0x00000889, 0x00000890, 0x00000897,
+	0x000008a4, 0x000008a8, 0x000008af, 0x000008bc,
+	0x000008c3, 0x000008d0, 0x00000904, 0x00000911,
+	0x0000091e, 0x00000925, 0x0000092c, 0x00000939,
 	// Entry 80 - 9F
-	0x000007c9, 0x000007d7, 0x000007e4, 0x000007ef,
-	0x00000802, 0x0000081e, 0x0000082e, 0x0000084f,
-	0x000008c6, 0x000008d3, 0x000008e8, 0x000008ef,
-	0x000008fc, 0x00000903, 0x0000090d, 0x00000917,
-	0x0000091e, 0x00000928, 0x00000932, 0x00000939,
-	0x00000946, 0x00000953, 0x00000960, 0x0000096d,
-	0x0000097a, 0x00000987, 0x00000994, 0x000009a1,
-	0x000009ab, 0x000009bb, 0x000009c6, 0x000009d0,
+	0x00000946, 0x0000094a, 0x00000951, 0x00000958,
+	0x00000965, 0x0000096c, 0x00000979, 0x00000986,
+	0x00000993, 0x000009a3, 0x000009b3, 0x000009ba,
+	0x000009c1, 0x000009c8, 0x000009cf, 0x000009d6,
+	0x000009e3, 0x000009f0, 0x00000a03, 0x00000a10,
+	0x00000a29, 0x00000a39, 0x00000a52, 0x00000a6b,
+	0x00000a72, 0x00000a82, 0x00000a8f, 0x00000a9c,
+	0x00000ab8, 0x00000ace, 0x00000ae4, 0x00000aee,
 	// Entry A0 - BF
-	0x000009dd, 0x000009e7, 0x000009f4, 0x00000a01,
-	0x00000a08, 0x00000a0f, 0x00000a1c, 0x00000a29,
-	0x00000a36, 0x00000a55, 0x00000a5c, 0x00000a63,
-	0x00000a70, 0x00000a7b, 0x00000a88, 0x00000a94,
-	0x00000aa0, 0x00000aac, 0x00000ab3, 0x00000ac0,
-	0x00000acc, 0x00000adf, 0x00000aec, 0x00000b1a,
-	0x00000b27, 0x00000b34, 0x00000b41, 0x00000b4e,
-	0x00000b5b, 0x00000b68, 0x00000b75, 0x00000b82,
+	0x00000afc, 0x00000b09, 0x00000b14, 0x00000b27,
+	0x00000b43, 0x00000b53, 0x00000b74, 0x00000beb,
+	0x00000c4f, 0x00000c5c, 0x00000c71, 0x00000c78,
+	0x00000c85, 0x00000c8c, 0x00000c96, 0x00000ca0,
+	0x00000ca7, 0x00000cb1, 0x00000cbb, 0x00000cc2,
+	0x00000ccf, 0x00000cdc, 0x00000ce9, 0x00000cf6,
+	0x00000d03, 0x00000d10, 0x00000d1d, 0x00000d2a,
+	0x00000d34, 0x00000d44, 0x00000d4f, 0x00000d59,
 	// Entry C0 - DF
-	0x00000b8f, 0x00000b9f, 0x00000bc0, 0x00000bdc,
-	0x00000bf8, 0x00000c1d, 0x00000c39, 0x00000c55,
-	0x00000c71, 0x00000c8a, 0x00000cab, 0x00000cca,
-	0x00000ce3, 0x00000d1d, 0x00000d57, 0x00000d5e,
-	0x00000d74, 0x00000d7b, 0x00000d82, 0x00000d8d,
-	0x00000d94, 0x00000da1, 0x00000da5, 0x00000da9,
-	0x00000db0, 0x00000db7, 0x00000dc4, 0x00000dce,
-	0x00000ddb, 0x00000de8, 0x00000def, 0x00000e0e,
+	0x00000d66, 0x00000d70, 0x00000d7d, 0x00000d8a,
+	0x00000d91, 0x00000d98, 0x00000da5, 0x00000db2,
+	0x00000dbf, 0x00000dde, 0x00000de5, 0x00000dec,
+	0x00000df9, 0x00000e04, 0x00000e11, 0x00000e1d,
+	0x00000e29, 0x00000e35, 0x00000e3c, 0x00000e49,
+	0x00000e55, 0x00000e68, 0x00000e75, 0x00000ea3,
+	0x00000eb0, 0x00000ebd, 0x00000eca, 0x00000ed7,
+	0x00000ee4, 0x00000ef1, 0x00000efe, 0x00000f0b,
 	// Entry E0 - FF
-	0x00000e15, 0x00000e1c, 0x00000e34, 0x00000e5b,
-	0x00000e73, 0x00000e80, 0x00000e87, 0x00000e94,
-	0x00000e9b, 0x00000ea5, 0x00000f13, 0x00000f23,
-	0x00000f30, 0x00000f37, 0x00000f4d, 0x00000f7e,
-	0x00000f8b, 0x00000fe4, 0x00000feb, 0x00000ffe,
-	0x0000100b, 0x00001018, 0x0000102b, 0x00001032,
-	0x00001045, 0x0000104f, 0x0000105c, 0x00001069,
-	0x00001070, 0x0000107a, 0x00001087, 0x00001094,
+	0x00000f18, 0x00000f28, 0x00000f49, 0x00000f65,
+	0x00000f81, 0x00000fa6, 0x00000fc2, 0x00000fde,
+	0x00000ffa, 0x00001013, 0x00001034, 0x00001053,
+	0x0000106c, 0x000010a6, 0x000010e0, 0x000010e7,
+	0x000010fd, 0x00001104, 0x0000110b, 0x00001116,
+	0x0000111d, 0x0000112a, 0x0000112e, 0x00001132,
+	0x00001139, 0x00001140, 0x0000114d, 0x00001157,
+	0x00001164, 0x00001171, 0x00001178, 0x00001197,
 	// Entry 100 - 11F
-	0x000010a1, 0x000010ae, 0x000010c6, 0x000010d4,
-	0x000010e2, 0x000010ef, 0x000010fc, 0x00001109,
-	0x00001116, 0x00001120, 0x00001127, 0x00001134,
-	0x00001141, 0x00001154, 0x0000115f, 0x0000116a,
-	0x00001175, 0x00001180, 0x00001192, 0x000011ab,
-	0x000011bb, 0x000011d1, 0x000011d8, 0x000011df,
-	0x000011ec, 0x000011ff, 0x00001212, 0x0000121f,
-	0x00001252, 0x0000126a, 0x00001291, 0x000012a8,
+	0x0000119e, 0x000011a5, 0x000011bd, 0x000011e4,
+	0x000011fc, 0x00001209, 0x00001210, 0x0000121d,
+	0x00001224, 0x0000122e, 0x0000129c, 0x000012ac,
+	0x000012b9, 0x000012e7, 0x000012ee, 0x00001304,
+	0x00001335, 0x00001342, 0x00001349, 0x000013b1,
+	0x000013b8, 0x000013bf, 0x000013c6, 0x000013cd,
+	0x000013e0, 0x00001430, 0x0000147a, 0x00001499,
+	0x000014ac, 0x000014bc, 0x000014c9, 0x000014ee,
 	// Entry 120 - 13F
-	0x000012d1, 0x000012e9, 0x00001310, 0x00001327,
-	0x00001350, 0x00001357, 0x0000136a, 0x00001378,
-	0x000013a5, 0x000013b2, 0x000013d3, 0x000013da,
-	0x000013e7, 0x00001415, 0x00001428, 0x0000144a,
-	0x00001457, 0x00001489, 0x000014b9, 0x000014d2,
-	0x000014f7, 0x00001501, 0x00001520, 0x00001530,
-} // Size: 1272 bytes
+	0x00001547, 0x00001569, 0x00001576, 0x0000157d,
+	0x0000159c, 0x000015a3, 0x000015aa, 0x000015b4,
+	0x000015db, 0x00001634, 0x0000163b, 0x00001672,
+	0x00001685, 0x00001692, 0x0000169f, 0x000016b2,
+	0x000016d4, 0x000016db, 0x000016ee, 0x000016f8,
+	0x00001705, 0x00001712, 0x00001719, 0x00001723,
+	0x00001730, 0x0000173d, 0x0000174a, 0x00001762,
+	0x00001770, 0x0000177e, 0x0000178b, 0x00001798,
+	// Entry 140 - 15F
+	0x000017a5, 0x000017b2, 0x000017bc, 0x000017c3,
+	0x000017d0, 0x000017dd, 0x000017f0, 0x000017fb,
+	0x00001806, 0x00001811, 0x0000181c, 0x0000182e,
+	0x00001847, 0x00001857, 0x0000186d, 0x00001874,
+	0x0000187b, 0x00001888, 0x0000189b, 0x000018ae,
+	0x000018c1, 0x000018dd, 0x000018ea, 0x0000191d,
+	0x00001935, 0x0000195c, 0x00001973, 0x0000199c,
+	0x000019b4, 0x000019db, 0x000019f2, 0x00001a1b,
+	// Entry 160 - 17F
+	0x00001a22, 0x00001a35, 0x00001a43, 0x00001a50,
+	0x00001a90, 0x00001abd, 0x00001afd, 0x00001b18,
+	0x00001b25, 0x00001b46, 0x00001b4d, 0x00001b5a,
+	0x00001b88, 0x00001b9b, 0x00001ba8, 0x00001bda,
+	0x00001c0a, 0x00001c23, 0x00001c48, 0x00001c52,
+	0x00001c71, 0x00001c81,
+} // Size: 1520 bytes
 
-const zh_CNData string = "" + // Size: 5424 bytes
+const zh_CNData string = "" + // Size: 7297 bytes
 	"\x02版本：%[1]s\x02FRP 版本：%[1]s\x02构建日期：%[1]s\x02所有文件\x02配置文件\x02证书文件\x02密钥" +
 	"文件\x02密码不匹配\x02请检查并重试。\x02发现更新！\x02关于\x02下载更新\x02正在检查更新\x02检查更新\x02如有任" +
 	"何意见或报告错误，请访问项目网址：\x02了解 FRP 软件配置文档，请访问 FRP 项目网址：\x02检查更新时出现错误。\x02当前没有" +
-	"可用的更新。\x02确定\x02取消\x02名称\x02值\x02添加\x02删除\x02全部清除\x02上移\x02下移\x02配置" +
-	"\x02新建配置\x02从文件导入\x02删除 %[1]s 个配置\x02配置已删除\x02配置名「%[1]s」已删除。\x02编辑\x02移动" +
-	"\x02上移\x02下移\x02置顶\x02置底\x02打开文件\x02在文件夹中显示\x02创建副本\x02全部\x02仅通用配置\x02导入" +
-	"配置\x02从 URL 导入\x02从剪贴板导入\x02NAT 检测\x02复制分享链接\x02导出所有配置 (ZIP 压缩包)\x02属性" +
-	"\x02全选\x02新建配置\x02手动设置\x02导入了 %[2]d 个配置文件中的 %[1]d 个。\x02文件 \x22%[1]s\x22" +
-	" 不是有效的压缩文件。\x02删除配置「%[1]s」\x02确定要删除配置「%[1]s」吗？此操作无法撤销。\x02该配置目前已被锁定。\x02" +
-	"删除 %[1]d 个配置\x02确定要删除这 %[1]d 个配置吗？\x02成功 %[1]d 个，失败 %[2]d 个。\x02新建客户端" +
-	"\x02编辑客户端 - %[1]s\x02基本\x02服务器地址\x02服务器端口\x02用户名\x02STUN 服务\x02认证\x02认证方" +
-	"式\x02无\x02来源\x02文件\x02令牌\x02选择令牌文件\x02密钥\x02受众\x02范围\x02令牌地址\x02附加范围" +
-	"\x02心跳消息\x02工作连接\x02日志\x02级别\x02最大天数\x02天\x02管理\x02管理地址\x02密码\x02静态资源" +
-	"\x02选择管理服务器使用的静态资源目录。\x02其他选项\x02自动删除\x02绝对\x02相对\x02删除日期\x02删除天数\x02秒" +
-	"\x02连接\x02协议\x02高级选项\x02参数\x02连接超时\x02保活周期\x02闲置超时\x02连接池数量\x02最大流数量\x02" +
-	"心跳\x02间隔\x02超时\x02开启\x02关闭\x02主机名称\x02证书文件\x02选择证书文件\x02密钥文件\x02选择证书密钥" +
-	"文件\x02受信任证书\x02选择受信任的证书\x02禁用自定义首字节\x02高级\x02使用源地址\x02多路复用\x02初次登录失败后退" +
-	"出\x02禁用开机自启动\x02使用旧文件格式\x02元数据\x02UDP 包大小\x02线路协议\x02代理 URL\x02跳过证书验证" +
-	"\x02必须填写令牌文件。\x02配置已存在\x02配置名「%[1]s」已存在。\x02由于代理转换失败，无法升级您的配置文件，请检查代理配置并" +
-	"重试。\x0a\x0a出错的代理：%[1]s\x02新建代理\x02编辑代理 - %[1]s\x02注释\x02随机名称\x02类型\x02" +
-	"请求头\x02响应头\x02角色\x02服务端\x02访问者\x02私钥\x02本地地址\x02本地端口\x02远程端口\x02允许用户" +
+	"可用的更新。\x02导出所有配置 (ZIP 压缩包)\x02包含新配置的默认值\x02包含 frp 的存储文件\x02解密机密信息，以便在其" +
+	"他计算机上使用配置\x02导入配置\x02有 %[1]d 个配置与现有配置同名。\x02作为副本导入\x02跳过\x02覆盖\x02使用文件" +
+	"中新配置的默认值\x02恢复 frp 的存储文件\x02确定\x02取消\x02名称\x02值\x02添加\x02删除\x02全部清除" +
+	"\x02上移\x02下移\x02配置\x02冲突\x02部分端口或域名也被其他配置使用：\x0a\x0a%[1]s\x02新建配置\x02从文件" +
+	"导入\x02删除 %[1]s 个配置\x02配置已删除\x02配置名「%[1]s」已删除。\x02编辑\x02移动\x02上移\x02下移" +
+	"\x02置顶\x02置底\x02打开文件\x02在文件夹中显示\x02创建副本\x02全部\x02仅通用配置\x02从 URL 导入\x02从剪" +
+	"贴板导入\x02订阅\x02立即刷新\x02刷新历史\x02取消订阅\x02NAT 检测\x02复制分享链接\x02复制加密分享链接\x02" +
+	"属性\x02全选\x02新建配置\x02手动设置\x02导入了 %[2]d 个配置文件中的 %[1]d 个。\x02文件 \x22%[1]s" +
+	"\x22 不是有效的压缩文件。\x02%[1]s：已覆盖配置「%[2]s」\x02%[1]s：已跳过，配置「%[2]s」已存在\x02口令错误。" +
+	"请重新输入。\x02已签名的分享链接\x02该分享链接由以下公钥签名：\x0a\x0a%[1]s\x0a\x0a请确保该密钥属于可信的发送者" +
+	"。是否导入该配置？\x02口令\x02确认口令\x02分享链接\x02删除配置「%[1]s」\x02确定要删除配置「%[1]s」吗？此操作无" +
+	"法撤销。\x02该配置目前已被锁定。\x02删除 %[1]d 个配置\x02确定要删除这 %[1]d 个配置吗？\x02成功 %[1]d 个" +
+	"，失败 %[2]d 个。\x02配置「%[1]s」不是从启用更新的 URL 导入的。\x02URL\x02尚未刷新。\x02已更新\x02已" +
+	"是最新\x02失败\x02新建客户端\x02编辑客户端 - %[1]s\x02基本\x02服务器地址\x02服务器端口\x02用户名\x02" +
+	"STUN 服务\x02认证\x02认证方式\x02无\x02来源\x02文件\x02令牌\x02选择令牌文件\x02密钥\x02受众\x02范围" +
+	"\x02令牌地址\x02附加范围\x02心跳消息\x02工作连接\x02日志\x02级别\x02最大天数\x02天\x02管理\x02管理地址" +
+	"\x02密码\x02静态资源\x02选择管理服务器使用的静态资源目录。\x02其他选项\x02自动删除\x02绝对\x02相对\x02删除日期" +
+	"\x02删除天数\x02秒\x02连接\x02协议\x02高级选项\x02参数\x02连接超时\x02保活周期\x02闲置超时\x02连接池数量" +
+	"\x02最大流数量\x02心跳\x02间隔\x02超时\x02开启\x02关闭\x02主机名称\x02证书文件\x02选择证书文件\x02密钥文" +
+	"件\x02选择证书密钥文件\x02受信任证书\x02选择受信任的证书\x02禁用自定义首字节\x02高级\x02使用源地址\x02文件格式" +
+	"\x02多路复用\x02初次登录失败后退出\x02禁用开机自启动\x02使用旧文件格式\x02元数据\x02UDP 包大小\x02线路协议" +
+	"\x02代理 URL\x02跳过证书验证\x02必须填写令牌文件。\x02配置已存在\x02配置名「%[1]s」已存在。\x02由于代理转换失败" +
+	"，无法升级您的配置文件，请检查代理配置并重试。\x0a\x0a出错的代理：%[1]s\x02旧版文件格式不支持以下选项，这些选项将会丢失：" +
+	"\x0a\x0a%[1]s\x0a\x0a确定要继续吗？\x02新建代理\x02编辑代理 - %[1]s\x02注释\x02随机名称\x02类型" +
+	"\x02请求头\x02响应头\x02角色\x02服务端\x02访问者\x02私钥\x02本地地址\x02本地端口\x02远程端口\x02允许用户" +
 	"\x02绑定地址\x02绑定端口\x02服务名称\x02服务用户\x02子域名\x02自定义域名\x02URL 路由\x02复用器\x02路由用" +
 	"户\x02客户端\x02带宽限流\x02代理协议\x02自动\x02默认\x02保持隧道\x02加密传输\x02压缩传输\x02禁用本地地址" +
 	"辅助连接\x02备用\x02毫秒\x02重试次数\x02次/小时\x02重试间隔\x02HTTP 用户\x02HTTP 密码\x02Host" +
@@ -1181,158 +1455,197 @@ const zh_CNData string = "" + // Size: 5424 bytes
 	"公网\x02未知\x02正在运行\x02已停止\x02正在启动\x02正在停止\x02状态\x02与服务器的连接已加密\x02启动\x02停" +
 	"止\x02停止配置「%[1]s」\x02确定要停止配置「%[1]s」吗？\x02启动配置「%[1]s」\x02本地目录\x02端口\x02打" +
 	"开端口\x02选项\x02主密码\x02您可以设置密码来限制访问此程序。\x0a在下次使用此程序时，您将被要求输入密码。\x02使用主密码" +
-	"\x02修改密码\x02语言\x02目前的显示语言\x02您必须重新启动程序才能应用修改。\x02选择语言\x02您可以在此处找到更多设置。" +
-	"\x0a包括应用程序更新、初始默认值等。\x02设置\x02密码已删除。\x02新主密码\x02确认密码\x02密码已设定。\x02通用\x02" +
-	"自动检查更新\x02默认值\x02日志级别\x02日志保留\x02手动\x02标识符\x02服务名称\x02文件格式\x02代理数量\x02" +
-	"启动类型\x02%[1]d 个文件，%[2]s\x02TCP 连接数\x02UDP 连接数\x02启动时间\x02创建时间\x02修改时间" +
-	"\x02%[1]s 属性\x02复制值\x02出错\x02快速添加\x02远程桌面\x02添加远程桌面\x02添加 VNC\x02添加 SSH" +
-	"\x02添加 Web\x02添加 FTP\x02HTTP 文件服务\x02添加 HTTP 文件服务\x02代理服务器\x02添加代理服务器" +
-	"\x02禁用\x02域名\x02远程地址\x02显示远程地址\x02复制访问地址\x02错误消息\x02此功能仅支持 INI 或 TOML 格式" +
-	"的文本。\x02删除代理「%[1]s」\x02确定要删除代理「%[1]s」吗？\x02删除 %[1]d 个代理\x02确定要删除这 %[1]" +
-	"d 个代理吗？\x02禁用代理「%[1]s」\x02确定要禁用代理「%[1]s」吗？\x02禁用 %[1]d 个代理\x02确定要禁用这 %[1" +
-	"]d 个代理吗？\x02启用\x02被动端口范围\x02FRP 管理器\x02* 支持批量导入，每行一个链接。\x02准备就绪\x02请输入正确" +
-	"的 URL 列表。\x02下载\x02输入密码\x02您必须输入管理密码来使用 %[1]s。\x02输入管理密码\x02密码错误。请重新输入" +
-	"。\x02输入无效\x02请输入一个从 %.[1]f 到 %.[2]f 的数字。\x02请输入一个从 %[1]s 到 %[2]s 的数字。" +
-	"\x02数值超出允许范围\x02文本与要求的模式不匹配。\x02必填项\x02请选择其中一个选项。\x02需要选择。"
+	"\x02修改密码\x02使用主密码加密配置中的机密信息\x02语言\x02目前的显示语言\x02您必须重新启动程序才能应用修改。\x02选择语言" +
+	"\x02备份\x02您可以将所有配置、日志和设置备份到文件，\x0a并在本机或其他计算机上恢复。\x02备份\x02恢复\x02位置\x02计划" +
+	"\x02备份已保存。\x02备份中的配置和设置将替换当前的配置和设置。\x0a是否继续？\x02已恢复 %[1]d 个配置。部分设置在重新启动程" +
+	"序后生效。\x02程序所在目录（便携）\x02所有用户共享\x02仅当前用户\x02数据位置\x02配置、日志和设置存储在：\x02所有数据" +
+	"都将被移动，正在运行的配置将会重新启动。\x0a是否继续？\x02所有数据已移动到 %[1]s。\x02计划备份\x02目录\x02选择保存" +
+	"备份的目录。\x02小时\x02保留\x02个备份\x02* 目录留空以禁用计划备份。\x02您可以在此处找到更多设置。\x0a包括应用程序" +
+	"更新、初始默认值等。\x02设置\x02请先禁用机密信息加密，再删除主密码。\x02密码已删除。\x02新主密码\x02确认密码\x02密码" +
+	"已设定。\x02密码错误。请重新输入。\x02通用\x02自动检查更新\x02默认值\x02日志级别\x02日志保留\x02手动\x02标识" +
+	"符\x02服务名称\x02代理数量\x02启动类型\x02%[1]d 个文件，%[2]s\x02TCP 连接数\x02UDP 连接数\x02" +
+	"启动时间\x02创建时间\x02修改时间\x02%[1]s 属性\x02复制值\x02出错\x02快速添加\x02远程桌面\x02添加远程桌" +
+	"面\x02添加 VNC\x02添加 SSH\x02添加 Web\x02添加 FTP\x02HTTP 文件服务\x02添加 HTTP 文件服务" +
+	"\x02代理服务器\x02添加代理服务器\x02禁用\x02域名\x02远程地址\x02显示远程地址\x02复制访问地址\x02分享所选代理" +
+	"\x02复制访问者分享链接\x02错误消息\x02此功能仅支持 INI 或 TOML 格式的文本。\x02删除代理「%[1]s」\x02确定要删" +
+	"除代理「%[1]s」吗？\x02删除 %[1]d 个代理\x02确定要删除这 %[1]d 个代理吗？\x02禁用代理「%[1]s」\x02确" +
+	"定要禁用代理「%[1]s」吗？\x02禁用 %[1]d 个代理\x02确定要禁用这 %[1]d 个代理吗？\x02启用\x02被动端口范围" +
+	"\x02FRP 管理器\x02配置检查\x02在配置中发现以下问题：\x0a\x0a%[1]s\x0a\x0a确定要保存吗？\x02* 支持批量" +
+	"导入，每行一个链接。\x02* 在链接后追加「#sha256=<校验和>」以校验文件。\x02从 URL 保持配置更新\x02准备就绪" +
+	"\x02请输入正确的 URL 列表。\x02下载\x02输入密码\x02您必须输入管理密码来使用 %[1]s。\x02输入管理密码\x02输入无" +
+	"效\x02请输入一个从 %.[1]f 到 %.[2]f 的数字。\x02请输入一个从 %[1]s 到 %[2]s 的数字。\x02数值超出允" +
+	"许范围\x02文本与要求的模式不匹配。\x02必填项\x02请选择其中一个选项。\x02需要选择。"
 
-var zh_TWIndex = []uint32{ // 312 elements
+var zh_TWIndex = []uint32{ // 374 elements
 	// Entry 0 - 1F
 	0x00000000, 0x0000000f, 0x00000022, 0x00000037,
 	0x00000044, 0x00000051, 0x0000005e, 0x0000006b,
 	0x0000007b, 0x00000091, 0x000000a1, 0x000000a8,
 	0x000000b5, 0x000000c8, 0x000000d5, 0x00000112,
-	0x00000150, 0x0000016f, 0x0000018e, 0x00000195,
-	0x0000019c, 0x000001a3, 0x000001a7, 0x000001ae,
-	0x000001b5, 0x000001c2, 0x000001c9, 0x000001d0,
-	0x000001d7, 0x000001e4, 0x000001f4, 0x0000020b,
+	0x00000150, 0x0000016f, 0x0000018e, 0x000001b1,
+	0x000001cd, 0x000001e8, 0x00000222, 0x0000022f,
+	0x0000025b, 0x0000026e, 0x00000275, 0x0000027c,
+	0x000002a1, 0x000002bc, 0x000002c3, 0x000002ca,
 	// Entry 20 - 3F
-	0x0000021b, 0x0000023c, 0x00000243, 0x0000024a,
-	0x00000251, 0x00000258, 0x0000025f, 0x00000266,
-	0x00000273, 0x00000289, 0x00000296, 0x0000029d,
-	0x000002ad, 0x000002ba, 0x000002c9, 0x000002dc,
-	0x000002e7, 0x000002fa, 0x0000031d, 0x00000324,
-	0x0000032b, 0x00000338, 0x00000345, 0x00000378,
-	0x000003a6, 0x000003be, 0x000003fd, 0x0000041c,
-	0x00000433, 0x0000045c, 0x00000483, 0x00000493,
+	0x000002d1, 0x000002d5, 0x000002dc, 0x000002e3,
+	0x000002f0, 0x000002f7, 0x000002fe, 0x00000305,
+	0x0000030c, 0x00000347, 0x00000354, 0x00000364,
+	0x0000037b, 0x0000038b, 0x000003ac, 0x000003b3,
+	0x000003ba, 0x000003c1, 0x000003c8, 0x000003cf,
+	0x000003d6, 0x000003e3, 0x000003f9, 0x00000406,
+	0x0000040d, 0x0000041d, 0x0000042c, 0x0000043f,
+	0x00000446, 0x00000459, 0x0000046c, 0x00000479,
 	// Entry 40 - 5F
-	0x000004ab, 0x000004b2, 0x000004c2, 0x000004d5,
-	0x000004dc, 0x000004eb, 0x000004f2, 0x000004ff,
-	0x00000503, 0x0000050a, 0x00000511, 0x00000518,
-	0x0000052b, 0x00000532, 0x00000539, 0x00000540,
-	0x0000054d, 0x0000055a, 0x0000056a, 0x00000577,
-	0x0000057e, 0x00000585, 0x00000592, 0x00000596,
-	0x0000059d, 0x000005aa, 0x000005b1, 0x000005be,
-	0x000005f2, 0x000005ff, 0x0000060c, 0x00000613,
+	0x00000484, 0x00000497, 0x000004b0, 0x000004b7,
+	0x000004be, 0x000004cb, 0x000004d8, 0x0000050b,
+	0x00000539, 0x0000055c, 0x0000058b, 0x000005ad,
+	0x000005c6, 0x0000063c, 0x00000643, 0x00000650,
+	0x0000065d, 0x00000675, 0x000006b4, 0x000006d3,
+	0x000006ea, 0x00000713, 0x0000073a, 0x00000775,
+	0x00000779, 0x0000078f, 0x00000799, 0x000007a6,
+	0x000007ad, 0x000007bd, 0x000007d5, 0x000007dc,
 	// Entry 60 - 7F
-	0x0000061a, 0x00000627, 0x00000634, 0x00000638,
-	0x0000063f, 0x00000646, 0x00000653, 0x0000065a,
-	0x00000667, 0x00000674, 0x00000681, 0x00000691,
-	0x000006a1, 0x000006a8, 0x000006af, 0x000006b6,
-	0x000006bd, 0x000006c4, 0x000006d1, 0x000006de,
-	0x000006f1, 0x000006fe, 0x00000717, 0x00000727,
-	0x00000740, 0x0000075c, 0x00000763, 0x00000776,
-	0x00000783, 0x0000079f, 0x000007b5, 0x000007cb,
+	0x000007ec, 0x000007ff, 0x00000806, 0x00000815,
+	0x0000081c, 0x00000829, 0x0000082d, 0x00000834,
+	0x0000083b, 0x00000842, 0x00000855, 0x0000085c,
+	0x00000863, 0x0000086a, 0x00000877, 0x00000884,
+	0x00000894, 0x000008a1, 0x000008a8, 0x000008af,
+	0x000008bc, 0x000008c0, 0x000008c7, 0x000008d4,
+	0x000008db, 0x000008e8, 0x0000091c, 0x00000929,
+	0x00000936, 0x0000093d, 0x00000944, 0x00000951,
 	// Entry 80 - 9F
-	0x000007d5, 0x000007e6, 0x000007f3, 0x000007fe,
-	0x00000811, 0x0000082d, 0x0000083d, 0x0000085e,
-	0x000008d5, 0x000008e2, 0x000008f7, 0x000008fe,
-	0x0000090b, 0x00000912, 0x0000091f, 0x0000092c,
-	0x00000933, 0x0000093d, 0x00000944, 0x0000094b,
-	0x00000958, 0x00000968, 0x00000978, 0x00000985,
-	0x00000992, 0x000009a2, 0x000009b2, 0x000009c2,
-	0x000009cc, 0x000009d9, 0x000009e4, 0x000009ee,
+	0x0000095e, 0x00000962, 0x00000969, 0x00000970,
+	0x0000097d, 0x00000984, 0x00000991, 0x0000099e,
+	0x000009ab, 0x000009bb, 0x000009cb, 0x000009d2,
+	0x000009d9, 0x000009e0, 0x000009e7, 0x000009ee,
+	0x000009fb, 0x00000a08, 0x00000a1b, 0x00000a28,
+	0x00000a41, 0x00000a51, 0x00000a6a, 0x00000a86,
+	0x00000a8d, 0x00000aa0, 0x00000aad, 0x00000aba,
+	0x00000ad6, 0x00000aec, 0x00000b02, 0x00000b0c,
 	// Entry A0 - BF
-	0x000009fb, 0x00000a05, 0x00000a12, 0x00000a1f,
-	0x00000a26, 0x00000a2d, 0x00000a3a, 0x00000a47,
-	0x00000a54, 0x00000a73, 0x00000a7a, 0x00000a81,
-	0x00000a8e, 0x00000a99, 0x00000aa6, 0x00000ab2,
-	0x00000abe, 0x00000aca, 0x00000ad1, 0x00000ade,
-	0x00000aea, 0x00000afd, 0x00000b0a, 0x00000b38,
-	0x00000b45, 0x00000b52, 0x00000b5f, 0x00000b6c,
-	0x00000b79, 0x00000b86, 0x00000b93, 0x00000ba0,
+	0x00000b1d, 0x00000b2a, 0x00000b35, 0x00000b48,
+	0x00000b64, 0x00000b74, 0x00000b95, 0x00000c0c,
+	0x00000c70, 0x00000c7d, 0x00000c92, 0x00000c99,
+	0x00000ca6, 0x00000cad, 0x00000cba, 0x00000cc7,
+	0x00000cce, 0x00000cd8, 0x00000cdf, 0x00000ce6,
+	0x00000cf3, 0x00000d03, 0x00000d13, 0x00000d20,
+	0x00000d2d, 0x00000d3d, 0x00000d4d, 0x00000d5d,
+	0x00000d67, 0x00000d74, 0x00000d7f, 0x00000d89,
 	// Entry C0 - DF
-	0x00000bad, 0x00000bbd, 0x00000bde, 0x00000bfa,
-	0x00000c19, 0x00000c41, 0x00000c5d, 0x00000c79,
-	0x00000c95, 0x00000cb1, 0x00000cd2, 0x00000cf4,
-	0x00000d10, 0x00000d50, 0x00000d87, 0x00000d8e,
-	0x00000da4, 0x00000dab, 0x00000db2, 0x00000dbd,
-	0x00000dc4, 0x00000dd1, 0x00000dd5, 0x00000dd9,
-	0x00000de6, 0x00000ded, 0x00000dfa, 0x00000e04,
-	0x00000e11, 0x00000e1e, 0x00000e25, 0x00000e44,
+	0x00000d96, 0x00000da0, 0x00000dad, 0x00000dba,
+	0x00000dc1, 0x00000dc8, 0x00000dd5, 0x00000de2,
+	0x00000def, 0x00000e0e, 0x00000e15, 0x00000e1c,
+	0x00000e29, 0x00000e34, 0x00000e41, 0x00000e4d,
+	0x00000e59, 0x00000e65, 0x00000e6c, 0x00000e79,
+	0x00000e85, 0x00000e98, 0x00000ea5, 0x00000ed3,
+	0x00000ee0, 0x00000eed, 0x00000efa, 0x00000f07,
+	0x00000f14, 0x00000f21, 0x00000f2e, 0x00000f3b,
 	// Entry E0 - FF
-	0x00000e4b, 0x00000e52, 0x00000e6a, 0x00000e91,
-	0x00000ea9, 0x00000eb6, 0x00000ec0, 0x00000ed0,
-	0x00000ed7, 0x00000ee1, 0x00000f4f, 0x00000f5f,
-	0x00000f6c, 0x00000f73, 0x00000f89, 0x00000fba,
-	0x00000fc7, 0x00001020, 0x00001027, 0x0000103a,
-	0x00001047, 0x00001054, 0x00001067, 0x0000106e,
-	0x00001081, 0x0000108b, 0x00001098, 0x000010a5,
-	0x000010ac, 0x000010b6, 0x000010c3, 0x000010d0,
+	0x00000f48, 0x00000f58, 0x00000f79, 0x00000f95,
+	0x00000fb4, 0x00000fdc, 0x00000ff8, 0x00001014,
+	0x00001030, 0x0000104c, 0x0000106d, 0x0000108f,
+	0x000010ab, 0x000010eb, 0x00001122, 0x00001129,
+	0x0000113f, 0x00001146, 0x0000114d, 0x00001158,
+	0x0000115f, 0x0000116c, 0x00001170, 0x00001174,
+	0x00001181, 0x00001188, 0x00001195, 0x0000119f,
+	0x000011ac, 0x000011b9, 0x000011c0, 0x000011df,
 	// Entry 100 - 11F
-	0x000010dd, 0x000010ea, 0x00001102, 0x00001110,
-	0x0000111e, 0x0000112b, 0x00001138, 0x00001145,
-	0x00001154, 0x0000115e, 0x00001165, 0x00001172,
-	0x0000117f, 0x00001192, 0x0000119d, 0x000011a8,
-	0x000011b3, 0x000011be, 0x000011d0, 0x000011e9,
-	0x000011f9, 0x0000120f, 0x00001216, 0x0000121d,
-	0x0000122a, 0x0000123d, 0x00001250, 0x0000125d,
-	0x00001290, 0x000012a8, 0x000012cf, 0x000012e6,
+	0x000011e6, 0x000011ed, 0x00001205, 0x0000122c,
+	0x00001244, 0x00001251, 0x0000125b, 0x0000126b,
+	0x00001272, 0x0000127c, 0x000012ea, 0x000012fa,
+	0x00001307, 0x00001335, 0x0000133c, 0x00001352,
+	0x00001383, 0x00001390, 0x00001397, 0x000013fc,
+	0x00001403, 0x0000140a, 0x00001411, 0x00001418,
+	0x0000142b, 0x0000147b, 0x000014c5, 0x000014e4,
+	0x000014fa, 0x0000150d, 0x0000151a, 0x0000153f,
 	// Entry 120 - 13F
-	0x0000130f, 0x00001327, 0x0000134e, 0x00001365,
-	0x0000138e, 0x00001395, 0x000013ab, 0x000013b9,
-	0x000013e6, 0x000013f3, 0x00001414, 0x0000141b,
-	0x00001428, 0x00001456, 0x00001469, 0x0000148b,
-	0x00001498, 0x000014ca, 0x000014fa, 0x00001513,
-	0x00001538, 0x00001545, 0x00001564, 0x00001574,
-} // Size: 1272 bytes
+	0x00001598, 0x000015ba, 0x000015c7, 0x000015ce,
+	0x000015ed, 0x000015f4, 0x000015fb, 0x00001605,
+	0x0000162c, 0x00001685, 0x0000168c, 0x000016c3,
+	0x000016d6, 0x000016e3, 0x000016f0, 0x00001703,
+	0x00001725, 0x0000172c, 0x0000173f, 0x00001749,
+	0x00001756, 0x00001763, 0x0000176a, 0x00001774,
+	0x00001781, 0x0000178e, 0x0000179b, 0x000017b3,
+	0x000017c1, 0x000017cf, 0x000017dc, 0x000017e9,
+	// Entry 140 - 15F
+	0x000017f6, 0x00001805, 0x0000180f, 0x00001816,
+	0x00001823, 0x00001830, 0x00001843, 0x0000184e,
+	0x00001859, 0x00001864, 0x0000186f, 0x00001881,
+	0x0000189a, 0x000018aa, 0x000018c0, 0x000018c7,
+	0x000018ce, 0x000018db, 0x000018ee, 0x00001901,
+	0x00001914, 0x0000192d, 0x0000193a, 0x0000196d,
+	0x00001985, 0x000019ac, 0x000019c3, 0x000019ec,
+	0x00001a04, 0x00001a2b, 0x00001a42, 0x00001a6b,
+	// Entry 160 - 17F
+	0x00001a72, 0x00001a88, 0x00001a96, 0x00001aa3,
+	0x00001ae3, 0x00001b10, 0x00001b56, 0x00001b71,
+	0x00001b7e, 0x00001b9f, 0x00001ba6, 0x00001bb3,
+	0x00001be1, 0x00001bf4, 0x00001c01, 0x00001c33,
+	0x00001c63, 0x00001c7c, 0x00001ca1, 0x00001cae,
+	0x00001ccd, 0x00001cdd,
+} // Size: 1520 bytes
 
-const zh_TWData string = "" + // Size: 5492 bytes
+const zh_TWData string = "" + // Size: 7389 bytes
 	"\x02版本：%[1]s\x02FRP 版本：%[1]s\x02構建日期：%[1]s\x02所有檔案\x02配置檔案\x02憑證檔案\x02金鑰" +
 	"檔案\x02密碼不相符\x02請檢查並重試。\x02發現更新！\x02關於\x02下載更新\x02正在檢查更新\x02檢查更新\x02如有任" +
 	"何意見或錯誤回報，請前往專案網址：\x02了解 FRP 軟體配置手冊，請前往 FRP 專案網址：\x02檢查更新時出現錯誤。\x02目前沒有" +
-	"可用的更新。\x02確定\x02取消\x02名稱\x02值\x02新增\x02刪除\x02全部清除\x02上移\x02下移\x02配置" +
-	"\x02新增配置\x02從檔案導入\x02刪除 %[1]s 個配置\x02配置已刪除\x02配置名「%[1]s」已刪除。\x02編輯\x02移動" +
-	"\x02上移\x02下移\x02置頂\x02置底\x02打開檔案\x02在資料夾中顯示\x02創建副本\x02全部\x02僅通用配置\x02導入" +
-	"配置\x02從 URL 導入\x02從剪貼簿導入\x02NAT 偵測\x02複製分享連結\x02導出所有配置 (ZIP 壓縮檔)\x02內容" +
-	"\x02全選\x02新增配置\x02手動設定\x02導入了 %[2]d 個配置檔案中的 %[1]d 個。\x02檔案 \x22%[1]s\x22" +
-	" 不是有效的壓縮檔案。\x02刪除配置「%[1]s」\x02確定要刪除配置「%[1]s」嗎？此動作無法還原。\x02該配置目前已被鎖定。\x02" +
-	"刪除 %[1]d 個配置\x02確定要刪除這 %[1]d 個配置嗎？\x02成功 %[1]d 個，失敗 %[2]d 個。\x02新增用戶端" +
-	"\x02編輯用戶端 - %[1]s\x02基本\x02伺服器位址\x02伺服器通訊埠\x02帳號\x02STUN 伺服器\x02認證\x02認證" +
-	"方式\x02無\x02來源\x02檔案\x02權杖\x02選擇權杖檔案\x02金鑰\x02受眾\x02範圍\x02權杖位址\x02附加範圍" +
-	"\x02伺服器心跳\x02工作連接\x02日誌\x02等級\x02最大天數\x02天\x02管理\x02管理位址\x02密碼\x02靜態資源" +
-	"\x02選擇管理伺服器使用的靜態資源目錄。\x02其他選項\x02自動刪除\x02絕對\x02相對\x02刪除日期\x02刪除天數\x02秒" +
-	"\x02連線\x02協定\x02進階選項\x02參數\x02連線超時\x02保持週期\x02閒置超時\x02連接池數量\x02最大流數量\x02" +
-	"心跳\x02間隔\x02超時\x02開啟\x02關閉\x02主機名稱\x02憑證檔案\x02選擇憑證檔案\x02金鑰檔案\x02選擇憑證金鑰" +
-	"檔案\x02受信任憑證\x02選擇受信任的憑證\x02停用自訂第一位元組\x02進階\x02使用來源位址\x02多路復用\x02初次登錄失敗" +
-	"後退出\x02停用開機自啟動\x02使用舊檔案格式\x02元資料\x02UDP 封包大小\x02線路協定\x02代理 URL\x02跳過證書" +
-	"驗證\x02必須填寫權杖檔案。\x02配置已存在\x02配置名「%[1]s」已存在。\x02由於代理轉換失敗，無法升級您的配置檔案，請檢查代" +
-	"理配置並重試。\x0a\x0a出錯的代理：%[1]s\x02新增代理\x02編輯代理 - %[1]s\x02註解\x02隨機名稱\x02類型" +
-	"\x02請求表頭\x02回應表頭\x02角色\x02伺服器\x02訪客\x02私鑰\x02本機位址\x02本機通訊埠\x02遠端通訊埠\x02允" +
-	"許帳號\x02綁定位址\x02綁定通訊埠\x02伺服器名稱\x02伺服器帳號\x02子域名\x02自定域名\x02URL 路由\x02復用器" +
-	"\x02路由帳號\x02客戶端\x02頻寬限制\x02代理協定\x02自動\x02預設\x02通道保持\x02加密傳輸\x02壓縮傳輸\x02停" +
-	"用本地位址輔助連接\x02備用\x02毫秒\x02重試次數\x02次/小時\x02重試間隔\x02HTTP 帳號\x02HTTP 密碼" +
-	"\x02Host 替換\x02外掛\x02外掛名稱\x02Unix 路徑\x02選擇 Unix 路徑\x02本機路徑\x02選擇需要顯示目錄列表" +
-	"的資料夾。\x02移除前綴\x02負載平衡\x02分組名稱\x02分組金鑰\x02健康檢查\x02檢查類型\x02檢查超時\x02檢查週期" +
-	"\x02錯誤次數\x02代理已存在\x02代理名「%[1]s」已存在。\x02必須填寫服務名稱。\x02必須填寫綁定通訊埠。\x02必須填寫本機" +
-	"通訊埠或外掛。\x02必須填寫本機位址。\x02必須填寫本機路徑。\x02必須填寫 Unix 路徑。\x02無效的本機通訊埠。\x02健康檢" +
-	"查 URL 為必填項。\x02外掛不支援範圍通訊埠。\x02無效的遠端通訊埠。\x02本機通訊埠的數量應與遠端通訊埠的數量相同。\x02自訂" +
-	"網域和子網域應至少填寫其中之一。\x02複製\x02打開日誌資料夾\x02最新\x02項目\x02NAT 類型\x02行為\x02外部位址" +
-	"\x02是\x02否\x02公共網路\x02未知\x02正在執行\x02已停止\x02正在啟動\x02正在停止\x02狀態\x02與伺服器的連線" +
-	"已加密\x02啟動\x02停止\x02停止配置「%[1]s」\x02確定要停止配置「%[1]s」嗎？\x02啟動配置「%[1]s」\x02本" +
-	"機目錄\x02通訊埠\x02打開通訊埠\x02選項\x02主密碼\x02您可以設定密碼來限制前往此程式。\x0a在下次使用此程式時，您將被要" +
-	"求輸入密碼。\x02使用主密碼\x02修改密碼\x02語言\x02目前的顯示語言\x02您必須重新啟動程式才能套用修改。\x02選擇語言" +
-	"\x02您可以在此處找到更多設定。\x0a包括應用程式更新、初始預設值等。\x02設定\x02密碼已刪除。\x02新主密碼\x02確認密碼" +
-	"\x02密碼已設定。\x02通用\x02自動檢查更新\x02預設值\x02日誌等級\x02日誌保留\x02手動\x02識別符\x02服務名稱" +
-	"\x02檔案格式\x02代理數量\x02啟動類型\x02%[1]d 個文件，%[2]s\x02TCP 連線數\x02UDP 連線數\x02啟動日" +
-	"期\x02建立日期\x02修改日期\x02%[1]s - 內容\x02複製值\x02出錯\x02快速添加\x02遠端桌面\x02添加遠端桌面" +
-	"\x02添加 VNC\x02添加 SSH\x02添加 Web\x02添加 FTP\x02HTTP 檔案服務\x02添加 HTTP 檔案服務" +
-	"\x02代理伺服器\x02添加代理伺服器\x02停用\x02域名\x02遠端位址\x02顯示遠端位址\x02複製存取位址\x02錯誤訊息\x02" +
-	"此功能僅支援 INI 或 TOML 格式的文字。\x02刪除代理「%[1]s」\x02確定要刪除代理「%[1]s」嗎？\x02刪除 %[1]" +
-	"d 個代理\x02確定要刪除這 %[1]d 個代理嗎？\x02停用代理「%[1]s」\x02確定要停用代理「%[1]s」嗎？\x02停用 %[1" +
-	"]d 個代理\x02確定要停用這 %[1]d 個代理嗎？\x02啟用\x02被動通訊埠範圍\x02FRP 管理器\x02* 支援批量導入，每行一" +
-	"個連結。\x02準備就緒\x02請輸入正確的 URL 列表。\x02下載\x02輸入密碼\x02您必須輸入管理密碼來使用 %[1]s。" +
-	"\x02輸入管理密碼\x02密碼錯誤。請重新輸入。\x02輸入無效\x02請輸入一個從 %.[1]f 到 %.[2]f 的數字。\x02請輸入一" +
-	"個從 %[1]s 到 %[2]s 的數字。\x02數值超出許可範圍\x02文字與要求的模式不相符。\x02必填項目\x02請選擇其中一個選項" +
-	"。\x02必需選擇。"
+	"可用的更新。\x02導出所有配置 (ZIP 壓縮檔)\x02包含新配置的預設值\x02包含 frp 的儲存檔案\x02解密機密資訊，以便在其" +
+	"他電腦上使用配置\x02導入配置\x02有 %[1]d 個配置與現有配置同名。\x02作為副本導入\x02略過\x02覆寫\x02使用檔案中" +
+	"新配置的預設值\x02還原 frp 的儲存檔案\x02確定\x02取消\x02名稱\x02值\x02新增\x02刪除\x02全部清除\x02" +
+	"上移\x02下移\x02配置\x02衝突\x02部分連接埠或網域也被其他配置使用：\x0a\x0a%[1]s\x02新增配置\x02從檔案導" +
+	"入\x02刪除 %[1]s 個配置\x02配置已刪除\x02配置名「%[1]s」已刪除。\x02編輯\x02移動\x02上移\x02下移" +
+	"\x02置頂\x02置底\x02打開檔案\x02在資料夾中顯示\x02創建副本\x02全部\x02僅通用配置\x02從 URL 導入\x02從剪" +
+	"貼簿導入\x02訂閱\x02立即重新整理\x02重新整理記錄\x02取消訂閱\x02NAT 偵測\x02複製分享連結\x02複製加密分享連結" +
+	"\x02內容\x02全選\x02新增配置\x02手動設定\x02導入了 %[2]d 個配置檔案中的 %[1]d 個。\x02檔案 \x22%[1" +
+	"]s\x22 不是有效的壓縮檔案。\x02%[1]s：已覆寫配置「%[2]s」\x02%[1]s：已略過，配置「%[2]s」已存在\x02口令錯" +
+	"誤。請重新輸入。\x02已簽署的分享連結\x02該分享連結由以下公鑰簽署：\x0a\x0a%[1]s\x0a\x0a請確保該金鑰屬於可信的傳" +
+	"送者。是否匯入該配置？\x02口令\x02確認口令\x02分享連結\x02刪除配置「%[1]s」\x02確定要刪除配置「%[1]s」嗎？此動" +
+	"作無法還原。\x02該配置目前已被鎖定。\x02刪除 %[1]d 個配置\x02確定要刪除這 %[1]d 個配置嗎？\x02成功 %[1]d" +
+	" 個，失敗 %[2]d 個。\x02配置「%[1]s」不是從啟用更新的 URL 導入的。\x02URL\x02尚未重新整理。\x02已更新" +
+	"\x02已是最新\x02失敗\x02新增用戶端\x02編輯用戶端 - %[1]s\x02基本\x02伺服器位址\x02伺服器通訊埠\x02帳號" +
+	"\x02STUN 伺服器\x02認證\x02認證方式\x02無\x02來源\x02檔案\x02權杖\x02選擇權杖檔案\x02金鑰\x02受眾" +
+	"\x02範圍\x02權杖位址\x02附加範圍\x02伺服器心跳\x02工作連接\x02日誌\x02等級\x02最大天數\x02天\x02管理" +
+	"\x02管理位址\x02密碼\x02靜態資源\x02選擇管理伺服器使用的靜態資源目錄。\x02其他選項\x02自動刪除\x02絕對\x02相對" +
+	"\x02刪除日期\x02刪除天數\x02秒\x02連線\x02協定\x02進階選項\x02參數\x02連線超時\x02保持週期\x02閒置超時" +
+	"\x02連接池數量\x02最大流數量\x02心跳\x02間隔\x02超時\x02開啟\x02關閉\x02主機名稱\x02憑證檔案\x02選擇憑證" +
+	"檔案\x02金鑰檔案\x02選擇憑證金鑰檔案\x02受信任憑證\x02選擇受信任的憑證\x02停用自訂第一位元組\x02進階\x02使用來源" +
+	"位址\x02檔案格式\x02多路復用\x02初次登錄失敗後退出\x02停用開機自啟動\x02使用舊檔案格式\x02元資料\x02UDP 封包" +
+	"大小\x02線路協定\x02代理 URL\x02跳過證書驗證\x02必須填寫權杖檔案。\x02配置已存在\x02配置名「%[1]s」已存在。" +
+	"\x02由於代理轉換失敗，無法升級您的配置檔案，請檢查代理配置並重試。\x0a\x0a出錯的代理：%[1]s\x02舊版檔案格式不支援以下選項，" +
+	"這些選項將會遺失：\x0a\x0a%[1]s\x0a\x0a確定要繼續嗎？\x02新增代理\x02編輯代理 - %[1]s\x02註解" +
+	"\x02隨機名稱\x02類型\x02請求表頭\x02回應表頭\x02角色\x02伺服器\x02訪客\x02私鑰\x02本機位址\x02本機通訊埠" +
+	"\x02遠端通訊埠\x02允許帳號\x02綁定位址\x02綁定通訊埠\x02伺服器名稱\x02伺服器帳號\x02子域名\x02自定域名\x02U" +
+	"RL 路由\x02復用器\x02路由帳號\x02客戶端\x02頻寬限制\x02代理協定\x02自動\x02預設\x02通道保持\x02加密傳輸" +
+	"\x02壓縮傳輸\x02停用本地位址輔助連接\x02備用\x02毫秒\x02重試次數\x02次/小時\x02重試間隔\x02HTTP 帳號" +
+	"\x02HTTP 密碼\x02Host 替換\x02外掛\x02外掛名稱\x02Unix 路徑\x02選擇 Unix 路徑\x02本機路徑" +
+	"\x02選擇需要顯示目錄列表的資料夾。\x02移除前綴\x02負載平衡\x02分組名稱\x02分組金鑰\x02健康檢查\x02檢查類型\x02檢" +
+	"查超時\x02檢查週期\x02錯誤次數\x02代理已存在\x02代理名「%[1]s」已存在。\x02必須填寫服務名稱。\x02必須填寫綁定通" +
+	"訊埠。\x02必須填寫本機通訊埠或外掛。\x02必須填寫本機位址。\x02必須填寫本機路徑。\x02必須填寫 Unix 路徑。\x02無效的" +
+	"本機通訊埠。\x02健康檢查 URL 為必填項。\x02外掛不支援範圍通訊埠。\x02無效的遠端通訊埠。\x02本機通訊埠的數量應與遠端通訊" +
+	"埠的數量相同。\x02自訂網域和子網域應至少填寫其中之一。\x02複製\x02打開日誌資料夾\x02最新\x02項目\x02NAT 類型" +
+	"\x02行為\x02外部位址\x02是\x02否\x02公共網路\x02未知\x02正在執行\x02已停止\x02正在啟動\x02正在停止" +
+	"\x02狀態\x02與伺服器的連線已加密\x02啟動\x02停止\x02停止配置「%[1]s」\x02確定要停止配置「%[1]s」嗎？\x02啟" +
+	"動配置「%[1]s」\x02本機目錄\x02通訊埠\x02打開通訊埠\x02選項\x02主密碼\x02您可以設定密碼來限制前往此程式。" +
+	"\x0a在下次使用此程式時，您將被要求輸入密碼。\x02使用主密碼\x02修改密碼\x02使用主密碼加密配置中的機密資訊\x02語言\x02目前" +
+	"的顯示語言\x02您必須重新啟動程式才能套用修改。\x02選擇語言\x02備份\x02您可以將所有配置、日誌和設定備份到檔案，\x0a並在本" +
+	"機或其他電腦上還原。\x02備份\x02還原\x02位置\x02排程\x02備份已儲存。\x02備份中的配置和設定將取代目前的配置和設定。" +
+	"\x0a是否繼續？\x02已還原 %[1]d 個配置。部分設定在重新啟動程式後生效。\x02程式所在目錄（可攜）\x02所有使用者共用\x02僅" +
+	"目前使用者\x02資料位置\x02配置、日誌和設定儲存在：\x02所有資料都將被移動，正在執行的配置將會重新啟動。\x0a是否繼續？\x02" +
+	"所有資料已移動到 %[1]s。\x02排程備份\x02目錄\x02選擇儲存備份的目錄。\x02小時\x02保留\x02個備份\x02* 目錄" +
+	"留空以停用排程備份。\x02您可以在此處找到更多設定。\x0a包括應用程式更新、初始預設值等。\x02設定\x02請先停用機密資訊加密，再刪" +
+	"除主密碼。\x02密碼已刪除。\x02新主密碼\x02確認密碼\x02密碼已設定。\x02密碼錯誤。請重新輸入。\x02通用\x02自動檢查" +
+	"更新\x02預設值\x02日誌等級\x02日誌保留\x02手動\x02識別符\x02服務名稱\x02代理數量\x02啟動類型\x02%[1]" +
+	"d 個文件，%[2]s\x02TCP 連線數\x02UDP 連線數\x02啟動日期\x02建立日期\x02修改日期\x02%[1]s - 內容" +
+	"\x02複製值\x02出錯\x02快速添加\x02遠端桌面\x02添加遠端桌面\x02添加 VNC\x02添加 SSH\x02添加 Web" +
+	"\x02添加 FTP\x02HTTP 檔案服務\x02添加 HTTP 檔案服務\x02代理伺服器\x02添加代理伺服器\x02停用\x02域名" +
+	"\x02遠端位址\x02顯示遠端位址\x02複製存取位址\x02分享所選代理\x02複製訪客分享連結\x02錯誤訊息\x02此功能僅支援 INI" +
+	" 或 TOML 格式的文字。\x02刪除代理「%[1]s」\x02確定要刪除代理「%[1]s」嗎？\x02刪除 %[1]d 個代理\x02確定要" +
+	"刪除這 %[1]d 個代理嗎？\x02停用代理「%[1]s」\x02確定要停用代理「%[1]s」嗎？\x02停用 %[1]d 個代理\x02" +
+	"確定要停用這 %[1]d 個代理嗎？\x02啟用\x02被動通訊埠範圍\x02FRP 管理器\x02配置檢查\x02在配置中發現以下問題：" +
+	"\x0a\x0a%[1]s\x0a\x0a確定要儲存嗎？\x02* 支援批量導入，每行一個連結。\x02* 在連結後附加「#sha256=<總和" +
+	"檢查碼>」以驗證檔案。\x02從 URL 保持配置更新\x02準備就緒\x02請輸入正確的 URL 列表。\x02下載\x02輸入密碼" +
+	"\x02您必須輸入管理密碼來使用 %[1]s。\x02輸入管理密碼\x02輸入無效\x02請輸入一個從 %.[1]f 到 %.[2]f 的數字。" +
+	"\x02請輸入一個從 %[1]s 到 %[2]s 的數字。\x02數值超出許可範圍\x02文字與要求的模式不相符。\x02必填項目\x02請選擇" +
+	"其中一個選項。\x02必需選擇。"
 
-	// Total table size 47102 bytes (45KiB); checksum: F8CC1B92
+	// Total table size 63005 bytes (61KiB); checksum: EEF29A3C
@@ -5,6 +5,7 @@ package i18n
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 
 	"golang.org/x/sys/windows"
 	"golang.org/x/text/language"
@@ -40,16 +41,20 @@ func GetLanguage() string {
 	return useLang.String()
 }
 
-// langInConfig returns the UI language code in config file
+// langInConfig returns the UI language code in config file of the data root
 func langInConfig() string {
-	b, err := os.ReadFile(config.LangFile)
+	root, err := config.DataRoot()
+	if err != nil {
+		return ""
+	}
+	b, err := os.ReadFile(filepath.Join(root, config.LangFile))
 	if err == nil {
 		id := string(b)
 		if _, ok := IDToName[id]; ok {
 			return id
 		}
 	}
-	b, err = os.ReadFile(config.DefaultAppFile)
+	b, err = os.ReadFile(filepath.Join(root, config.DefaultAppFile))
 	if err != nil {
 		return ""
 	}
@@ -0,0 +1,437 @@
+// Package api implements the local REST management API of frpmgr.
+//
+// All endpoints are under /api/v1 and exchange JSON. Every request must carry
+// the configured token in the header "Authorization: Bearer <token>".
+//
+//	GET    /api/v1/configs                       list configs
+//	GET    /api/v1/configs/{id}                  show a config
+//	GET    /api/v1/configs/{id}/status           proxy status of a running config
+//	POST   /api/v1/configs/{id}/reload           hot-reload a running config
+//	POST   /api/v1/configs/{id}/stop             stop a config
+//	GET    /api/v1/configs/{id}/proxies          list proxies
+//	POST   /api/v1/configs/{id}/proxies          add a proxy
+//	GET    /api/v1/configs/{id}/proxies/{name}   show a proxy
+//	PUT    /api/v1/configs/{id}/proxies/{name}   replace a proxy
+//	DELETE /api/v1/configs/{id}/proxies/{name}   remove a proxy
+//	GET    /api/v1/configs/{id}/revisions         list revisions
+//	GET    /api/v1/configs/{id}/revisions/diff    diff revisions "from" and "to"
+//	POST   /api/v1/configs/{id}/revisions/{rev}   restore a revision
+//	GET    /api/v1/configs/{id}/subscription      show the source URL and refresh history
+//	POST   /api/v1/configs/{id}/subscription/refresh  refresh from the source URL now
+//
+// Proxies are encoded as config.Proxy with Go field names.
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/ipc"
+	"github.com/koho/frpmgr/pkg/profile"
+)
+
+// DefaultAddr is the address used when no address is configured.
+const DefaultAddr = "127.0.0.1:7480"
+
+// queryTimeout limits the time to query proxy status from a service.
+const queryTimeout = 5 * time.Second
+
+// ErrNoToken is returned when the server is created without a token.
+var ErrNoToken = errors.New("api token is required")
+
+// Controller controls the services of profiles.
+type Controller interface {
+	// Running reports whether the service of the profile is running.
+	Running(p *profile.Profile) bool
+	// Status queries the status of the given proxies from the running service.
+	Status(ctx context.Context, p *profile.Profile, names []string) ([]ipc.ProxyMessage, error)
+	// Reload validates the config file and hot-reloads the running service.
+	Reload(p *profile.Profile) error
+	// Stop stops the service of the profile.
+	Stop(p *profile.Profile) error
+}
+
+// ConfigInfo is the summary of a config.
+type ConfigInfo struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Running bool     `json:"running"`
+	Server  string   `json:"server"`
+	Proxies []string `json:"proxies"`
+	// Conflicts with other configs, only reported for a single config.
+	Conflicts []config.Conflict `json:"conflicts,omitempty"`
+}
+
+// Server handles the API requests.
+type Server struct {
+	repo  *profile.Repository
+	ctl   Controller
+	token string
+	mux   *http.ServeMux
+	// mu serializes the modification of configs.
+	mu sync.Mutex
+}
+
+// NewServer creates an API server for the profiles in the repository.
+func NewServer(repo *profile.Repository, ctl Controller, token string) (*Server, error) {
+	if token == "" {
+		return nil, ErrNoToken
+	}
+	s := &Server{repo: repo, ctl: ctl, token: token, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /api/v1/configs", s.listConfigs)
+	s.mux.HandleFunc("GET /api/v1/configs/{id}", s.withProfile(s.getConfig))
+	s.mux.HandleFunc("GET /api/v1/configs/{id}/status", s.withProfile(s.getStatus))
+	s.mux.HandleFunc("POST /api/v1/configs/{id}/reload", s.withProfile(s.reload))
+	s.mux.HandleFunc("POST /api/v1/configs/{id}/stop", s.withProfile(s.stop))
+	s.mux.HandleFunc("GET /api/v1/configs/{id}/proxies", s.withProfile(s.listProxies))
+	s.mux.HandleFunc("POST /api/v1/configs/{id}/proxies", s.withProfile(s.addProxy))
+	s.mux.HandleFunc("GET /api/v1/configs/{id}/proxies/{name}", s.withProfile(s.getProxy))
+	s.mux.HandleFunc("PUT /api/v1/configs/{id}/proxies/{name}", s.withProfile(s.updateProxy))
+	s.mux.HandleFunc("DELETE /api/v1/configs/{id}/proxies/{name}", s.withProfile(s.deleteProxy))
+	s.mux.HandleFunc("GET /api/v1/configs/{id}/revisions", s.withProfile(s.listRevisions))
+	s.mux.HandleFunc("GET /api/v1/configs/{id}/revisions/diff", s.withProfile(s.diffRevisions))
+	s.mux.HandleFunc("POST /api/v1/configs/{id}/revisions/{rev}", s.withProfile(s.restoreRevision))
+	s.mux.HandleFunc("GET /api/v1/configs/{id}/subscription", s.withProfile(s.getSubscription))
+	s.mux.HandleFunc("POST /api/v1/configs/{id}/subscription/refresh", s.withProfile(s.refresh))
+	return s, nil
+}
+
+// Handle registers an additional handler which is protected by the same token.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		writeError(w, http.StatusUnauthorized, errors.New("invalid token"))
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// Serve accepts requests on a loopback address until the context is canceled.
+func (s *Server) Serve(ctx context.Context, addr string) error {
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	l, err := ipc.NewTCPTransport(addr).Listen()
+	if err != nil {
+		return err
+	}
+	svr := &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		svr.Close()
+	}()
+	if err = svr.Serve(l); errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// withProfile loads the profile given in the path before calling the handler.
+func (s *Server) withProfile(h func(http.ResponseWriter, *http.Request, *profile.Profile)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		p, err := s.repo.Get(r.PathValue("id"))
+		if err != nil {
+			writeError(w, statusOf(err), err)
+			return
+		}
+		h(w, r, p)
+	}
+}
+
+func (s *Server) listConfigs(w http.ResponseWriter, r *http.Request) {
+	list, err := s.repo.List()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, lo.Map(list, func(p *profile.Profile, i int) ConfigInfo {
+		return s.info(p)
+	}))
+}
+
+func (s *Server) getConfig(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	info := s.info(p)
+	info.Conflicts, _ = s.repo.Conflicts(p)
+	writeJSON(w, http.StatusOK, info)
+}
+
+func (s *Server) getStatus(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	if !s.ctl.Running(p) {
+		writeError(w, http.StatusConflict, errors.New("config is not running"))
+		return
+	}
+	names := lo.FlatMap(p.Data.Proxies, func(item *config.Proxy, i int) []string {
+		return item.GetAlias()
+	})
+	ctx, cancel := context.WithTimeout(r.Context(), queryTimeout)
+	defer cancel()
+	msg, err := s.ctl.Status(ctx, p, names)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, msg)
+}
+
+func (s *Server) reload(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	if !s.ctl.Running(p) {
+		writeError(w, http.StatusConflict, errors.New("config is not running"))
+		return
+	}
+	if err := s.ctl.Reload(p); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) stop(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	if err := s.ctl.Stop(p); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listProxies(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	writeJSON(w, http.StatusOK, p.Data.Proxies)
+}
+
+func (s *Server) getProxy(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	i := indexOfProxy(p, r.PathValue("name"))
+	if i < 0 {
+		writeError(w, http.StatusNotFound, errors.New("proxy not found"))
+		return
+	}
+	writeJSON(w, http.StatusOK, p.Data.Proxies[i])
+}
+
+func (s *Server) addProxy(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	proxy, err := decodeProxy(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	s.modify(w, p.ID(), func(p *profile.Profile) (int, error) {
+		if indexOfProxy(p, proxy.Name) >= 0 {
+			return http.StatusConflict, errors.New("proxy already exists")
+		}
+		p.Data.AddProxy(proxy)
+		return http.StatusCreated, nil
+	})
+}
+
+func (s *Server) updateProxy(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	proxy, err := decodeProxy(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	name := r.PathValue("name")
+	s.modify(w, p.ID(), func(p *profile.Profile) (int, error) {
+		i := indexOfProxy(p, name)
+		if i < 0 {
+			return http.StatusNotFound, errors.New("proxy not found")
+		}
+		// Renaming must not collide with another proxy.
+		if j := indexOfProxy(p, proxy.Name); j >= 0 && j != i {
+			return http.StatusConflict, errors.New("proxy already exists")
+		}
+		p.Data.Proxies[i] = proxy
+		return http.StatusOK, nil
+	})
+}
+
+func (s *Server) deleteProxy(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	name := r.PathValue("name")
+	s.modify(w, p.ID(), func(p *profile.Profile) (int, error) {
+		i := indexOfProxy(p, name)
+		if i < 0 {
+			return http.StatusNotFound, errors.New("proxy not found")
+		}
+		p.Data.DeleteProxy(i)
+		return http.StatusNoContent, nil
+	})
+}
+
+func (s *Server) listRevisions(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	revs, err := s.repo.Revisions(p.ID())
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, revs)
+}
+
+func (s *Server) diffRevisions(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	from, err1 := strconv.Atoi(r.URL.Query().Get("from"))
+	to, err2 := strconv.Atoi(r.URL.Query().Get("to"))
+	if err := errors.Join(err1, err2); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	diff, err := s.repo.DiffRevisions(p.ID(), from, to)
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, diff)
+}
+
+func (s *Server) restoreRevision(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	rev, err := strconv.Atoi(r.PathValue("rev"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	s.modify(w, p.ID(), func(p *profile.Profile) (int, error) {
+		data, err := s.repo.Revision(p.ID(), rev)
+		if err != nil {
+			return statusOf(err), err
+		}
+		p.Data = data
+		return http.StatusOK, nil
+	})
+}
+
+func (s *Server) getSubscription(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	sub, err := s.repo.Subscription(p.ID())
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sub)
+}
+
+// refresh downloads the config from its source URL, and reloads the running service if it's changed.
+func (s *Server) refresh(w http.ResponseWriter, r *http.Request, p *profile.Profile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ev, err := s.repo.Refresh(r.Context(), p.ID())
+	if err != nil {
+		if ev.Status == profile.RefreshFailed {
+			writeError(w, http.StatusBadGateway, err)
+		} else {
+			writeError(w, statusOf(err), err)
+		}
+		return
+	}
+	if ev.Status == profile.RefreshUpdated && s.ctl.Running(p) {
+		if err = s.ctl.Reload(p); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, ev)
+}
+
+// modify applies the change to the config and saves it.
+// A running service is reloaded, and the previous config is restored if the reload fails.
+func (s *Server) modify(w http.ResponseWriter, id string, change func(p *profile.Profile) (int, error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Load the profile again under lock, so concurrent changes are not lost.
+	p, err := s.repo.Get(id)
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	prev := p.Data.Copy(true)
+	code, err := change(p)
+	if err != nil {
+		writeError(w, code, err)
+		return
+	}
+	if err = s.repo.Update(p); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if s.ctl.Running(p) {
+		if err = s.ctl.Reload(p); err != nil {
+			p.Data = prev
+			s.repo.Update(p)
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+	}
+	if code == http.StatusNoContent {
+		w.WriteHeader(code)
+		return
+	}
+	info := s.info(p)
+	info.Conflicts, _ = s.repo.Conflicts(p)
+	writeJSON(w, code, info)
+}
+
+func (s *Server) info(p *profile.Profile) ConfigInfo {
+	return ConfigInfo{
+		ID:      p.ID(),
+		Name:    p.Name(),
+		Running: s.ctl.Running(p),
+		Server:  net.JoinHostPort(p.Data.ServerAddress, strconv.Itoa(p.Data.ServerPort)),
+		Proxies: lo.Map(p.Data.Proxies, func(item *config.Proxy, i int) string {
+			return item.Name
+		}),
+	}
+}
+
+func indexOfProxy(p *profile.Profile, name string) int {
+	return slices.IndexFunc(p.Data.Proxies, func(proxy *config.Proxy) bool {
+		return proxy.Name == name
+	})
+}
+
+func decodeProxy(r *http.Request) (*config.Proxy, error) {
+	var proxy config.Proxy
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&proxy); err != nil {
+		return nil, err
+	}
+	if proxy.Name == "" {
+		return nil, errors.New("proxy name is required")
+	}
+	// Invalid proxies are rejected before they are saved
+	proxy.Complete()
+	if err := proxy.Validate(); err != nil {
+		return nil, err
+	}
+	return &proxy, nil
+}
+
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, profile.ErrNotFound), errors.Is(err, profile.ErrRevisionNotFound), errors.Is(err, profile.ErrNotSubscribed):
+		return http.StatusNotFound
+	case errors.Is(err, profile.ErrInvalidID), errors.Is(err, profile.ErrInvalidSubscription):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
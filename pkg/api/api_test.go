@@ -0,0 +1,279 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/ipc"
+	"github.com/koho/frpmgr/pkg/profile"
+)
+
+type fakeController struct {
+	running   bool
+	reloadErr error
+	reloaded  int
+	stopped   int
+	names     []string
+}
+
+func (f *fakeController) Running(p *profile.Profile) bool {
+	return f.running
+}
+
+func (f *fakeController) Status(ctx context.Context, p *profile.Profile, names []string) ([]ipc.ProxyMessage, error) {
+	f.names = names
+	return []ipc.ProxyMessage{{Name: names[0], Status: "running"}}, nil
+}
+
+func (f *fakeController) Reload(p *profile.Profile) error {
+	f.reloaded++
+	return f.reloadErr
+}
+
+func (f *fakeController) Stop(p *profile.Profile) error {
+	f.stopped++
+	return nil
+}
+
+func newTestServer(t *testing.T) (*Server, *fakeController, *profile.Profile) {
+	repo := profile.NewRepository(t.TempDir(), &config.App{})
+	conf := config.NewDefaultClientConfig()
+	conf.ClientCommon.Name = "test"
+	conf.ServerAddress = "example.com"
+	conf.AddProxy(&config.Proxy{BaseProxyConf: config.BaseProxyConf{Name: "ssh", Type: "tcp", LocalPort: "22"}, RemotePort: "6000"})
+	p, err := repo.Create(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctl := &fakeController{running: true}
+	s, err := NewServer(repo, ctl, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, ctl, p
+}
+
+func do(s *Server, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	return w
+}
+
+func TestNewServer(t *testing.T) {
+	if _, err := NewServer(nil, nil, ""); !errors.Is(err, ErrNoToken) {
+		t.Errorf("Expected: %v, got: %v", ErrNoToken, err)
+	}
+}
+
+func TestAuth(t *testing.T) {
+	s, _, _ := newTestServer(t)
+	tests := []struct {
+		token    string
+		expected int
+	}{
+		{"", http.StatusUnauthorized},
+		{"wrong", http.StatusUnauthorized},
+		{"secret", http.StatusOK},
+	}
+	for _, test := range tests {
+		if w := do(s, "GET", "/api/v1/configs", test.token, ""); w.Code != test.expected {
+			t.Errorf("Expected: %v, got: %v", test.expected, w.Code)
+		}
+	}
+}
+
+func TestConfigs(t *testing.T) {
+	s, ctl, p := newTestServer(t)
+	w := do(s, "GET", "/api/v1/configs", "secret", "")
+	var list []ConfigInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	expected := []ConfigInfo{{ID: p.ID(), Name: "test", Running: true, Server: "example.com:7000", Proxies: []string{"ssh"}}}
+	if !reflect.DeepEqual(list, expected) {
+		t.Errorf("Expected: %v, got: %v", expected, list)
+	}
+
+	tests := []struct {
+		method   string
+		path     string
+		expected int
+	}{
+		{"GET", "/api/v1/configs/" + p.ID(), http.StatusOK},
+		{"GET", "/api/v1/configs/unknown", http.StatusNotFound},
+		{"GET", "/api/v1/configs/" + p.ID() + "/status", http.StatusOK},
+		{"POST", "/api/v1/configs/" + p.ID() + "/reload", http.StatusNoContent},
+		{"POST", "/api/v1/configs/" + p.ID() + "/stop", http.StatusNoContent},
+	}
+	for _, test := range tests {
+		if w = do(s, test.method, test.path, "secret", ""); w.Code != test.expected {
+			t.Errorf("%s %s: Expected: %v, got: %v", test.method, test.path, test.expected, w.Code)
+		}
+	}
+	if !reflect.DeepEqual(ctl.names, []string{"ssh"}) {
+		t.Errorf("Expected: %v, got: %v", []string{"ssh"}, ctl.names)
+	}
+	if ctl.reloaded != 1 || ctl.stopped != 1 {
+		t.Errorf("Expected: %v, got: %v", [2]int{1, 1}, [2]int{ctl.reloaded, ctl.stopped})
+	}
+
+	ctl.running = false
+	if w = do(s, "GET", "/api/v1/configs/"+p.ID()+"/status", "secret", ""); w.Code != http.StatusConflict {
+		t.Errorf("Expected: %v, got: %v", http.StatusConflict, w.Code)
+	}
+}
+
+func TestProxies(t *testing.T) {
+	s, ctl, p := newTestServer(t)
+	base := "/api/v1/configs/" + p.ID() + "/proxies"
+	tests := []struct {
+		method   string
+		path     string
+		body     string
+		expected int
+	}{
+		{"POST", base, `{"Name": "web", "Type": "http", "LocalPort": "80", "SubDomain": "web"}`, http.StatusCreated},
+		{"POST", base, `{"Name": "web", "Type": "http", "LocalPort": "80", "SubDomain": "www"}`, http.StatusConflict},
+		{"POST", base, `{"Type": "http"}`, http.StatusBadRequest},
+		{"POST", base, `{"Name": "x", "Unknown": 1}`, http.StatusBadRequest},
+		{"POST", base, `{"Name": "api", "Type": "http", "LocalPort": "8080"}`, http.StatusBadRequest},
+		{"POST", base, `{"Name": "range", "Type": "tcp", "LocalPort": "6000-6002", "RemotePort": "7000"}`, http.StatusBadRequest},
+		{"POST", base, `{"Name": "x", "Type": "unknown"}`, http.StatusBadRequest},
+		{"PUT", base + "/ssh", `{"Name": "ssh", "Type": "tcp", "LocalPort": "2222", "RemotePort": "6000"}`, http.StatusOK},
+		{"PUT", base + "/ssh", `{"Name": "ssh", "Type": "stcp", "Role": "visitor", "ServerName": "remote"}`, http.StatusBadRequest},
+		{"PUT", base + "/ssh", `{"Name": "web", "Type": "tcp", "LocalPort": "22"}`, http.StatusConflict},
+		{"PUT", base + "/unknown", `{"Name": "unknown", "Type": "tcp", "LocalPort": "22"}`, http.StatusNotFound},
+		{"GET", base + "/ssh", "", http.StatusOK},
+		{"DELETE", base + "/web", "", http.StatusNoContent},
+		{"DELETE", base + "/web", "", http.StatusNotFound},
+	}
+	for _, test := range tests {
+		if w := do(s, test.method, test.path, "secret", test.body); w.Code != test.expected {
+			t.Errorf("%s %s: Expected: %v, got: %v", test.method, test.path, test.expected, w.Code)
+		}
+	}
+	if ctl.reloaded != 3 {
+		t.Errorf("Expected: %v, got: %v", 3, ctl.reloaded)
+	}
+	saved, err := config.UnmarshalClientConf(p.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Proxies) != 1 || saved.Proxies[0].LocalPort != "2222" {
+		t.Errorf("Expected: %v, got: %v", "2222", saved.Proxies)
+	}
+
+	// A failed reload restores the previous config.
+	ctl.reloadErr = errors.New("invalid config")
+	if w := do(s, "DELETE", base+"/ssh", "secret", ""); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected: %v, got: %v", http.StatusUnprocessableEntity, w.Code)
+	}
+	if saved, err = config.UnmarshalClientConf(p.Path); err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Proxies) != 1 {
+		t.Errorf("Expected: %v, got: %v", 1, len(saved.Proxies))
+	}
+}
+
+func TestRevisions(t *testing.T) {
+	s, ctl, p := newTestServer(t)
+	base := "/api/v1/configs/" + p.ID()
+	if w := do(s, "DELETE", base+"/proxies/ssh", "secret", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("Expected: %v, got: %v", http.StatusNoContent, w.Code)
+	}
+	w := do(s, "GET", base+"/revisions", "secret", "")
+	var revs []profile.Revision
+	if err := json.Unmarshal(w.Body.Bytes(), &revs); err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != 2 || revs[1].Summary != "removed proxy ssh" {
+		t.Errorf("Expected: %v, got: %v", "removed proxy ssh", revs)
+	}
+	w = do(s, "GET", base+"/revisions/diff?from=1&to=2", "secret", "")
+	var diff config.Diff
+	if err := json.Unmarshal(w.Body.Bytes(), &diff); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(diff.Removed, []string{"ssh"}) {
+		t.Errorf("Expected: %v, got: %v", []string{"ssh"}, diff.Removed)
+	}
+
+	tests := []struct {
+		method   string
+		path     string
+		expected int
+	}{
+		{"GET", base + "/revisions/diff?from=1", http.StatusBadRequest},
+		{"GET", base + "/revisions/diff?from=1&to=9", http.StatusNotFound},
+		{"POST", base + "/revisions/9", http.StatusNotFound},
+		{"POST", base + "/revisions/1", http.StatusOK},
+	}
+	for _, test := range tests {
+		if w = do(s, test.method, test.path, "secret", ""); w.Code != test.expected {
+			t.Errorf("%s %s: Expected: %v, got: %v", test.method, test.path, test.expected, w.Code)
+		}
+	}
+	// The restored config is saved and reloaded
+	if ctl.reloaded != 2 {
+		t.Errorf("Expected: %v, got: %v", 2, ctl.reloaded)
+	}
+	saved, err := config.UnmarshalClientConf(p.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Proxies) != 1 {
+		t.Errorf("Expected: %v, got: %v", 1, len(saved.Proxies))
+	}
+}
+
+func TestSubscription(t *testing.T) {
+	s, ctl, p := newTestServer(t)
+	base := "/api/v1/configs/" + p.ID()
+	if w := do(s, "GET", base+"/subscription", "secret", ""); w.Code != http.StatusNotFound {
+		t.Errorf("Expected: %v, got: %v", http.StatusNotFound, w.Code)
+	}
+	content, err := p.Data.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(append(content, "\nuser = \"bob\"\n"...))
+	}))
+	defer ts.Close()
+	if err = s.repo.Subscribe(p.ID(), profile.Subscription{URL: ts.URL}); err != nil {
+		t.Fatal(err)
+	}
+	w := do(s, "POST", base+"/subscription/refresh", "secret", "")
+	var ev profile.RefreshEvent
+	if err = json.Unmarshal(w.Body.Bytes(), &ev); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusOK || ev.Status != profile.RefreshUpdated || ctl.reloaded != 1 {
+		t.Errorf("Expected: %v, got: %v, %v, %v", "updated and reloaded", w.Code, ev.Status, ctl.reloaded)
+	}
+	// Refreshing the same content doesn't reload the service
+	do(s, "POST", base+"/subscription/refresh", "secret", "")
+	if ctl.reloaded != 1 {
+		t.Errorf("Expected: %v, got: %v", 1, ctl.reloaded)
+	}
+	w = do(s, "GET", base+"/subscription", "secret", "")
+	var sub profile.Subscription
+	if err = json.Unmarshal(w.Body.Bytes(), &sub); err != nil {
+		t.Fatal(err)
+	}
+	if sub.URL != ts.URL || len(sub.History) != 2 || sub.History[1].Status != profile.RefreshUnchanged {
+		t.Errorf("Expected: %v, got: %v", "2 refreshes", sub)
+	}
+}
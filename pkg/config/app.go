@@ -2,9 +2,16 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/koho/frpmgr/pkg/consts"
+	"github.com/koho/frpmgr/pkg/sec"
+	"github.com/koho/frpmgr/pkg/util"
 )
 
 const (
@@ -19,6 +26,76 @@ type App struct {
 	Defaults    DefaultValue `json:"defaults"`
 	Sort        []string     `json:"sort,omitempty"`
 	Position    []int32      `json:"position,omitempty"`
+	API         APIConfig    `json:"api"`
+	// HistoryLimit is the number of revisions kept for each profile.
+	// Zero means the default limit.
+	HistoryLimit int `json:"historyLimit,omitempty"`
+	// Vault enables the encryption of secrets in configs.
+	Vault *VaultConfig `json:"vault,omitempty"`
+	// Download configures the downloads of configs from URLs.
+	Download DownloadConfig `json:"download,omitzero"`
+	// Backup configures the scheduled backups of the data directory.
+	Backup BackupConfig `json:"backup,omitzero"`
+}
+
+// BackupConfig configures the scheduled backups of the data directory.
+type BackupConfig struct {
+	// Dir is the directory of backup files. Scheduled backups are disabled if it's empty.
+	Dir string `json:"dir,omitempty"`
+	// Interval between backups in hours.
+	Interval int `json:"interval,omitempty"`
+	// Keep is the number of backup files kept in the directory.
+	Keep int `json:"keep,omitempty"`
+}
+
+// DownloadConfig configures the downloads of URL imports and subscriptions.
+type DownloadConfig struct {
+	// Proxy is the URL of the proxy server, or "direct" to connect directly.
+	// The proxy of the system is used if it's empty.
+	Proxy string `json:"proxy,omitempty"`
+	// MaxSize is the maximum size of a downloaded file in bytes.
+	MaxSize int64 `json:"maxSize,omitempty"`
+	// Retries is the number of retries after a network error or a server error.
+	Retries int `json:"retries,omitempty"`
+	// Timeout of each attempt in seconds.
+	Timeout int `json:"timeout,omitempty"`
+	// Sites are the credentials and headers sent to URLs.
+	Sites []DownloadSite `json:"sites,omitempty"`
+}
+
+// DownloadSite holds the credentials and headers sent to the URLs starting with the prefix.
+// The token and password are sealed by the vault if it's enabled.
+type DownloadSite struct {
+	Prefix   string            `json:"prefix"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Token    string            `json:"token,omitempty"`
+	Username string            `json:"username,omitempty"`
+	Password string            `json:"password,omitempty"`
+}
+
+// VaultConfig stores the key of the secret vault.
+type VaultConfig struct {
+	// Key is the vault key encrypted with the master password. The UI and the command line
+	// only open the vault with this key, and it can recover the vault on another machine.
+	Key string `json:"key"`
+	// MachineKey is the vault key protected by the system in the machine scope,
+	// so services can decrypt secrets without a user to enter the master password.
+	//
+	// Any process on this machine can decrypt it, so it doesn't protect secrets from
+	// other local accounts. It only protects the secrets in copies of the data, such as
+	// backups, exported bundles and files read on another machine or from a disk image.
+	MachineKey string `json:"machineKey"`
+}
+
+// APIConfig configures the local management API served by the manager service.
+type APIConfig struct {
+	Enabled bool `json:"enabled"`
+	// Addr is the loopback address to listen on.
+	Addr string `json:"addr,omitempty"`
+	// Token is required in the Authorization header of every request.
+	Token string `json:"token,omitempty"`
+	// Metrics enables the Prometheus endpoint "/metrics" on the same address.
+	Metrics bool `json:"metrics,omitempty"`
 }
 
 type DefaultValue struct {
@@ -33,6 +110,7 @@ type DefaultValue struct {
 	TLSEnable            bool   `json:"tls"`
 	ManualStart          bool   `json:"manualStart,omitempty"`
 	LegacyFormat         bool   `json:"legacyFormat,omitempty"`
+	Format               string `json:"format,omitempty"`
 }
 
 func (dv *DefaultValue) AsClientConfig() ClientCommon {
@@ -49,10 +127,107 @@ func (dv *DefaultValue) AsClientConfig() ClientCommon {
 		TLSEnable:                 dv.TLSEnable,
 		ManualStart:               dv.ManualStart,
 		LegacyFormat:              dv.LegacyFormat,
+		Format:                    dv.Format,
 		DisableCustomTLSFirstByte: true,
 	}
 }
 
+// ErrVaultLocked is returned when a sealed secret is used without the vault.
+var ErrVaultLocked = errors.New("secrets are encrypted by a vault, which is not open")
+
+// OpenVault returns the secret vault decrypted with the master password,
+// or nil if the vault is not enabled.
+func (conf *App) OpenVault(password string) (*sec.Vault, error) {
+	if conf.Vault == nil {
+		return nil, nil
+	}
+	return sec.UnwrapVault(conf.Vault.Key, password)
+}
+
+// OpenServiceVault returns the secret vault decrypted with the machine key, or nil if the vault
+// is not enabled. It's only used by services, which have no user to enter the master password.
+func (conf *App) OpenServiceVault() (*sec.Vault, error) {
+	if conf.Vault == nil {
+		return nil, nil
+	}
+	return sec.UnprotectVault(conf.Vault.MachineKey)
+}
+
+// Options returns the download options of the given URL.
+// The site with the longest prefix of the URL provides the credentials and headers.
+// Sealed credentials are opened with the given vault.
+func (dc *DownloadConfig) Options(url string, v *sec.Vault) (util.DownloadOptions, error) {
+	opts := util.DownloadOptions{
+		Proxy:   dc.Proxy,
+		MaxSize: dc.MaxSize,
+		Retries: dc.Retries,
+		Timeout: time.Duration(dc.Timeout) * time.Second,
+	}
+	var site *DownloadSite
+	for i, s := range dc.Sites {
+		if s.Prefix != "" && strings.HasPrefix(url, s.Prefix) && (site == nil || len(s.Prefix) > len(site.Prefix)) {
+			site = &dc.Sites[i]
+		}
+	}
+	if site == nil {
+		return opts, nil
+	}
+	opts.Token, opts.Username, opts.Password = site.Token, site.Username, site.Password
+	for _, secret := range []*string{&opts.Token, &opts.Password} {
+		if !sec.IsSealed(*secret) {
+			continue
+		}
+		if v == nil {
+			return opts, fmt.Errorf("credentials of %s: %w", site.Prefix, ErrVaultLocked)
+		}
+		plain, err := v.Open(*secret)
+		if err != nil {
+			return opts, fmt.Errorf("credentials of %s: %w", site.Prefix, err)
+		}
+		*secret = plain
+	}
+	if len(site.Headers) > 0 {
+		opts.Header = make(http.Header)
+		for k, v := range site.Headers {
+			opts.Header.Set(k, v)
+		}
+	}
+	return opts, nil
+}
+
+// MapSecrets replaces each non-empty token and password of download sites with the result of fn.
+func (dc *DownloadConfig) MapSecrets(fn func(string) (string, error)) error {
+	for i := range dc.Sites {
+		for _, secret := range []*string{&dc.Sites[i].Token, &dc.Sites[i].Password} {
+			if *secret == "" {
+				continue
+			}
+			s, err := fn(*secret)
+			if err != nil {
+				return err
+			}
+			*secret = s
+		}
+	}
+	return nil
+}
+
+// SealSecrets seals the plain tokens and passwords of download sites with the vault, such as
+// the ones written by hand. It reports whether any secret is sealed, so the configuration can be saved.
+func (conf *App) SealSecrets(v *sec.Vault) (sealed bool, err error) {
+	if v == nil {
+		return false, nil
+	}
+	err = conf.Download.MapSecrets(func(s string) (string, error) {
+		if sec.IsSealed(s) {
+			return s, nil
+		}
+		sealed = true
+		return v.Seal(s)
+	})
+	return
+}
+
 func UnmarshalAppConf(path string, dst *App) (lang *string, err error) {
 	b, err := os.ReadFile(LangFile)
 	if err == nil {
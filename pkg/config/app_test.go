@@ -1,9 +1,13 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/koho/frpmgr/pkg/sec"
 )
 
 func TestUnmarshalAppConfFromIni(t *testing.T) {
@@ -51,3 +55,67 @@ func TestUnmarshalAppConfFromIni(t *testing.T) {
 		t.Errorf("Expected: %v, got: %v", expectedLang, lang)
 	}
 }
+
+func TestDownloadOptions(t *testing.T) {
+	dc := DownloadConfig{
+		Proxy:   "http://127.0.0.1:3128",
+		Retries: 2,
+		Timeout: 5,
+		Sites: []DownloadSite{
+			{Prefix: "https://portal.example.com/", Username: "alice", Password: "secret"},
+			{Prefix: "https://portal.example.com/frp/", Token: "abc", Headers: map[string]string{"x-team": "ops"}},
+		},
+	}
+	tests := []struct {
+		url      string
+		token    string
+		username string
+		header   string
+	}{
+		{"https://portal.example.com/frp/a.toml", "abc", "", "ops"},
+		{"https://portal.example.com/b.toml", "", "alice", ""},
+		{"https://example.com/portal.example.com/", "", "", ""},
+	}
+	for _, test := range tests {
+		opts, err := dc.Options(test.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if opts.Token != test.token || opts.Username != test.username || opts.Header.Get("X-Team") != test.header {
+			t.Errorf("%s: Expected: %v, %v, %v, got: %v, %v, %v", test.url,
+				test.token, test.username, test.header, opts.Token, opts.Username, opts.Header.Get("X-Team"))
+		}
+		if opts.Proxy != dc.Proxy || opts.Retries != 2 || opts.Timeout != 5*time.Second {
+			t.Errorf("Expected: %v, got: %v", dc, opts)
+		}
+	}
+}
+
+func TestDownloadSecrets(t *testing.T) {
+	v, err := sec.NewVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := App{Download: DownloadConfig{Sites: []DownloadSite{
+		{Prefix: "https://portal.example.com/", Token: "abc", Username: "alice", Password: "secret"},
+	}}}
+	for i, expected := range []bool{true, false} {
+		if sealed, err := app.SealSecrets(v); err != nil || sealed != expected {
+			t.Errorf("Test %d: Expected: %v, got: %v, %v", i, expected, sealed, err)
+		}
+	}
+	site := app.Download.Sites[0]
+	if !sec.IsSealed(site.Token) || !sec.IsSealed(site.Password) || site.Username != "alice" {
+		t.Errorf("Expected: %v, got: %v", "sealed token and password", site)
+	}
+	opts, err := app.Download.Options("https://portal.example.com/a.toml", v)
+	if err != nil || opts.Token != "abc" || opts.Password != "secret" {
+		t.Errorf("Expected: %v, got: %v, %v, %v", "plain credentials", opts.Token, opts.Password, err)
+	}
+	if _, err = app.Download.Options("https://portal.example.com/a.toml", nil); !errors.Is(err, ErrVaultLocked) {
+		t.Errorf("Expected: %v, got: %v", ErrVaultLocked, err)
+	}
+	if err = app.Download.MapSecrets(v.Open); err != nil || app.Download.Sites[0].Token != "abc" {
+		t.Errorf("Expected: %v, got: %v, %v", "abc", app.Download.Sites[0].Token, err)
+	}
+}
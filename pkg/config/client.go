@@ -1,17 +1,22 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
 	"github.com/fatedier/frp/pkg/config"
 	v1 "github.com/fatedier/frp/pkg/config/v1"
+	"github.com/fatedier/frp/pkg/config/v1/validation"
 	frputil "github.com/fatedier/frp/pkg/util/util"
 	"github.com/pelletier/go-toml/v2"
 	"github.com/samber/lo"
 	"gopkg.in/ini.v1"
+	"sigs.k8s.io/yaml"
 
 	"github.com/koho/frpmgr/pkg/consts"
 	"github.com/koho/frpmgr/pkg/util"
@@ -115,6 +120,10 @@ type ClientCommon struct {
 	DisableCustomTLSFirstByte bool         `ini:"disable_custom_tls_first_byte"`
 
 	Store v1.StoreConfig `ini:"-"`
+	// VirtualNetAddress is the address of the virtual network interface, e.g. "100.86.0.1/24".
+	VirtualNetAddress string `ini:"-"`
+	// FeatureGates enables or disables experimental features of frp.
+	FeatureGates map[string]bool `ini:"-"`
 
 	// Name of this config.
 	Name string `ini:"frpmgr_name"`
@@ -127,6 +136,12 @@ type ClientCommon struct {
 	Metas map[string]string `ini:"-"`
 	// Config file format
 	LegacyFormat bool `ini:"-"`
+	// Format is the file format of a config that is not in the legacy format.
+	// It's one of "toml", "yaml" and "json". An empty value means TOML.
+	Format string `ini:"-"`
+	// Extras are the v1 fields that are not modeled by the config.
+	// They are written back as is when the config is saved in the v1 format.
+	Extras map[string]any `ini:"-"`
 }
 
 // BaseProxyConf provides configuration info that is common to all types.
@@ -184,6 +199,8 @@ type BaseProxyConf struct {
 	Annotations map[string]string `ini:"-"`
 	// Disabled defines whether to start the proxy.
 	Disabled bool `ini:"-"`
+	// Extras are the v1 fields that are not modeled by the proxy.
+	Extras map[string]any `ini:"-"`
 }
 
 type PluginParams struct {
@@ -200,6 +217,7 @@ type PluginParams struct {
 	PluginUnixPath          string            `ini:"plugin_unix_path,omitempty" unix_domain_socket:"true"`
 	PluginHeaders           map[string]string `ini:"-" http2https:"true" http2http:"true" https2https:"true" https2http:"true"`
 	PluginEnableHTTP2       bool              `ini:"-" https2https:"true" https2http:"true"`
+	PluginDestinationIP     string            `ini:"-" visitor:"virtual_net"`
 }
 
 // HealthCheckConf configures health checking. This can be useful for load
@@ -291,7 +309,14 @@ func (p *Proxy) Complete() {
 		}
 		p.BaseProxyConf = BaseProxyConf{
 			Name: base.Name, Type: base.Type, UseEncryption: base.UseEncryption,
-			UseCompression: base.UseCompression, Disabled: base.Disabled,
+			UseCompression: base.UseCompression, Disabled: base.Disabled, Extras: base.Extras,
+		}
+		// Visitor plugins
+		if slices.Contains(consts.VisitorPluginTypes, base.Plugin) {
+			p.Plugin = base.Plugin
+			if pluginParams, err := util.PruneByTag(base.PluginParams, base.Plugin, "visitor"); err == nil {
+				p.PluginParams = pluginParams.(PluginParams)
+			}
 		}
 		// Reset xtcp visitor parameters
 		if !p.KeepTunnelOpen {
@@ -328,9 +353,38 @@ func (p *Proxy) Complete() {
 	}
 }
 
+// Validate checks the proxy by the validation of frp, which is run when the config is loaded by frp.
+func (p *Proxy) Validate() error {
+	if p.IsVisitor() {
+		c := ClientVisitorToV1(p)
+		if c.VisitorConfigurer == nil {
+			return fmt.Errorf("unknown visitor type: %s", p.Type)
+		}
+		c.Complete()
+		return validation.ValidateVisitorConfigurer(c.VisitorConfigurer)
+	}
+	cfgs, err := ClientProxyToV1(p)
+	if err != nil {
+		return err
+	}
+	for _, c := range cfgs {
+		if c.ProxyConfigurer == nil {
+			return fmt.Errorf("unknown proxy type: %s", p.Type)
+		}
+		c.Complete()
+		if err = validation.ValidateProxyConfigurerForClient(c.ProxyConfigurer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type ClientConfig struct {
 	ClientCommon
 	Proxies []*Proxy
+
+	// issues are the problems found when the config was loaded.
+	issues []ConversionIssue
 }
 
 // Name of this config.
@@ -352,21 +406,43 @@ func (conf *ClientConfig) AddProxy(proxy *Proxy) {
 }
 
 func (conf *ClientConfig) Save(path string) error {
+	b, err := conf.Marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0666)
+}
+
+// Marshal returns the content of the config file.
+func (conf *ClientConfig) Marshal() ([]byte, error) {
 	if conf.LegacyFormat {
-		return conf.saveINI(path)
+		return conf.marshalINI()
 	} else {
-		return conf.saveTOML(path)
+		return conf.marshalV1()
+	}
+}
+
+func (conf *ClientConfig) marshalINI() ([]byte, error) {
+	cfg, err := conf.iniFile()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if _, err = cfg.WriteTo(&buf); err != nil {
+		return nil, err
 	}
+	return buf.Bytes(), nil
 }
 
-func (conf *ClientConfig) saveINI(path string) error {
+// iniFile returns the legacy INI document of the config.
+func (conf *ClientConfig) iniFile() (*ini.File, error) {
 	cfg := ini.Empty()
 	common, err := cfg.NewSection("common")
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if err = common.ReflectFrom(&conf.ClientCommon); err != nil {
-		return err
+		return nil, err
 	}
 	for k, v := range conf.Metas {
 		common.Key("meta_" + k).SetValue(v)
@@ -381,10 +457,10 @@ func (conf *ClientConfig) saveINI(path string) error {
 		}
 		p, err := cfg.NewSection(name)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if err = p.ReflectFrom(&proxy); err != nil {
-			return err
+			return nil, err
 		}
 		for k, v := range proxy.Metas {
 			p.Key("meta_" + k).SetValue(v)
@@ -396,10 +472,30 @@ func (conf *ClientConfig) saveINI(path string) error {
 			p.Key("plugin_header_" + k).SetValue(v)
 		}
 	}
-	return cfg.SaveTo(path)
+	return cfg, nil
 }
 
-func (conf *ClientConfig) saveTOML(path string) error {
+func (conf *ClientConfig) marshalV1() ([]byte, error) {
+	obj, err := conf.v1Map()
+	if err != nil {
+		return nil, err
+	}
+	var b []byte
+	switch conf.Format {
+	case consts.FormatYAML:
+		b, err = yaml.Marshal(obj)
+	case consts.FormatJSON:
+		if b, err = json.MarshalIndent(obj, "", "  "); err == nil {
+			b = append(b, '\n')
+		}
+	default:
+		b, err = toml.Marshal(obj)
+	}
+	return b, err
+}
+
+// v1Map returns the config in the v1 format as a map of keys.
+func (conf *ClientConfig) v1Map() (map[string]any, error) {
 	c := ClientConfigV1{
 		ClientCommonConfig: ClientCommonToV1(&conf.ClientCommon),
 		Mgr: Mgr{
@@ -408,28 +504,38 @@ func (conf *ClientConfig) saveTOML(path string) error {
 			AutoDelete:  conf.AutoDelete,
 		},
 	}
+	var proxyExtras, visitorExtras []map[string]any
 	for i, v := range conf.Proxies {
 		if v.IsVisitor() {
 			visitor := ClientVisitorToV1(v)
 			visitor.Mgr.Sort = i + 1
 			c.Visitors = append(c.Visitors, visitor)
+			visitorExtras = append(visitorExtras, v.Extras)
 		} else {
 			proxies, err := ClientProxyToV1(v)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			c.Proxies = append(c.Proxies, proxies...)
+			for range proxies {
+				proxyExtras = append(proxyExtras, v.Extras)
+			}
 		}
 	}
-	obj, err := toMap(&c, "json")
+	m, err := toMap(&c, "json")
 	if err != nil {
-		return err
+		return nil, err
 	}
-	b, err := toml.Marshal(obj)
-	if err != nil {
-		return err
+	// Fields that are not modeled are added back
+	mergeExtras(m, conf.Extras)
+	for key, extras := range map[string][]map[string]any{"proxies": proxyExtras, "visitors": visitorExtras} {
+		if sections, ok := m[key].([]any); ok {
+			for i, section := range sections {
+				mergeExtras(section.(map[string]any), extras[i])
+			}
+		}
 	}
-	return os.WriteFile(path, b, 0666)
+	return m, nil
 }
 
 // Complete prunes and completes this config.
@@ -488,11 +594,13 @@ func (conf *ClientConfig) Complete(read bool) {
 func (conf *ClientConfig) Copy(all bool) *ClientConfig {
 	newConf := NewDefaultClientConfig()
 	newConf.ClientCommon = conf.ClientCommon
+	newConf.Extras = cloneExtras(conf.Extras)
 	// We can't share the same log file between different configs
 	newConf.LogFile = ""
 	if all {
 		for _, proxy := range conf.Proxies {
 			var newProxy = *proxy
+			newProxy.Extras = cloneExtras(proxy.Extras)
 			newConf.Proxies = append(newConf.Proxies, &newProxy)
 		}
 	}
@@ -525,7 +633,13 @@ func (conf *ClientConfig) CountStart() int {
 func (conf *ClientConfig) Ext() string {
 	if conf.LegacyFormat {
 		return ".ini"
-	} else {
+	}
+	switch conf.Format {
+	case consts.FormatYAML:
+		return ".yaml"
+	case consts.FormatJSON:
+		return ".json"
+	default:
 		return ".toml"
 	}
 }
@@ -625,42 +739,74 @@ func UnmarshalClientConf(source interface{}) (*ClientConfig, error) {
 	if config.DetectLegacyINIFormat(b) {
 		return UnmarshalClientConfFromIni(source)
 	}
+	path, _ := source.(string)
+	format := detectFormat(path, b)
 	var cfg = NewDefaultClientConfigV1()
-	if err = config.LoadConfigure(b, &cfg, false); err != nil {
+	if err = config.LoadConfigure(b, &cfg, false, format); err != nil {
 		return nil, err
 	}
+	// The raw document is used to find fields that are not modeled
+	var raw map[string]any
+	if err = config.LoadConfigure(b, &raw, false, format); err != nil {
+		return nil, err
+	}
+	rawProxies, _ := raw["proxies"].([]any)
+	rawVisitors, _ := raw["visitors"].([]any)
 	var conf ClientConfig
 	conf.ClientCommon = ClientCommonFromV1(&cfg.ClientCommonConfig)
+	conf.Format = format
 	conf.ClientCommon.Name = cfg.Mgr.Name
 	conf.ManualStart = cfg.Mgr.ManualStart
 	conf.AutoDelete = cfg.Mgr.AutoDelete
+	if conf.Extras, err = commonExtras(&conf.ClientCommon, raw); err != nil {
+		return nil, err
+	}
 	// Proxies
-	ignore := make(map[string]struct{})
+	ignore := make(map[string]*Proxy)
 	proxies := make([]*Proxy, len(cfg.Proxies))
 	for i, v := range cfg.Proxies {
 		p := ClientProxyFromV1(v)
+		if i < len(rawProxies) {
+			if p.Extras, err = proxyExtras(p, rawProxies[i]); err != nil {
+				return nil, err
+			}
+		}
 		if p.IsRange() {
 			for _, name := range p.GetAlias() {
 				if name != p.Name {
-					ignore[name] = struct{}{}
+					ignore[name] = p
 				}
 			}
 		}
 		proxies[i] = p
 	}
 	conf.Proxies = lo.Filter(proxies, func(item *Proxy, index int) bool {
-		_, ok := ignore[item.Name]
+		first, ok := ignore[item.Name]
+		if ok {
+			conf.issues = append(conf.issues, rangeIssues(first, item)...)
+		}
 		return !ok
 	})
 	// Visitors
-	slices.SortStableFunc(cfg.Visitors, func(a, b TypedVisitorConfig) int {
-		if a.Mgr.Sort <= 0 && b.Mgr.Sort <= 0 {
+	visitors := make([]*Proxy, len(cfg.Visitors))
+	for i, v := range cfg.Visitors {
+		visitors[i] = ClientVisitorFromV1(v)
+		if i < len(rawVisitors) {
+			if visitors[i].Extras, err = proxyExtras(visitors[i], rawVisitors[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	order := lo.Range(len(cfg.Visitors))
+	slices.SortStableFunc(order, func(a, b int) int {
+		sa, sb := cfg.Visitors[a].Mgr.Sort, cfg.Visitors[b].Mgr.Sort
+		if sa <= 0 && sb <= 0 {
 			return 0
 		}
-		return a.Mgr.Sort - b.Mgr.Sort
+		return sa - sb
 	})
-	for _, v := range cfg.Visitors {
-		visitor := ClientVisitorFromV1(v)
+	for _, i := range order {
+		v, visitor := cfg.Visitors[i], visitors[i]
 		if v.Mgr.Sort <= 0 {
 			conf.Proxies = append(conf.Proxies, visitor)
 		} else {
@@ -671,6 +817,27 @@ func UnmarshalClientConf(source interface{}) (*ClientConfig, error) {
 	return &conf, nil
 }
 
+// detectFormat returns the file format of a v1 config. The file extension is used if
+// it's known, otherwise the format is detected from the content in the same way as frp.
+func detectFormat(path string, b []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return consts.FormatTOML
+	case ".yaml", ".yml":
+		return consts.FormatYAML
+	case ".json":
+		return consts.FormatJSON
+	}
+	var v any
+	if toml.Unmarshal(b, &v) == nil {
+		return consts.FormatTOML
+	}
+	if trimmed := bytes.TrimSpace(b); len(trimmed) > 0 && trimmed[0] == '{' {
+		return consts.FormatJSON
+	}
+	return consts.FormatYAML
+}
+
 func NewDefaultClientConfig() *ClientConfig {
 	return &ClientConfig{
 		ClientCommon: ClientCommon{
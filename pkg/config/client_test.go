@@ -1,9 +1,13 @@
 package config
 
 import (
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
+
+	frpconfig "github.com/fatedier/frp/pkg/config"
+	v1 "github.com/fatedier/frp/pkg/config/v1"
 )
 
 func TestUnmarshalClientConfFromIni(t *testing.T) {
@@ -71,3 +75,118 @@ func TestProxyGetAlias(t *testing.T) {
 		t.Errorf("Expected: %v, got: %v", expected, output)
 	}
 }
+
+func TestProxyValidate(t *testing.T) {
+	tests := []struct {
+		proxy Proxy
+		valid bool
+	}{
+		{Proxy{BaseProxyConf: BaseProxyConf{Name: "ssh", Type: "tcp", LocalPort: "22"}, RemotePort: "6000"}, true},
+		{Proxy{BaseProxyConf: BaseProxyConf{Name: "web", Type: "http", LocalPort: "80"}, SubDomain: "web"}, true},
+		{Proxy{BaseProxyConf: BaseProxyConf{Name: "web", Type: "http", LocalPort: "80"}}, false},
+		{Proxy{BaseProxyConf: BaseProxyConf{Name: "range", Type: "tcp", LocalPort: "6000-6001"}, RemotePort: "7000"}, false},
+		{Proxy{BaseProxyConf: BaseProxyConf{Name: "visitor", Type: "stcp"}, Role: "visitor", ServerName: "ssh", BindPort: 6000}, true},
+		{Proxy{BaseProxyConf: BaseProxyConf{Name: "visitor", Type: "stcp"}, Role: "visitor", ServerName: "ssh"}, false},
+		{Proxy{BaseProxyConf: BaseProxyConf{Name: "unknown", Type: "unknown"}}, false},
+	}
+	for i, test := range tests {
+		if err := test.proxy.Validate(); (err == nil) != test.valid {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.valid, err)
+		}
+	}
+}
+
+func TestSaveFormats(t *testing.T) {
+	expected := NewDefaultClientConfig()
+	expected.ClientCommon.Name = "test"
+	expected.ServerAddress = "example.com"
+	expected.ManualStart = true
+	expected.DeleteMethod = "absolute"
+	expected.DeleteAfterDate = time.Date(2023, 3, 23, 0, 0, 0, 0, time.UTC)
+	expected.Proxies = []*Proxy{
+		{BaseProxyConf: BaseProxyConf{Name: "ssh", Type: "tcp", LocalIP: "127.0.0.1", LocalPort: "22"}, RemotePort: "6000"},
+		{BaseProxyConf: BaseProxyConf{Name: "db", Type: "stcp"}, Role: "visitor", SK: "123", ServerName: "db",
+			BindAddr: "127.0.0.1", BindPort: 3306},
+		{BaseProxyConf: BaseProxyConf{Name: "games", Type: "udp", LocalIP: "127.0.0.1", LocalPort: "7000-7002"}, RemotePort: "8000-8002"},
+	}
+	expected.Complete(false)
+	for _, format := range []string{"toml", "yaml", "json"} {
+		t.Run(format, func(t *testing.T) {
+			expected.Format = format
+			path := filepath.Join(t.TempDir(), "test.conf")
+			if err := expected.Save(path); err != nil {
+				t.Fatal(err)
+			}
+			output, err := UnmarshalClientConf(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(output, expected) {
+				t.Errorf("Expected: %v, got: %v", expected, output)
+			}
+			if ext := output.Ext(); ext != "."+format {
+				t.Errorf("Expected: %v, got: %v", "."+format, ext)
+			}
+		})
+	}
+}
+
+func TestVirtualNet(t *testing.T) {
+	expected := NewDefaultClientConfig()
+	expected.ServerAddress = "example.com"
+	expected.Format = "toml"
+	expected.VirtualNetAddress = "100.86.0.1/24"
+	expected.FeatureGates = map[string]bool{"VirtualNet": true}
+	expected.Proxies = []*Proxy{
+		{BaseProxyConf: BaseProxyConf{Name: "vnet-server", Type: "stcp", Plugin: "virtual_net", PluginParams: PluginParams{
+			PluginDestinationIP: "100.86.0.2",
+		}}, SK: "123"},
+		{BaseProxyConf: BaseProxyConf{Name: "vnet-visitor", Type: "stcp", Plugin: "virtual_net", PluginParams: PluginParams{
+			PluginDestinationIP: "100.86.0.2", PluginLocalAddr: "127.0.0.1:80",
+		}}, Role: "visitor", SK: "123", ServerName: "vnet-server", BindPort: -1},
+	}
+	expected.Complete(false)
+	// Parameters not supported by the plugin are pruned
+	if ip := expected.Proxies[0].PluginDestinationIP; ip != "" {
+		t.Errorf("Expected: %v, got: %v", "", ip)
+	}
+	if addr := expected.Proxies[1].PluginLocalAddr; addr != "" {
+		t.Errorf("Expected: %v, got: %v", "", addr)
+	}
+	if ip := expected.Proxies[1].PluginDestinationIP; ip != "100.86.0.2" {
+		t.Errorf("Expected: %v, got: %v", "100.86.0.2", ip)
+	}
+	path := filepath.Join(t.TempDir(), "test.toml")
+	if err := expected.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	// The saved config is understood by frp
+	common, proxies, visitors, _, err := frpconfig.LoadClientConfig(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if common.VirtualNet.Address != expected.VirtualNetAddress {
+		t.Errorf("Expected: %v, got: %v", expected.VirtualNetAddress, common.VirtualNet.Address)
+	}
+	if !reflect.DeepEqual(common.FeatureGates, expected.FeatureGates) {
+		t.Errorf("Expected: %v, got: %v", expected.FeatureGates, common.FeatureGates)
+	}
+	if len(proxies) != 1 || len(visitors) != 1 {
+		t.Fatalf("Expected: %v, got: %v", "1 proxy and 1 visitor", []int{len(proxies), len(visitors)})
+	}
+	if plugin, ok := proxies[0].GetBaseConfig().Plugin.ClientPluginOptions.(*v1.VirtualNetPluginOptions); !ok {
+		t.Errorf("Expected: %v, got: %v", &v1.VirtualNetPluginOptions{Type: "virtual_net"}, plugin)
+	}
+	if plugin, ok := visitors[0].GetBaseConfig().Plugin.VisitorPluginOptions.(*v1.VirtualNetVisitorPluginOptions); !ok ||
+		plugin.DestinationIP != "100.86.0.2" {
+		t.Errorf("Expected: %v, got: %v", "100.86.0.2", plugin)
+	}
+	// The config is unchanged after a round trip
+	output, err := UnmarshalClientConf(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Expected: %v, got: %v", expected, output)
+	}
+}
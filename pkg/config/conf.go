@@ -62,3 +62,22 @@ func Expiry(configPath string, del AutoDelete) (time.Duration, error) {
 	}
 	return 0, os.ErrNoDeadline
 }
+
+// LimitExpiry makes the config expire no later than the given time.
+// A zero time means no limit.
+func (conf *ClientConfig) LimitExpiry(t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	switch conf.DeleteMethod {
+	case consts.DeleteAbsolute:
+		if !conf.DeleteAfterDate.After(t) {
+			return
+		}
+	case consts.DeleteRelative:
+		if conf.DeleteAfterDays > 0 && !time.Now().AddDate(0, 0, int(conf.DeleteAfterDays)).After(t) {
+			return
+		}
+	}
+	conf.AutoDelete = AutoDelete{DeleteMethod: consts.DeleteAbsolute, DeleteAfterDate: t}
+}
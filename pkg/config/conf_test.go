@@ -37,3 +37,24 @@ func TestExpiry(t *testing.T) {
 		}
 	}
 }
+
+func TestLimitExpiry(t *testing.T) {
+	limit := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	tests := []struct {
+		input    AutoDelete
+		expected AutoDelete
+	}{
+		{AutoDelete{}, AutoDelete{DeleteMethod: "absolute", DeleteAfterDate: limit}},
+		{AutoDelete{DeleteMethod: "relative", DeleteAfterDays: 1}, AutoDelete{DeleteMethod: "relative", DeleteAfterDays: 1}},
+		{AutoDelete{DeleteMethod: "relative", DeleteAfterDays: 3}, AutoDelete{DeleteMethod: "absolute", DeleteAfterDate: limit}},
+		{AutoDelete{DeleteMethod: "absolute", DeleteAfterDate: limit.Add(-time.Hour)}, AutoDelete{DeleteMethod: "absolute", DeleteAfterDate: limit.Add(-time.Hour)}},
+	}
+	for i, test := range tests {
+		conf := NewDefaultClientConfig()
+		conf.AutoDelete = test.input
+		conf.LimitExpiry(limit)
+		if conf.AutoDelete != test.expected {
+			t.Errorf("Test %d: expected: %v, got: %v", i, test.expected, conf.AutoDelete)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	frputil "github.com/fatedier/frp/pkg/util/util"
+
+	"github.com/koho/frpmgr/pkg/consts"
+)
+
+// ConflictKind is the kind of resource claimed by more than one config.
+type ConflictKind string
+
+const (
+	ConflictRemotePort   ConflictKind = "remote port"
+	ConflictCustomDomain ConflictKind = "custom domain"
+	ConflictSubdomain    ConflictKind = "subdomain"
+	ConflictBindPort     ConflictKind = "bind port"
+)
+
+// ProxyRef refers to a proxy of a config.
+type ProxyRef struct {
+	Config string `json:"config"`
+	Proxy  string `json:"proxy"`
+
+	conf *ClientConfig
+}
+
+// Conflict is a resource claimed by proxies of different configs.
+type Conflict struct {
+	Kind ConflictKind `json:"kind"`
+	// Server is the address of the server, or empty for local resources.
+	Server string `json:"server,omitempty"`
+	// Resource identifies the claimed resource, e.g. "tcp/6000" or "http/example.com".
+	Resource string     `json:"resource"`
+	Proxies  []ProxyRef `json:"proxies"`
+}
+
+func (c Conflict) String() string {
+	users := make([]string, len(c.Proxies))
+	for i, p := range c.Proxies {
+		users[i] = fmt.Sprintf("%s/%s", p.Config, p.Proxy)
+	}
+	where := "local"
+	if c.Server != "" {
+		where = c.Server
+	}
+	return fmt.Sprintf("%s %s on %s is claimed by %s", c.Kind, c.Resource, where, strings.Join(users, ", "))
+}
+
+// Involves reports whether a proxy of the given config is part of the conflict.
+func (c Conflict) Involves(conf *ClientConfig) bool {
+	return slices.ContainsFunc(c.Proxies, func(p ProxyRef) bool { return p.conf == conf })
+}
+
+// claim is a resource used by a proxy.
+type claim struct {
+	kind     ConflictKind
+	server   string
+	resource string
+	// addr is the local address of a bind port.
+	addr string
+	ref  ProxyRef
+}
+
+// FindConflicts reports resources that are claimed by proxies of more than one config.
+// Remote ports, custom domains and subdomains are compared between configs connecting to
+// the same server, while bind ports of visitors are compared between all configs.
+// Conflicts inside a single config are reported by Lint instead.
+func FindConflicts(confs []*ClientConfig) []Conflict {
+	var claims []claim
+	for _, conf := range confs {
+		claims = append(claims, claimsOf(conf)...)
+	}
+	type groupKey struct {
+		kind     ConflictKind
+		server   string
+		resource string
+	}
+	groups := make(map[groupKey][]claim)
+	var order []groupKey
+	for _, c := range claims {
+		key := groupKey{c.kind, c.server, c.resource}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], c)
+	}
+	var conflicts []Conflict
+	for _, key := range order {
+		group := groups[key]
+		var refs []ProxyRef
+		for i, c := range group {
+			for j, other := range group {
+				if i != j && c.ref.conf != other.ref.conf && addrOverlaps(c.addr, other.addr) {
+					refs = append(refs, c.ref)
+					break
+				}
+			}
+		}
+		if len(refs) > 0 {
+			conflicts = append(conflicts, Conflict{Kind: key.kind, Server: key.server, Resource: key.resource, Proxies: refs})
+		}
+	}
+	return mergePorts(conflicts)
+}
+
+// mergePorts combines remote port conflicts between the same proxies into port ranges.
+func mergePorts(conflicts []Conflict) []Conflict {
+	type mergeKey struct {
+		server string
+		proto  string
+		refs   string
+	}
+	ports := make(map[mergeKey][]int64)
+	first := make(map[mergeKey]int)
+	result := make([]Conflict, 0, len(conflicts))
+	for _, c := range conflicts {
+		proto, port, ok := strings.Cut(c.Resource, "/")
+		if c.Kind != ConflictRemotePort || !ok {
+			result = append(result, c)
+			continue
+		}
+		n, _ := strconv.ParseInt(port, 10, 64)
+		key := mergeKey{c.Server, proto, fmt.Sprint(c.Proxies)}
+		if _, ok = first[key]; !ok {
+			first[key] = len(result)
+			result = append(result, c)
+		}
+		ports[key] = append(ports[key], n)
+	}
+	for key, i := range first {
+		result[i].Resource = key.proto + "/" + formatPorts(ports[key])
+	}
+	return result
+}
+
+// claimsOf returns the resources used by the enabled proxies of a config.
+func claimsOf(conf *ClientConfig) []claim {
+	server := net.JoinHostPort(strings.ToLower(conf.ServerAddress), strconv.Itoa(conf.ServerPort))
+	var claims []claim
+	for _, proxy := range conf.Proxies {
+		if proxy.Disabled {
+			continue
+		}
+		ref := ProxyRef{Config: conf.Name(), Proxy: proxy.Name, conf: conf}
+		if proxy.IsVisitor() {
+			if proxy.BindPort <= 0 {
+				continue
+			}
+			proto := consts.ProxyTypeTCP
+			if proxy.Type == consts.ProxyTypeSUDP {
+				proto = consts.ProxyTypeUDP
+			}
+			addr := proxy.BindAddr
+			if addr == "" {
+				addr = "127.0.0.1"
+			}
+			claims = append(claims, claim{
+				kind: ConflictBindPort, resource: fmt.Sprintf("%s/%d", proto, proxy.BindPort), addr: addr, ref: ref,
+			})
+			continue
+		}
+		switch proxy.Type {
+		case consts.ProxyTypeTCP, consts.ProxyTypeUDP:
+			ports, err := frputil.ParseRangeNumbers(proxy.RemotePort)
+			if err != nil {
+				continue
+			}
+			for _, port := range ports {
+				// Port 0 means a random port is assigned by the server.
+				if port == 0 {
+					continue
+				}
+				claims = append(claims, claim{
+					kind: ConflictRemotePort, server: server, resource: fmt.Sprintf("%s/%d", proxy.Type, port), ref: ref,
+				})
+			}
+		case consts.ProxyTypeHTTP, consts.ProxyTypeHTTPS, consts.ProxyTypeTCPMUX:
+			// HTTP proxies of the same domain can be routed by locations and users.
+			routes := []string{""}
+			if proxy.Type == consts.ProxyTypeHTTP && proxy.Locations != "" {
+				routes = splitList(proxy.Locations)
+			}
+			if proxy.RouteByHTTPUser != "" {
+				for i := range routes {
+					routes[i] += "@" + proxy.RouteByHTTPUser
+				}
+			}
+			for _, domain := range splitList(proxy.CustomDomains) {
+				for _, route := range routes {
+					claims = append(claims, claim{
+						kind: ConflictCustomDomain, server: server, resource: proxy.Type + "/" + strings.ToLower(domain) + route, ref: ref,
+					})
+				}
+			}
+			if proxy.SubDomain != "" {
+				for _, route := range routes {
+					claims = append(claims, claim{
+						kind: ConflictSubdomain, server: server, resource: proxy.Type + "/" + strings.ToLower(proxy.SubDomain) + route, ref: ref,
+					})
+				}
+			}
+		}
+	}
+	return claims
+}
+
+// addrOverlaps reports whether two local addresses can't listen on the same port.
+// Remote resources have no address and always overlap.
+func addrOverlaps(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ip1, ip2 := net.ParseIP(a), net.ParseIP(b)
+	return ip1 != nil && ip1.IsUnspecified() || ip2 != nil && ip2.IsUnspecified()
+}
+
+// splitList splits a comma-separated list and drops empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindConflicts(t *testing.T) {
+	newConf := func(name, server string, proxies ...*Proxy) *ClientConfig {
+		conf := NewDefaultClientConfig()
+		conf.ClientCommon.Name = name
+		conf.ServerAddress = server
+		conf.Proxies = proxies
+		return conf
+	}
+	a := newConf("a", "example.com",
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "ssh", Type: "tcp"}, RemotePort: "6000"},
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "range:games", Type: "tcp", LocalPort: "1-5"}, RemotePort: "7000-7004"},
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "web", Type: "http"}, CustomDomains: "Example.com, www.example.com", Locations: "/api"},
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "blog", Type: "http"}, SubDomain: "blog"},
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "db", Type: "stcp"}, Role: "visitor", ServerName: "db", BindPort: 9000},
+	)
+	b := newConf("b", "EXAMPLE.com",
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "ssh2", Type: "tcp"}, RemotePort: "6000"},
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "dns", Type: "udp"}, RemotePort: "7000"},
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "rdp", Type: "tcp", LocalPort: "1-3"}, RemotePort: "7002-7004,7010"},
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "web", Type: "http"}, CustomDomains: "example.com", Locations: "/api,/"},
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "blog", Type: "http"}, SubDomain: "blog"},
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "db", Type: "xtcp"}, Role: "visitor", ServerName: "db", BindAddr: "0.0.0.0", BindPort: 9000},
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "off", Type: "tcp", Disabled: true}, RemotePort: "6000"},
+	)
+	// Same ports on a different server
+	c := newConf("c", "other.com",
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "ssh", Type: "tcp"}, RemotePort: "6000"},
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "blog", Type: "http"}, SubDomain: "blog"},
+		&Proxy{BaseProxyConf: BaseProxyConf{Name: "dns", Type: "sudp"}, Role: "visitor", ServerName: "dns", BindPort: 9000},
+	)
+	ref := func(conf *ClientConfig, proxy string) ProxyRef {
+		return ProxyRef{Config: conf.Name(), Proxy: proxy, conf: conf}
+	}
+	expected := []Conflict{
+		{ConflictRemotePort, "example.com:7000", "tcp/6000", []ProxyRef{ref(a, "ssh"), ref(b, "ssh2")}},
+		{ConflictRemotePort, "example.com:7000", "tcp/7002-7004", []ProxyRef{ref(a, "range:games"), ref(b, "rdp")}},
+		{ConflictCustomDomain, "example.com:7000", "http/example.com/api", []ProxyRef{ref(a, "web"), ref(b, "web")}},
+		{ConflictSubdomain, "example.com:7000", "http/blog", []ProxyRef{ref(a, "blog"), ref(b, "blog")}},
+		{ConflictBindPort, "", "tcp/9000", []ProxyRef{ref(a, "db"), ref(b, "db")}},
+	}
+	conflicts := FindConflicts([]*ClientConfig{a, b, c})
+	if !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("Expected: %v, got: %v", expected, conflicts)
+	}
+	if !conflicts[0].Involves(a) || conflicts[0].Involves(c) {
+		t.Errorf("Expected: %v, got: %v", "[true false]", []bool{conflicts[0].Involves(a), conflicts[0].Involves(c)})
+	}
+}
@@ -110,6 +110,8 @@ func ClientCommonFromV1(c *v1.ClientCommonConfig) (r ClientCommon) {
 	r.UDPPacketSize = c.UDPPacketSize
 	r.Metas = c.Metadatas
 	r.Store = c.Store
+	r.VirtualNetAddress = c.VirtualNet.Address
+	r.FeatureGates = c.FeatureGates
 	return
 }
 
@@ -280,6 +282,11 @@ func clientVisitorBaseFromV1(c *v1.VisitorBaseConfig, out *Proxy) {
 	out.ServerName = c.ServerName
 	out.BindAddr = c.BindAddr
 	out.BindPort = c.BindPort
+	out.Plugin = c.Plugin.Type
+	switch v := c.Plugin.VisitorPluginOptions.(type) {
+	case *v1.VirtualNetVisitorPluginOptions:
+		out.PluginDestinationIP = v.DestinationIP
+	}
 }
 
 func ClientCommonToV1(c *ClientCommon) (r v1.ClientCommonConfig) {
@@ -382,6 +389,8 @@ func ClientCommonToV1(c *ClientCommon) (r v1.ClientCommonConfig) {
 	r.UDPPacketSize = c.UDPPacketSize
 	r.Metadatas = c.Metas
 	r.Store = c.Store
+	r.VirtualNet.Address = c.VirtualNetAddress
+	r.FeatureGates = c.FeatureGates
 	return
 }
 
@@ -652,6 +661,10 @@ func clientProxyBaseToV1(c *BaseProxyConf) (v1.ProxyBaseConfig, error) {
 			CrtPath:   c.PluginCrtPath,
 			KeyPath:   c.PluginKeyPath,
 		}
+	case consts.PluginVirtualNet:
+		r.Plugin.ClientPluginOptions = &v1.VirtualNetPluginOptions{
+			Type: c.Plugin,
+		}
 	}
 	return r, nil
 }
@@ -701,6 +714,16 @@ func clientVisitorBaseToV1(p *Proxy) v1.VisitorBaseConfig {
 	if p.Disabled {
 		r.Enabled = new(bool)
 	}
+	switch p.Plugin {
+	case consts.PluginVirtualNet:
+		r.Plugin = v1.TypedVisitorPluginOptions{
+			Type: p.Plugin,
+			VisitorPluginOptions: &v1.VirtualNetVisitorPluginOptions{
+				Type:          p.Plugin,
+				DestinationIP: p.PluginDestinationIP,
+			},
+		}
+	}
 	return r
 }
 
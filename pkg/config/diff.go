@@ -0,0 +1,282 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/koho/frpmgr/pkg/util"
+)
+
+// Change is a field whose value differs between two configs.
+type Change struct {
+	// Field is the Go field name, nested fields are joined by dots, e.g. "AdminTLS.CertFile".
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// ProxyDiff is the changes of a proxy that exists in both configs.
+type ProxyDiff struct {
+	Name string `json:"name"`
+	// NewName is the name in the new config if the proxy is renamed.
+	NewName string   `json:"newName,omitempty"`
+	Changes []Change `json:"changes,omitempty"`
+	// AddedAliases and RemovedAliases are the changes of the proxy names
+	// registered on the server, such as the ports of a range proxy.
+	AddedAliases   []string `json:"addedAliases,omitempty"`
+	RemovedAliases []string `json:"removedAliases,omitempty"`
+}
+
+// Diff is the structured difference between two configs.
+type Diff struct {
+	Common  []Change    `json:"common,omitempty"`
+	Added   []string    `json:"added,omitempty"`
+	Removed []string    `json:"removed,omitempty"`
+	Changed []ProxyDiff `json:"changed,omitempty"`
+}
+
+// diffIgnored are the common fields that are managed per profile, so they are not compared.
+var diffIgnored = []string{"LogFile", "Store.Path"}
+
+// DiffConfigs compares the old config with the new config semantically.
+//
+// Proxies are matched by name first, then by the aliases registered on the server,
+// so a range proxy matches the single proxies of its ports. A removed proxy and an added
+// proxy that only differ in names are reported as a renamed proxy.
+func DiffConfigs(old, new *ClientConfig) *Diff {
+	d := &Diff{Common: omitChanges(diffStruct(reflect.ValueOf(old.ClientCommon), reflect.ValueOf(new.ClientCommon), ""), diffIgnored...)}
+	matched := make(map[*Proxy]*Proxy)
+	var unmatched []*Proxy
+	for _, p := range old.Proxies {
+		i := slices.IndexFunc(new.Proxies, func(np *Proxy) bool { return np.Name == p.Name })
+		if i < 0 {
+			aliases := p.GetAlias()
+			i = slices.IndexFunc(new.Proxies, func(np *Proxy) bool {
+				return matched[np] == nil && slices.ContainsFunc(np.GetAlias(), func(alias string) bool {
+					return slices.Contains(aliases, alias)
+				})
+			})
+		}
+		if i < 0 || matched[new.Proxies[i]] != nil {
+			unmatched = append(unmatched, p)
+			continue
+		}
+		matched[new.Proxies[i]] = p
+	}
+	// Renamed proxies
+	for _, np := range new.Proxies {
+		if matched[np] != nil {
+			continue
+		}
+		if i := slices.IndexFunc(unmatched, func(p *Proxy) bool {
+			renamed := *np
+			renamed.Name = p.Name
+			return len(diffProxy(p, &renamed).Changes) == 0
+		}); i >= 0 {
+			matched[np] = unmatched[i]
+			unmatched = slices.Delete(unmatched, i, i+1)
+		}
+	}
+	for _, p := range old.Proxies {
+		if slices.Contains(unmatched, p) {
+			d.Removed = append(d.Removed, p.Name)
+		}
+	}
+	for _, np := range new.Proxies {
+		p := matched[np]
+		if p == nil {
+			d.Added = append(d.Added, np.Name)
+		} else if pd := diffProxy(p, np); pd.NewName != "" || len(pd.Changes) > 0 || len(pd.AddedAliases) > 0 || len(pd.RemovedAliases) > 0 {
+			d.Changed = append(d.Changed, pd)
+		}
+	}
+	return d
+}
+
+// diffProxy compares two proxies. The name change is reported by NewName.
+func diffProxy(old, new *Proxy) ProxyDiff {
+	pd := ProxyDiff{Name: old.Name}
+	if new.Name != old.Name {
+		pd.NewName = new.Name
+	}
+	pd.Changes = omitChanges(diffStruct(reflect.ValueOf(*old), reflect.ValueOf(*new), ""), "Name")
+	oldAliases, newAliases := old.GetAlias(), new.GetAlias()
+	for _, alias := range newAliases {
+		if !slices.Contains(oldAliases, alias) {
+			pd.AddedAliases = append(pd.AddedAliases, alias)
+		}
+	}
+	for _, alias := range oldAliases {
+		if !slices.Contains(newAliases, alias) {
+			pd.RemovedAliases = append(pd.RemovedAliases, alias)
+		}
+	}
+	// A plain rename of a proxy without aliases doesn't need to list them
+	if !old.IsRange() && !new.IsRange() {
+		pd.AddedAliases, pd.RemovedAliases = nil, nil
+	}
+	return pd
+}
+
+// Empty reports whether the two configs are the same.
+func (d *Diff) Empty() bool {
+	return len(d.Common) == 0 && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Summary returns a short description of the changes, e.g.
+// "changed ServerAddress; added proxy web; modified proxy ssh".
+func (d *Diff) Summary() string {
+	var parts []string
+	if n := len(d.Common); n > 3 {
+		parts = append(parts, fmt.Sprintf("changed %d fields", n))
+	} else if n > 0 {
+		parts = append(parts, "changed "+strings.Join(changedFields(d.Common), ", "))
+	}
+	for _, s := range []struct {
+		verb  string
+		names []string
+	}{
+		{"added", d.Added},
+		{"removed", d.Removed},
+		{"renamed", renamedProxies(d.Changed)},
+		{"modified", changedProxies(d.Changed)},
+	} {
+		if len(s.names) > 0 {
+			parts = append(parts, s.verb+" proxy "+strings.Join(s.names, ", "))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// String returns the changes in lines. Added, removed and changed items are
+// prefixed by "+", "-" and "~" respectively.
+func (d *Diff) String() string {
+	var b strings.Builder
+	writeChanges(&b, d.Common, "")
+	for _, name := range d.Added {
+		fmt.Fprintf(&b, "+ proxy %s\n", name)
+	}
+	for _, name := range d.Removed {
+		fmt.Fprintf(&b, "- proxy %s\n", name)
+	}
+	for _, pd := range d.Changed {
+		if pd.NewName != "" {
+			fmt.Fprintf(&b, "~ proxy %s -> %s\n", pd.Name, pd.NewName)
+		} else {
+			fmt.Fprintf(&b, "~ proxy %s\n", pd.Name)
+		}
+		writeChanges(&b, pd.Changes, "    ")
+		for _, alias := range pd.AddedAliases {
+			fmt.Fprintf(&b, "    + alias %s\n", alias)
+		}
+		for _, alias := range pd.RemovedAliases {
+			fmt.Fprintf(&b, "    - alias %s\n", alias)
+		}
+	}
+	return b.String()
+}
+
+func writeChanges(b *strings.Builder, changes []Change, indent string) {
+	for _, c := range changes {
+		fmt.Fprintf(b, "%s~ %s: %s -> %s\n", indent, c.Field, formatChange(c.Old), formatChange(c.New))
+	}
+}
+
+// formatChange returns the JSON representation of a changed value.
+func formatChange(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func changedFields(changes []Change) []string {
+	fields := make([]string, len(changes))
+	for i, c := range changes {
+		fields[i] = c.Field
+	}
+	return fields
+}
+
+// changedProxies returns the names of proxies with field changes.
+func changedProxies(diffs []ProxyDiff) []string {
+	var names []string
+	for _, pd := range diffs {
+		if len(pd.Changes) > 0 || len(pd.AddedAliases) > 0 || len(pd.RemovedAliases) > 0 {
+			names = append(names, util.GetOrElse(pd.NewName, pd.Name))
+		}
+	}
+	return names
+}
+
+func renamedProxies(diffs []ProxyDiff) []string {
+	var names []string
+	for _, pd := range diffs {
+		if pd.NewName != "" {
+			names = append(names, pd.Name+" to "+pd.NewName)
+		}
+	}
+	return names
+}
+
+// omitChanges returns the changes except the given fields.
+func omitChanges(changes []Change, fields ...string) []Change {
+	var r []Change
+	for _, c := range changes {
+		if !slices.Contains(fields, c.Field) {
+			r = append(r, c)
+		}
+	}
+	return r
+}
+
+// diffStruct returns the changed fields of two structs of the same type.
+// Embedded structs are flattened, and other nested structs are compared by their fields.
+func diffStruct(old, new reflect.Value, prefix string) []Change {
+	var changes []Change
+	t := old.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		ov, nv := old.Field(i), new.Field(i)
+		name := prefix + f.Name
+		if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeFor[time.Time]() {
+			if f.Anonymous {
+				changes = append(changes, diffStruct(ov, nv, prefix)...)
+			} else {
+				changes = append(changes, diffStruct(ov, nv, name+".")...)
+			}
+			continue
+		}
+		if !equalValue(ov, nv) {
+			changes = append(changes, Change{Field: name, Old: ov.Interface(), New: nv.Interface()})
+		}
+	}
+	return changes
+}
+
+// equalValue reports whether two values are equal. Nil and empty maps or slices are equal.
+func equalValue(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Map, reflect.Slice:
+		if a.Len() == 0 && b.Len() == 0 {
+			return true
+		}
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return equalValue(a.Elem(), b.Elem())
+	}
+	if t, ok := a.Interface().(time.Time); ok {
+		return t.Equal(b.Interface().(time.Time))
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	old := NewDefaultClientConfig()
+	old.ServerAddress = "example.com"
+	old.Proxies = []*Proxy{
+		{BaseProxyConf: BaseProxyConf{Name: "ssh", Type: "tcp", LocalPort: "22"}, RemotePort: "6000"},
+		{BaseProxyConf: BaseProxyConf{Name: "web", Type: "http", LocalPort: "80"}, SubDomain: "web"},
+	}
+	new := old.Copy(true)
+	new.ServerAddress = "frp.example.com"
+	new.AdminTLS.CertFile = "cert.pem"
+	new.Metas = map[string]string{}
+	new.Proxies[0].RemotePort = "6022"
+	new.DeleteProxy(1)
+	new.AddProxy(&Proxy{BaseProxyConf: BaseProxyConf{Name: "dns", Type: "udp", LocalPort: "53"}, RemotePort: "6053"})
+
+	expected := &Diff{
+		Common: []Change{
+			{Field: "ServerAddress", Old: "example.com", New: "frp.example.com"},
+			{Field: "AdminTLS.CertFile", Old: "", New: "cert.pem"},
+		},
+		Added:   []string{"dns"},
+		Removed: []string{"web"},
+		Changed: []ProxyDiff{{Name: "ssh", Changes: []Change{{Field: "RemotePort", Old: "6000", New: "6022"}}}},
+	}
+	diff := DiffConfigs(old, new)
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("Expected: %v, got: %v", expected, diff)
+	}
+	summary := "changed ServerAddress, AdminTLS.CertFile; added proxy dns; removed proxy web; modified proxy ssh"
+	if s := diff.Summary(); s != summary {
+		t.Errorf("Expected: %v, got: %v", summary, s)
+	}
+	text := `~ ServerAddress: "example.com" -> "frp.example.com"
+~ AdminTLS.CertFile: "" -> "cert.pem"
++ proxy dns
+- proxy web
+~ proxy ssh
+    ~ RemotePort: "6000" -> "6022"
+`
+	if s := diff.String(); s != text {
+		t.Errorf("Expected: %v, got: %v", text, s)
+	}
+	if diff = DiffConfigs(old, old); !diff.Empty() {
+		t.Errorf("Expected: %v, got: %v", &Diff{}, diff)
+	}
+}
+
+func TestDiffConfigsProxies(t *testing.T) {
+	old := NewDefaultClientConfig()
+	old.LogFile = "logs/a.log"
+	old.Proxies = []*Proxy{
+		{BaseProxyConf: BaseProxyConf{Name: "ssh", Type: "tcp", LocalPort: "22"}, RemotePort: "6000"},
+		{BaseProxyConf: BaseProxyConf{Name: "games", Type: "udp", LocalPort: "7000-7001"}, RemotePort: "8000-8001"},
+		{BaseProxyConf: BaseProxyConf{Name: "dns", Type: "udp", LocalPort: "53"}, RemotePort: "6053"},
+	}
+	new := NewDefaultClientConfig()
+	new.LogFile = "logs/b.log"
+	new.Proxies = []*Proxy{
+		{BaseProxyConf: BaseProxyConf{Name: "shell", Type: "tcp", LocalPort: "22"}, RemotePort: "6000"},
+		{BaseProxyConf: BaseProxyConf{Name: "games_0", Type: "udp", LocalPort: "7000"}, RemotePort: "8000"},
+		{BaseProxyConf: BaseProxyConf{Name: "dns", Type: "udp", LocalPort: "53-54"}, RemotePort: "6053-6054"},
+	}
+	expected := &Diff{
+		Changed: []ProxyDiff{
+			{Name: "ssh", NewName: "shell"},
+			{Name: "games", NewName: "games_0", Changes: []Change{
+				{Field: "LocalPort", Old: "7000-7001", New: "7000"},
+				{Field: "RemotePort", Old: "8000-8001", New: "8000"},
+			}, RemovedAliases: []string{"games_1"}},
+			{Name: "dns", Changes: []Change{
+				{Field: "LocalPort", Old: "53", New: "53-54"},
+				{Field: "RemotePort", Old: "6053", New: "6053-6054"},
+			}, AddedAliases: []string{"dns_0", "dns_1"}, RemovedAliases: []string{"dns"}},
+		},
+	}
+	diff := DiffConfigs(old, new)
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("Expected: %v, got: %v", expected, diff)
+	}
+	summary := "renamed proxy ssh to shell, games to games_0; modified proxy games_0, dns"
+	if s := diff.Summary(); s != summary {
+		t.Errorf("Expected: %v, got: %v", summary, s)
+	}
+	text := `~ proxy ssh -> shell
+~ proxy games -> games_0
+    ~ LocalPort: "7000-7001" -> "7000"
+    ~ RemotePort: "8000-8001" -> "8000"
+    - alias games_1
+~ proxy dns
+    ~ LocalPort: "53" -> "53-54"
+    ~ RemotePort: "6053" -> "6053-6054"
+    + alias dns_0
+    + alias dns_1
+    - alias dns
+`
+	if s := diff.String(); s != text {
+		t.Errorf("Expected: %v, got: %v", text, s)
+	}
+}
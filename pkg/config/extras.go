@@ -0,0 +1,190 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ConversionIssue is a frp v1 field that can't be represented by the config,
+// so it's dropped when the config is saved.
+type ConversionIssue struct {
+	// Proxy is the name of the proxy or visitor, or empty for the common section.
+	Proxy string `json:"proxy,omitempty"`
+	// Field is the dotted v1 path of the field, e.g. "transport.quic.keepalivePeriod".
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+func (i ConversionIssue) String() string {
+	if i.Proxy == "" {
+		return fmt.Sprintf("%s: %s", i.Field, i.Reason)
+	}
+	return fmt.Sprintf("[%s] %s: %s", i.Proxy, i.Field, i.Reason)
+}
+
+// ConversionReport lists the fields of the source file that are lost when the config is saved.
+// Fields that are not modeled by the config are kept as extras of the v1 format,
+// but they can't be written to the legacy INI format.
+func (conf *ClientConfig) ConversionReport() []ConversionIssue {
+	issues := slices.Clone(conf.issues)
+	if conf.LegacyFormat {
+		const reason = "not supported by the legacy format"
+		issues = append(issues, extraIssues("", conf.Extras, nil, reason)...)
+		for _, proxy := range conf.Proxies {
+			issues = append(issues, extraIssues(proxy.Name, proxy.Extras, nil, reason)...)
+		}
+	}
+	return issues
+}
+
+// extraIssues returns an issue for each leaf field of the extras.
+func extraIssues(proxy string, extras map[string]any, prefix []string, reason string) []ConversionIssue {
+	var issues []ConversionIssue
+	for _, k := range sortedKeys(extras) {
+		path := append(slices.Clone(prefix), k)
+		if sub, ok := extras[k].(map[string]any); ok {
+			issues = append(issues, extraIssues(proxy, sub, path, reason)...)
+		} else {
+			issues = append(issues, ConversionIssue{proxy, strings.Join(path, "."), reason})
+		}
+	}
+	return issues
+}
+
+// commonExtras returns the fields of the raw v1 document that are not modeled by the common config.
+func commonExtras(c *ClientCommon, raw map[string]any) (map[string]any, error) {
+	v1Conf := ClientConfigV1{ClientCommonConfig: ClientCommonToV1(c)}
+	known, err := toMap(&v1Conf, "json")
+	if err != nil {
+		return nil, err
+	}
+	return subtractKeys(omitKeys(raw, map[string]bool{"proxies": true, "visitors": true, "frpmgr": true}), known), nil
+}
+
+// proxyExtras returns the fields of the raw v1 section that are not modeled by the proxy.
+func proxyExtras(p *Proxy, raw any) (map[string]any, error) {
+	section, ok := raw.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	var known map[string]any
+	var err error
+	if p.IsVisitor() {
+		visitor := ClientVisitorToV1(p)
+		known, err = toMap(&visitor, "json")
+	} else {
+		var proxies []TypedProxyConfig
+		if proxies, err = ClientProxyToV1(p); err != nil {
+			return nil, err
+		}
+		known, err = toMap(&proxies[0], "json")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return subtractKeys(omitKeys(section, map[string]bool{"frpmgr": true}), known), nil
+}
+
+// rangeIssues reports the extras of a port of a range proxy that differ from the first port.
+// A range proxy is made of the first port, so these fields are dropped.
+func rangeIssues(first, port *Proxy) []ConversionIssue {
+	extras := make(map[string]any)
+	for k, v := range flattenTOML(port.Extras, nil, nil) {
+		if !reflect.DeepEqual(lookupTOML(first.Extras, splitPath(k)), v) {
+			setTOML(extras, splitPath(k), v)
+		}
+	}
+	return extraIssues(port.Name, extras, nil, fmt.Sprintf("differs from the first port of range proxy %q", first.Name))
+}
+
+// subtractKeys returns the fields of "m" whose keys are not in "known".
+// Nested tables are compared by keys recursively, other values are compared by presence.
+func subtractKeys(m, known map[string]any) map[string]any {
+	var r map[string]any
+	for k, v := range m {
+		var extra any = v
+		if kv, ok := known[k]; ok {
+			sub, ok1 := v.(map[string]any)
+			ksub, ok2 := kv.(map[string]any)
+			if !ok1 || !ok2 {
+				continue
+			}
+			subExtra := subtractKeys(sub, ksub)
+			if subExtra == nil {
+				continue
+			}
+			extra = subExtra
+		}
+		if r == nil {
+			r = make(map[string]any)
+		}
+		r[k] = normalizeNumbers(extra)
+	}
+	return r
+}
+
+// mergeExtras adds the extras to the map without overriding existing keys.
+func mergeExtras(m, extras map[string]any) {
+	for k, v := range extras {
+		ev, ok := m[k]
+		if !ok {
+			m[k] = v
+			continue
+		}
+		sub, ok1 := ev.(map[string]any)
+		esub, ok2 := v.(map[string]any)
+		if ok1 && ok2 {
+			mergeExtras(sub, esub)
+		}
+	}
+}
+
+// normalizeNumbers converts integral numbers decoded from JSON to integers,
+// so they are not written as floats.
+func normalizeNumbers(v any) any {
+	switch t := v.(type) {
+	case float64:
+		if t == math.Trunc(t) && math.Abs(t) < 1<<53 {
+			return int64(t)
+		}
+	case map[string]any:
+		for k, e := range t {
+			t[k] = normalizeNumbers(e)
+		}
+	case []any:
+		for i, e := range t {
+			t[i] = normalizeNumbers(e)
+		}
+	}
+	return v
+}
+
+// cloneExtras returns a deep copy of the extras, so it can be modified
+// without affecting the original config.
+func cloneExtras(extras map[string]any) map[string]any {
+	if extras == nil {
+		return nil
+	}
+	return cloneValue(extras).(map[string]any)
+}
+
+func cloneValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(t))
+		for k, e := range t {
+			m[k] = cloneValue(e)
+		}
+		return m
+	case []any:
+		s := make([]any, len(t))
+		for i, e := range t {
+			s[i] = cloneValue(e)
+		}
+		return s
+	}
+	return v
+}
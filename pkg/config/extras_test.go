@@ -0,0 +1,121 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExtras(t *testing.T) {
+	input := `
+serverAddr = "example.com"
+futureOption = 3
+log.disablePrintColor = true
+
+[frpmgr]
+name = "test"
+
+[[proxies]]
+name = "web"
+type = "http"
+localPort = 80
+customDomains = ["example.com"]
+transport.futureOption = "x"
+
+[[proxies]]
+name = "games_0"
+type = "tcp"
+localPort = 7000
+remotePort = 8000
+futureOption = 1
+frpmgr.range = { local = "7000-7001", remote = "8000-8001" }
+
+[[proxies]]
+name = "games_1"
+type = "tcp"
+localPort = 7001
+remotePort = 8001
+futureOption = 2
+
+[[visitors]]
+name = "db"
+type = "stcp"
+serverName = "db"
+bindPort = 3306
+futureOption = [1, 2]
+`
+	conf, err := UnmarshalClientConf([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedExtras := []map[string]any{
+		{"futureOption": int64(3), "log": map[string]any{"disablePrintColor": true}},
+		{"transport": map[string]any{"futureOption": "x"}},
+		{"futureOption": int64(1)},
+		{"futureOption": []any{int64(1), int64(2)}},
+	}
+	extras := []map[string]any{conf.Extras}
+	for _, proxy := range conf.Proxies {
+		extras = append(extras, proxy.Extras)
+	}
+	if !reflect.DeepEqual(extras, expectedExtras) {
+		t.Errorf("Expected: %v, got: %v", expectedExtras, extras)
+	}
+	// Extras are kept after saving
+	for _, format := range []string{"toml", "yaml", "json"} {
+		t.Run(format, func(t *testing.T) {
+			conf.Format = format
+			path := filepath.Join(t.TempDir(), "test."+format)
+			if err = conf.Save(path); err != nil {
+				t.Fatal(err)
+			}
+			output, err := UnmarshalClientConf(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(output.Extras, conf.Extras) {
+				t.Errorf("Expected: %v, got: %v", conf.Extras, output.Extras)
+			}
+			for i, proxy := range output.Proxies {
+				if !reflect.DeepEqual(proxy.Extras, conf.Proxies[i].Extras) {
+					t.Errorf("Expected: %v, got: %v", conf.Proxies[i].Extras, proxy.Extras)
+				}
+			}
+		})
+	}
+	expectedIssues := []ConversionIssue{
+		{"games_1", "futureOption", `differs from the first port of range proxy "games"`},
+	}
+	if issues := conf.ConversionReport(); !reflect.DeepEqual(issues, expectedIssues) {
+		t.Errorf("Expected: %v, got: %v", expectedIssues, issues)
+	}
+	// Extras can't be saved in the legacy format
+	conf.LegacyFormat = true
+	expectedIssues = append(expectedIssues, []ConversionIssue{
+		{"", "futureOption", "not supported by the legacy format"},
+		{"", "log.disablePrintColor", "not supported by the legacy format"},
+		{"web", "transport.futureOption", "not supported by the legacy format"},
+		{"games", "futureOption", "not supported by the legacy format"},
+		{"db", "futureOption", "not supported by the legacy format"},
+	}...)
+	if issues := conf.ConversionReport(); !reflect.DeepEqual(issues, expectedIssues) {
+		t.Errorf("Expected: %v, got: %v", expectedIssues, issues)
+	}
+}
+
+func TestCopyExtras(t *testing.T) {
+	conf := NewDefaultClientConfig()
+	conf.Extras = map[string]any{"log": map[string]any{"disablePrintColor": true}}
+	conf.Proxies = []*Proxy{{BaseProxyConf: BaseProxyConf{Name: "ssh", Type: "tcp",
+		Extras: map[string]any{"futureOption": []any{int64(1)}}}}}
+	for i, c := range []*ClientConfig{conf.Copy(true), conf.Clone()} {
+		c.Extras["log"].(map[string]any)["disablePrintColor"] = false
+		c.Proxies[0].Extras["futureOption"].([]any)[0] = int64(2)
+		if expected := map[string]any{"log": map[string]any{"disablePrintColor": true}}; !reflect.DeepEqual(conf.Extras, expected) {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, expected, conf.Extras)
+		}
+		if expected := map[string]any{"futureOption": []any{int64(1)}}; !reflect.DeepEqual(conf.Proxies[0].Extras, expected) {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, expected, conf.Proxies[0].Extras)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+
+	frputil "github.com/fatedier/frp/pkg/util/util"
+
+	"github.com/koho/frpmgr/pkg/consts"
+	"github.com/koho/frpmgr/pkg/util"
+)
+
+// Severity is the level of a diagnostic.
+type Severity int
+
+const (
+	// SeverityWarning indicates a problem that doesn't prevent the config from running,
+	// but it probably doesn't work as expected.
+	SeverityWarning Severity = iota
+	// SeverityError indicates a problem that makes the config or a proxy fail.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Diagnostic is a problem found by Lint.
+type Diagnostic struct {
+	// Proxy is the name of the proxy, or empty for the common section.
+	Proxy string `json:"proxy,omitempty"`
+	// Field is the Go field name of the problematic value.
+	Field    string   `json:"field"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	if d.Proxy == "" {
+		return fmt.Sprintf("%s: %s: %s", d.Severity, d.Field, d.Message)
+	}
+	return fmt.Sprintf("%s: [%s] %s: %s", d.Severity, d.Proxy, d.Field, d.Message)
+}
+
+// Lint checks the config for problems that are not reported by frp validation.
+// All problems are returned at once, ordered by their position in the config.
+func Lint(conf *ClientConfig) []Diagnostic {
+	var diags []Diagnostic
+	// Common TLS files
+	for _, f := range []struct{ field, path string }{
+		{"TLSCertFile", conf.TLSCertFile},
+		{"TLSKeyFile", conf.TLSKeyFile},
+		{"TLSTrustedCaFile", conf.TLSTrustedCaFile},
+		{"OIDCTrustedCaFile", conf.OIDCTrustedCaFile},
+		{"AdminTLS.CertFile", conf.AdminTLS.CertFile},
+		{"AdminTLS.KeyFile", conf.AdminTLS.KeyFile},
+		{"AdminTLS.TrustedCaFile", conf.AdminTLS.TrustedCaFile},
+	} {
+		if d, ok := lintFile("", f.field, f.path); !ok {
+			diags = append(diags, d)
+		}
+	}
+	ports := make(map[string][]usedPort)
+	for _, proxy := range conf.Proxies {
+		if proxy.IsVisitor() {
+			diags = append(diags, lintVisitor(conf, proxy)...)
+			continue
+		}
+		diags = append(diags, lintPluginParams(proxy)...)
+		if proxy.Plugin != "" {
+			for _, f := range []struct{ field, path string }{
+				{"PluginCrtPath", proxy.PluginCrtPath},
+				{"PluginKeyPath", proxy.PluginKeyPath},
+			} {
+				if d, ok := lintFile(proxy.Name, f.field, f.path); !ok {
+					diags = append(diags, d)
+				}
+			}
+		}
+		if !proxy.Disabled {
+			diags = append(diags, lintRemotePort(ports, proxy)...)
+		}
+	}
+	return diags
+}
+
+// lintFile checks that the file exists if the path is set.
+func lintFile(proxy, field, path string) (Diagnostic, bool) {
+	if path == "" {
+		return Diagnostic{}, true
+	}
+	if _, err := os.Stat(path); err != nil {
+		return Diagnostic{proxy, field, SeverityError, fmt.Sprintf("file %q is not accessible: %v", path, err)}, false
+	}
+	return Diagnostic{}, true
+}
+
+// lintPluginParams reports plugin parameters that are not supported by the plugin.
+// They are silently dropped when the config is completed.
+func lintPluginParams(proxy *Proxy) []Diagnostic {
+	var diags []Diagnostic
+	params := reflect.ValueOf(proxy.PluginParams)
+	kept := reflect.New(params.Type()).Elem()
+	if proxy.Plugin != "" {
+		pruned, err := util.PruneByTag(proxy.PluginParams, "true", proxy.Plugin)
+		if err != nil {
+			return nil
+		}
+		kept = reflect.ValueOf(pruned)
+	}
+	for i := 0; i < params.NumField(); i++ {
+		if !params.Field(i).IsZero() && kept.Field(i).IsZero() {
+			field := params.Type().Field(i).Name
+			msg := fmt.Sprintf("parameter is not used by plugin %q and will be dropped", proxy.Plugin)
+			if proxy.Plugin == "" {
+				msg = "parameter is set without a plugin and will be dropped"
+			}
+			diags = append(diags, Diagnostic{proxy.Name, field, SeverityWarning, msg})
+		}
+	}
+	return diags
+}
+
+// lintVisitor reports visitors whose server proxy is not found in the config.
+// Visitors of other users' proxies are not checked.
+func lintVisitor(conf *ClientConfig, visitor *Proxy) []Diagnostic {
+	if visitor.ServerUser != "" && visitor.ServerUser != conf.User {
+		return nil
+	}
+	if visitor.ServerName == "" {
+		return []Diagnostic{{visitor.Name, "ServerName", SeverityError, "server name is required"}}
+	}
+	if slices.ContainsFunc(conf.Proxies, func(p *Proxy) bool {
+		return !p.IsVisitor() && p.Type == visitor.Type && p.Name == visitor.ServerName
+	}) {
+		return nil
+	}
+	return []Diagnostic{{visitor.Name, "ServerName", SeverityWarning,
+		fmt.Sprintf("no %s proxy named %q in this config", visitor.Type, visitor.ServerName)}}
+}
+
+// usedPort is a remote port used by a proxy.
+type usedPort struct {
+	proxy string
+	ports []int64
+	// ranged is true if the proxy uses a port range.
+	ranged bool
+}
+
+// lintRemotePort reports remote ports that are used by a previous proxy of the same protocol.
+func lintRemotePort(used map[string][]usedPort, proxy *Proxy) []Diagnostic {
+	if (proxy.Type != consts.ProxyTypeTCP && proxy.Type != consts.ProxyTypeUDP) || proxy.RemotePort == "" {
+		return nil
+	}
+	ports, err := frputil.ParseRangeNumbers(proxy.RemotePort)
+	if err != nil {
+		return []Diagnostic{{proxy.Name, "RemotePort", SeverityError, err.Error()}}
+	}
+	// Port 0 means a random port is assigned by the server.
+	ports = slices.DeleteFunc(ports, func(port int64) bool { return port == 0 })
+	current := usedPort{proxy: proxy.Name, ports: ports, ranged: proxy.IsRange()}
+	var diags []Diagnostic
+	for _, prev := range used[proxy.Type] {
+		var overlap []int64
+		for _, port := range ports {
+			if slices.Contains(prev.ports, port) {
+				overlap = append(overlap, port)
+			}
+		}
+		if len(overlap) == 0 {
+			continue
+		}
+		var msg string
+		if prev.ranged || current.ranged {
+			msg = fmt.Sprintf("port range overlaps with proxy %q on %s", prev.proxy, formatPorts(overlap))
+		} else {
+			msg = fmt.Sprintf("remote port %d is already used by proxy %q", overlap[0], prev.proxy)
+		}
+		diags = append(diags, Diagnostic{proxy.Name, "RemotePort", SeverityError, msg})
+	}
+	used[proxy.Type] = append(used[proxy.Type], current)
+	return diags
+}
+
+// formatPorts joins a sorted list of ports into ranges, e.g. "6000-6002,6005".
+func formatPorts(ports []int64) string {
+	slices.Sort(ports)
+	var s string
+	for i := 0; i < len(ports); {
+		j := i
+		for j+1 < len(ports) && ports[j+1] == ports[j]+1 {
+			j++
+		}
+		if s != "" {
+			s += ","
+		}
+		if i == j {
+			s += fmt.Sprint(ports[i])
+		} else {
+			s += fmt.Sprintf("%d-%d", ports[i], ports[j])
+		}
+		i = j + 1
+	}
+	return s
+}
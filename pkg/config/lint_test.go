@@ -0,0 +1,107 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	certFile := filepath.Join(t.TempDir(), "client.crt")
+	if err := os.WriteFile(certFile, nil, 0666); err != nil {
+		t.Fatal(err)
+	}
+	missingFile := filepath.Join(t.TempDir(), "missing.key")
+	tests := []struct {
+		name     string
+		common   ClientCommon
+		proxies  []*Proxy
+		expected []Diagnostic
+	}{
+		{
+			name:   "clean",
+			common: ClientCommon{TLSCertFile: certFile},
+			proxies: []*Proxy{
+				{BaseProxyConf: BaseProxyConf{Name: "ssh", Type: "tcp"}, RemotePort: "6000"},
+				{BaseProxyConf: BaseProxyConf{Name: "dns", Type: "udp"}, RemotePort: "6000"},
+				{BaseProxyConf: BaseProxyConf{Name: "any1", Type: "tcp"}, RemotePort: "0"},
+				{BaseProxyConf: BaseProxyConf{Name: "any2", Type: "tcp"}, RemotePort: "0"},
+				{BaseProxyConf: BaseProxyConf{Name: "off", Type: "tcp", Disabled: true}, RemotePort: "6000"},
+			},
+		},
+		{
+			name: "duplicate ports",
+			proxies: []*Proxy{
+				{BaseProxyConf: BaseProxyConf{Name: "a", Type: "tcp"}, RemotePort: "6000"},
+				{BaseProxyConf: BaseProxyConf{Name: "b", Type: "tcp"}, RemotePort: "6000"},
+				{BaseProxyConf: BaseProxyConf{Name: "c", Type: "tcp", LocalPort: "22-25"}, RemotePort: "5998-6001"},
+				{BaseProxyConf: BaseProxyConf{Name: "d", Type: "tcp"}, RemotePort: "abc"},
+			},
+			expected: []Diagnostic{
+				{"b", "RemotePort", SeverityError, `remote port 6000 is already used by proxy "a"`},
+				{"c", "RemotePort", SeverityError, `port range overlaps with proxy "a" on 6000`},
+				{"c", "RemotePort", SeverityError, `port range overlaps with proxy "b" on 6000`},
+				{"d", "RemotePort", SeverityError, `range number is invalid, strconv.ParseInt: parsing "abc": invalid syntax`},
+			},
+		},
+		{
+			name: "overlapping ranges",
+			proxies: []*Proxy{
+				{BaseProxyConf: BaseProxyConf{Name: "a", Type: "udp", LocalPort: "1-5"}, RemotePort: "7000-7004"},
+				{BaseProxyConf: BaseProxyConf{Name: "b", Type: "udp", LocalPort: "1-5"}, RemotePort: "7002-7004,7008"},
+			},
+			expected: []Diagnostic{
+				{"b", "RemotePort", SeverityError, `port range overlaps with proxy "a" on 7002-7004`},
+			},
+		},
+		{
+			name:   "visitors",
+			common: ClientCommon{User: "me"},
+			proxies: []*Proxy{
+				{BaseProxyConf: BaseProxyConf{Name: "secret", Type: "stcp"}},
+				{BaseProxyConf: BaseProxyConf{Name: "v1", Type: "stcp"}, Role: "visitor", ServerName: "secret"},
+				{BaseProxyConf: BaseProxyConf{Name: "v2", Type: "xtcp"}, Role: "visitor", ServerName: "secret"},
+				{BaseProxyConf: BaseProxyConf{Name: "v3", Type: "stcp"}, Role: "visitor", ServerName: "other", ServerUser: "you"},
+				{BaseProxyConf: BaseProxyConf{Name: "v4", Type: "sudp"}, Role: "visitor"},
+			},
+			expected: []Diagnostic{
+				{"v2", "ServerName", SeverityWarning, `no xtcp proxy named "secret" in this config`},
+				{"v4", "ServerName", SeverityError, "server name is required"},
+			},
+		},
+		{
+			name:   "plugin params and files",
+			common: ClientCommon{TLSTrustedCaFile: missingFile},
+			proxies: []*Proxy{
+				{BaseProxyConf: BaseProxyConf{Name: "socks", Type: "tcp", Plugin: "socks5", PluginParams: PluginParams{
+					PluginUser: "user", PluginLocalAddr: "127.0.0.1:80",
+				}}},
+				{BaseProxyConf: BaseProxyConf{Name: "https", Type: "https", Plugin: "https2http", PluginParams: PluginParams{
+					PluginLocalAddr: "127.0.0.1:80", PluginCrtPath: certFile, PluginKeyPath: missingFile,
+				}}},
+				{BaseProxyConf: BaseProxyConf{Name: "plain", Type: "tcp", PluginParams: PluginParams{PluginUser: "user"}}},
+			},
+			expected: []Diagnostic{
+				{"", "TLSTrustedCaFile", SeverityError, ""},
+				{"socks", "PluginLocalAddr", SeverityWarning, `parameter is not used by plugin "socks5" and will be dropped`},
+				{"https", "PluginKeyPath", SeverityError, ""},
+				{"plain", "PluginUser", SeverityWarning, "parameter is set without a plugin and will be dropped"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diags := Lint(&ClientConfig{ClientCommon: test.common, Proxies: test.proxies})
+			// Messages of file errors depend on the platform.
+			for i := range diags {
+				if i < len(test.expected) && test.expected[i].Message == "" {
+					diags[i].Message = ""
+				}
+			}
+			if !reflect.DeepEqual(diags, test.expected) {
+				t.Errorf("Expected: %v, got: %v", test.expected, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LocationFile is the file next to the executable which selects the data root.
+// It can't be stored in the data root, because it's needed to find the data root.
+const LocationFile = "location.json"
+
+// DataDirName is the name of the data root in the system and user data directories.
+const DataDirName = "FRP Manager"
+
+// DataLocation is the place of the data root, which contains the configs, logs,
+// store files and the application configuration.
+type DataLocation string
+
+const (
+	// LocationPortable places the data root next to the executable.
+	// It's the default, so the data of existing installations is kept.
+	LocationPortable DataLocation = "portable"
+	// LocationMachine places the data root in the program data directory shared by all users.
+	LocationMachine DataLocation = "machine"
+	// LocationUser places the data root in the local application data directory of the current user.
+	LocationUser DataLocation = "user"
+)
+
+// Locations lists all data locations.
+var Locations = []DataLocation{LocationPortable, LocationMachine, LocationUser}
+
+type locationFile struct {
+	Location DataLocation `json:"location"`
+}
+
+// LoadLocation returns the data location selected in the given executable directory.
+// It returns LocationPortable if the location is not selected.
+func LoadLocation(exeDir string) (DataLocation, error) {
+	b, err := os.ReadFile(filepath.Join(exeDir, LocationFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return LocationPortable, nil
+		}
+		return "", err
+	}
+	var f locationFile
+	if err = json.Unmarshal(b, &f); err != nil {
+		return "", err
+	}
+	if f.Location == "" {
+		return LocationPortable, nil
+	}
+	if _, err = f.Location.Root(exeDir); err != nil {
+		return "", err
+	}
+	return f.Location, nil
+}
+
+// SaveLocation selects the data location in the given executable directory.
+// The location file is removed when the portable location is selected.
+func SaveLocation(exeDir string, loc DataLocation) error {
+	if _, err := loc.Root(exeDir); err != nil {
+		return err
+	}
+	name := filepath.Join(exeDir, LocationFile)
+	if loc == LocationPortable {
+		if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	b, err := json.MarshalIndent(&locationFile{Location: loc}, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, b, 0666)
+}
+
+// Root returns the absolute data root of this location.
+func (loc DataLocation) Root(exeDir string) (string, error) {
+	var dir, env string
+	switch loc {
+	case LocationPortable:
+		return filepath.Abs(exeDir)
+	case LocationMachine:
+		env = "ProgramData"
+	case LocationUser:
+		env = "LOCALAPPDATA"
+	default:
+		return "", fmt.Errorf("unknown data location %q", loc)
+	}
+	if dir = os.Getenv(env); dir == "" {
+		return "", fmt.Errorf("%%%s%% is not defined", env)
+	}
+	return filepath.Join(dir, DataDirName), nil
+}
+
+// DataRoot returns the data root selected next to the running executable.
+func DataRoot() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	exeDir := filepath.Dir(path)
+	loc, err := LoadLocation(exeDir)
+	if err != nil {
+		return "", err
+	}
+	return loc.Root(exeDir)
+}
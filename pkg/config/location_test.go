@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocation(t *testing.T) {
+	exeDir := t.TempDir()
+	t.Setenv("ProgramData", filepath.Join(exeDir, "machine"))
+	t.Setenv("LOCALAPPDATA", filepath.Join(exeDir, "user"))
+	tests := []struct {
+		loc      DataLocation
+		expected string
+		file     bool
+	}{
+		{LocationMachine, filepath.Join(exeDir, "machine", DataDirName), true},
+		{LocationUser, filepath.Join(exeDir, "user", DataDirName), true},
+		{LocationPortable, exeDir, false},
+	}
+	for i, test := range tests {
+		if err := SaveLocation(exeDir, test.loc); err != nil {
+			t.Fatal(err)
+		}
+		loc, err := LoadLocation(exeDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if loc != test.loc {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.loc, loc)
+		}
+		if root, err := loc.Root(exeDir); err != nil || root != test.expected {
+			t.Errorf("Test %d: Expected: %v, got: %v, %v", i, test.expected, root, err)
+		}
+		if _, err = os.Stat(filepath.Join(exeDir, LocationFile)); (err == nil) != test.file {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.file, err)
+		}
+	}
+	if err := SaveLocation(exeDir, "cloud"); err == nil {
+		t.Errorf("Expected: %v, got: %v", "unknown location", err)
+	}
+	t.Setenv("LOCALAPPDATA", "")
+	if _, err := LocationUser.Root(exeDir); err == nil {
+		t.Errorf("Expected: %v, got: %v", "undefined directory", err)
+	}
+}
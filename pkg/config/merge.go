@@ -0,0 +1,136 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Merge applies the local changes made since the base config to the remote config, and
+// returns the merged config with the conflicts. The base config is the remote config that
+// the local config was derived from, so the configs are compared in the same way as DiffConfigs.
+//
+// Local changes of fields and proxies are kept unless the remote config changes the same
+// field or proxy differently. In that case the remote value wins, and the field or proxy
+// is reported as a conflict, e.g. "ServerAddress" or "proxy ssh".
+func Merge(base, local, remote *ClientConfig) (*ClientConfig, []string) {
+	merged := remote.Clone()
+	localDiff, remoteDiff := DiffConfigs(base, local), DiffConfigs(base, remote)
+	var conflicts []string
+
+	// Common fields
+	for _, c := range localDiff.Common {
+		if slices.ContainsFunc(remoteDiff.Common, func(rc Change) bool { return rc.Field == c.Field }) {
+			if !equalValue(fieldByPath(reflect.ValueOf(&local.ClientCommon).Elem(), c.Field),
+				fieldByPath(reflect.ValueOf(&remote.ClientCommon).Elem(), c.Field)) {
+				conflicts = append(conflicts, c.Field)
+			}
+			continue
+		}
+		copyField(reflect.ValueOf(&merged.ClientCommon).Elem(), reflect.ValueOf(&local.ClientCommon).Elem(), c.Field)
+	}
+
+	// Proxies changed locally
+	for _, ld := range localDiff.Changed {
+		lp := findProxy(local.Proxies, newNameOf(ld))
+		if slices.Contains(remoteDiff.Removed, ld.Name) {
+			conflicts = append(conflicts, "proxy "+ld.Name)
+			continue
+		}
+		i := slices.IndexFunc(remoteDiff.Changed, func(rd ProxyDiff) bool { return rd.Name == ld.Name })
+		if i < 0 {
+			j := slices.IndexFunc(merged.Proxies, func(p *Proxy) bool { return p.Name == ld.Name })
+			if lp == nil || j < 0 || (ld.NewName != "" && findProxy(merged.Proxies, ld.NewName) != nil) {
+				conflicts = append(conflicts, "proxy "+ld.Name)
+				continue
+			}
+			merged.Proxies[j] = copyProxy(lp)
+			continue
+		}
+		// Both sides changed the proxy, so the fields are merged one by one
+		rd := remoteDiff.Changed[i]
+		mp := findProxy(merged.Proxies, newNameOf(rd))
+		if lp == nil || mp == nil {
+			conflicts = append(conflicts, "proxy "+ld.Name)
+			continue
+		}
+		conflict := false
+		for _, c := range ld.Changes {
+			if slices.ContainsFunc(rd.Changes, func(rc Change) bool { return rc.Field == c.Field }) {
+				conflict = conflict || !equalValue(fieldByPath(reflect.ValueOf(lp).Elem(), c.Field), fieldByPath(reflect.ValueOf(mp).Elem(), c.Field))
+				continue
+			}
+			copyField(reflect.ValueOf(mp).Elem(), reflect.ValueOf(lp).Elem(), c.Field)
+		}
+		if ld.NewName != "" {
+			if rd.NewName != "" || findProxy(merged.Proxies, ld.NewName) != nil {
+				conflict = conflict || rd.NewName != ld.NewName
+			} else {
+				mp.Name = ld.NewName
+			}
+		}
+		if conflict {
+			conflicts = append(conflicts, "proxy "+ld.Name)
+		}
+	}
+
+	// Proxies removed locally
+	for _, name := range localDiff.Removed {
+		if slices.ContainsFunc(remoteDiff.Changed, func(rd ProxyDiff) bool { return rd.Name == name }) {
+			conflicts = append(conflicts, "proxy "+name)
+			continue
+		}
+		merged.Proxies = slices.DeleteFunc(merged.Proxies, func(p *Proxy) bool { return p.Name == name })
+	}
+
+	// Proxies added locally
+	for _, name := range localDiff.Added {
+		lp := findProxy(local.Proxies, name)
+		if mp := findProxy(merged.Proxies, name); mp != nil {
+			if len(diffProxy(mp, lp).Changes) > 0 {
+				conflicts = append(conflicts, "proxy "+name)
+			}
+			continue
+		}
+		merged.AddProxy(copyProxy(lp))
+	}
+	return merged, conflicts
+}
+
+func newNameOf(pd ProxyDiff) string {
+	if pd.NewName != "" {
+		return pd.NewName
+	}
+	return pd.Name
+}
+
+func findProxy(proxies []*Proxy, name string) *Proxy {
+	if i := slices.IndexFunc(proxies, func(p *Proxy) bool { return p.Name == name }); i >= 0 {
+		return proxies[i]
+	}
+	return nil
+}
+
+func copyProxy(p *Proxy) *Proxy {
+	newProxy := *p
+	return &newProxy
+}
+
+// fieldByPath returns the field of a struct by a field path of Change.
+// Fields of embedded structs are promoted, so they are found by their own names.
+func fieldByPath(v reflect.Value, path string) reflect.Value {
+	for _, name := range strings.Split(path, ".") {
+		if v = v.FieldByName(name); !v.IsValid() {
+			break
+		}
+	}
+	return v
+}
+
+// copyField sets the field of dst to the value of the same field of src.
+func copyField(dst, src reflect.Value, path string) {
+	d, s := fieldByPath(dst, path), fieldByPath(src, path)
+	if d.IsValid() && s.IsValid() && d.CanSet() {
+		d.Set(s)
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/samber/lo"
+)
+
+func TestMerge(t *testing.T) {
+	base := NewDefaultClientConfig()
+	base.ServerAddress = "example.com"
+	base.User = "alice"
+	base.Proxies = []*Proxy{
+		{BaseProxyConf: BaseProxyConf{Name: "ssh", Type: "tcp", LocalPort: "22"}, RemotePort: "6000"},
+		{BaseProxyConf: BaseProxyConf{Name: "web", Type: "http", LocalPort: "80"}, SubDomain: "web"},
+		{BaseProxyConf: BaseProxyConf{Name: "dns", Type: "udp", LocalPort: "53"}, RemotePort: "6053"},
+		{BaseProxyConf: BaseProxyConf{Name: "rdp", Type: "tcp", LocalPort: "3389"}, RemotePort: "6389"},
+	}
+	local := base.Copy(true)
+	local.User = "bob"
+	local.ServerPort = 7001
+	local.Proxies[0].LocalIP = "10.0.0.2"
+	local.Proxies[0].RemotePort = "6022"
+	local.Proxies[1].Name = "www"
+	local.DeleteProxy(2)
+	local.Proxies[2].Disabled = true
+	local.AddProxy(&Proxy{BaseProxyConf: BaseProxyConf{Name: "vnc", Type: "tcp", LocalPort: "5900"}, RemotePort: "6900"})
+
+	remote := base.Copy(true)
+	remote.ServerAddress = "frp.example.com"
+	remote.ServerPort = 7002
+	remote.Proxies[0].RemotePort = "6122"
+	remote.Proxies[1].SubDomain = "site"
+	remote.DeleteProxy(3)
+	remote.AddProxy(&Proxy{BaseProxyConf: BaseProxyConf{Name: "ftp", Type: "tcp", LocalPort: "21"}, RemotePort: "6021"})
+
+	merged, conflicts := Merge(base, local, remote)
+	if expected := []string{"ServerPort", "proxy ssh", "proxy rdp"}; !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("Expected: %v, got: %v", expected, conflicts)
+	}
+	tests := []struct {
+		name     string
+		expected any
+		output   any
+	}{
+		{"server address", "frp.example.com", merged.ServerAddress},
+		{"server port", 7002, merged.ServerPort},
+		{"user", "bob", merged.User},
+		{"proxies", []string{"ssh", "www", "ftp", "vnc"}, lo.Map(merged.Proxies, func(p *Proxy, i int) string { return p.Name })},
+		{"ssh local ip", "10.0.0.2", merged.Proxies[0].LocalIP},
+		{"ssh remote port", "6122", merged.Proxies[0].RemotePort},
+		{"www subdomain", "site", merged.Proxies[1].SubDomain},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.output, test.expected) {
+			t.Errorf("%s: Expected: %v, got: %v", test.name, test.expected, test.output)
+		}
+	}
+	// The inputs are not modified
+	if remote.User != "alice" || remote.Proxies[0].LocalIP != "" {
+		t.Errorf("Expected: %v, got: %v", "unmodified remote config", remote)
+	}
+
+	// Without local changes, the result is the remote config
+	merged, conflicts = Merge(base, base, remote)
+	if diff := DiffConfigs(remote, merged); !diff.Empty() || len(conflicts) > 0 {
+		t.Errorf("Expected: %v, got: %v, %v", "no changes", diff, conflicts)
+	}
+}
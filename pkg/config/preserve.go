@@ -0,0 +1,911 @@
+package config
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/fatedier/frp/pkg/config"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+	"github.com/samber/lo"
+	"gopkg.in/ini.v1"
+
+	"github.com/koho/frpmgr/pkg/consts"
+)
+
+// SavePreserve writes the config to the given path by editing the existing document in place.
+// Comments, the order of keys and sections, and keys that are not modeled by the config
+// are kept. It's the same as Save if the file doesn't exist, can't be loaded,
+// or is in a different format. Only INI and TOML documents are edited in place.
+//
+// A key of the existing document is considered modeled if it's written back when the
+// document is loaded and saved again. Modeled keys are updated or removed according
+// to the config, while other keys are left untouched. Proxies are matched with the document
+// by name, or by position if they are renamed.
+func (conf *ClientConfig) SavePreserve(path string) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return conf.Save(path)
+		}
+		return err
+	}
+	if config.DetectLegacyINIFormat(src) != conf.LegacyFormat {
+		return conf.Save(path)
+	}
+	// Only TOML documents are edited in place
+	if !conf.LegacyFormat && (conf.Format != "" && conf.Format != consts.FormatTOML || detectFormat(path, src) != consts.FormatTOML) {
+		return conf.Save(path)
+	}
+	old, err := UnmarshalClientConf(src)
+	if err != nil {
+		return conf.Save(path)
+	}
+	var b []byte
+	if conf.LegacyFormat {
+		b, err = conf.mergeINI(src, old)
+	} else {
+		b, err = conf.mergeTOML(src, old)
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0666)
+}
+
+// mergeINI updates the INI document src with the values of the config.
+// The old config is the config loaded from src.
+func (conf *ClientConfig) mergeINI(src []byte, old *ClientConfig) ([]byte, error) {
+	doc, err := ini.LoadSources(ini.LoadOptions{
+		IgnoreInlineComment: true,
+		AllowBooleanKeys:    true,
+	}, src)
+	if err != nil {
+		return nil, err
+	}
+	known, err := old.iniFile()
+	if err != nil {
+		return nil, err
+	}
+	gen, err := conf.iniFile()
+	if err != nil {
+		return nil, err
+	}
+	// Proxies are matched with the sections of the document, so renamed proxies keep their comments
+	proxySections := func(f *ini.File, filter func(name string) bool) []string {
+		return lo.Filter(f.SectionStrings(), func(name string, i int) bool {
+			return name != ini.DefaultSection && name != "common" && filter(name)
+		})
+	}
+	origNames := proxySections(doc, known.HasSection)
+	genNames := proxySections(gen, func(string) bool { return true })
+	renamed := make(map[string]string)
+	for j, i := range matchNames(origNames, genNames) {
+		if i >= 0 {
+			renamed[genNames[j]] = origNames[i]
+		}
+	}
+	out := ini.Empty()
+	// Keys outside any section are never modeled
+	if err = mergeSection(out.Section(ini.DefaultSection), doc.Section(ini.DefaultSection), nil, nil); err != nil {
+		return nil, err
+	}
+	for _, section := range gen.Sections() {
+		if section.Name() == ini.DefaultSection {
+			continue
+		}
+		dst, err := out.NewSection(section.Name())
+		if err != nil {
+			return nil, err
+		}
+		name := section.Name()
+		if n, ok := renamed[name]; ok {
+			name = n
+		}
+		orig, _ := doc.GetSection(name)
+		prev, _ := known.GetSection(name)
+		if err = mergeSection(dst, orig, section, prev); err != nil {
+			return nil, err
+		}
+	}
+	// Sections that are neither generated nor modeled are kept at the end
+	for _, section := range doc.Sections() {
+		if section.Name() == ini.DefaultSection || gen.HasSection(section.Name()) || known.HasSection(section.Name()) {
+			continue
+		}
+		dst, err := out.NewSection(section.Name())
+		if err != nil {
+			return nil, err
+		}
+		if err = mergeSection(dst, section, nil, nil); err != nil {
+			return nil, err
+		}
+	}
+	var buf bytes.Buffer
+	if _, err = out.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mergeSection fills dst with the keys of the original section in their original order.
+// Keys of the generated section replace the original values, while keys of the known
+// section that are missing in the generated section are removed. Any section may be nil.
+func mergeSection(dst, orig, gen, known *ini.Section) error {
+	if orig != nil {
+		dst.Comment = orig.Comment
+		for _, key := range orig.Keys() {
+			value := key.Value()
+			if gen != nil && gen.HasKey(key.Name()) {
+				value = gen.Key(key.Name()).Value()
+			} else if known != nil && known.HasKey(key.Name()) {
+				continue
+			}
+			k, err := dst.NewKey(key.Name(), value)
+			if err != nil {
+				return err
+			}
+			k.Comment = key.Comment
+		}
+	}
+	if gen != nil {
+		for _, key := range gen.Keys() {
+			if dst.HasKey(key.Name()) {
+				continue
+			}
+			if _, err := dst.NewKey(key.Name(), key.Value()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// tomlExpr is a top-level expression of a TOML document.
+type tomlExpr struct {
+	kind unstable.Kind
+	key  []string
+	// start and end are the line range of the expression. The end is exclusive.
+	start, end int
+	// value is the offset of the value in the first line of a key-value.
+	value int
+	// comment is the trailing comment of the expression.
+	comment string
+}
+
+// tomlSection is a table of a TOML document. The root table has no header.
+type tomlSection struct {
+	header *tomlExpr
+	// start and end are the line range of the section, including the comments
+	// right above the header.
+	start, end int
+	kvs        []*tomlExpr
+}
+
+func (s *tomlSection) key() []string {
+	if s.header == nil {
+		return nil
+	}
+	return s.header.key
+}
+
+func (s *tomlSection) isArray() bool {
+	return s.header != nil && s.header.kind == unstable.ArrayTable
+}
+
+// tomlGroup is a sequence of sections that are written together.
+// An element of an array of tables is a group of the array table and its sub-tables.
+type tomlGroup struct {
+	sections []*tomlSection
+	// array is the key of the array if the group is an element.
+	array string
+}
+
+// tomlDoc is a TOML document that is edited line by line.
+type tomlDoc struct {
+	lines  []string
+	eol    string
+	groups []*tomlGroup
+	// drop marks lines to be removed.
+	drop []bool
+	// replace holds the new content of lines.
+	replace map[int]string
+	// insert holds the lines to be inserted before a line.
+	insert map[int][]string
+}
+
+func parseTOMLDoc(src []byte) (*tomlDoc, error) {
+	doc := &tomlDoc{
+		lines:   strings.SplitAfter(string(src), "\n"),
+		eol:     "\n",
+		replace: make(map[int]string),
+		insert:  make(map[int][]string),
+	}
+	if n := len(doc.lines); doc.lines[n-1] == "" {
+		doc.lines = doc.lines[:n-1]
+	}
+	if bytes.Contains(src, []byte("\r\n")) {
+		doc.eol = "\r\n"
+	}
+	doc.drop = make([]bool, len(doc.lines))
+	var exprs []*tomlExpr
+	p := unstable.Parser{KeepComments: true}
+	p.Reset(src)
+	for p.NextExpression() {
+		node := p.Expression()
+		expr := &tomlExpr{kind: node.Kind}
+		first, last := node, node
+		if node.Kind != unstable.Comment {
+			first = nil
+			for it := node.Key(); it.Next(); {
+				if first == nil {
+					first = it.Node()
+				}
+				last = it.Node()
+				expr.key = append(expr.key, string(last.Data))
+			}
+			if c := node.Next(); c != nil && c.Kind == unstable.Comment {
+				expr.comment = string(p.Raw(c.Raw))
+			}
+		}
+		pos := p.Shape(first.Raw).Start
+		expr.start = pos.Line - 1
+		if node.Kind == unstable.KeyValue {
+			end := p.Shape(last.Raw).End
+			lineOffset := end.Offset - end.Column + 1
+			rest := src[end.Offset:]
+			i := bytes.IndexByte(rest, '=') + 1
+			for i < len(rest) && (rest[i] == ' ' || rest[i] == '\t') {
+				i++
+			}
+			expr.value = end.Offset + i - lineOffset
+		}
+		exprs = append(exprs, expr)
+	}
+	if err := p.Error(); err != nil {
+		return nil, err
+	}
+	for i, expr := range exprs {
+		expr.end = len(doc.lines)
+		if i+1 < len(exprs) {
+			expr.end = exprs[i+1].start
+		}
+		for expr.end-1 > expr.start && strings.TrimSpace(doc.lines[expr.end-1]) == "" {
+			expr.end--
+		}
+	}
+	// Split the document into sections
+	root := &tomlSection{end: len(doc.lines)}
+	sections := []*tomlSection{root}
+	for _, expr := range exprs {
+		cur := sections[len(sections)-1]
+		switch expr.kind {
+		case unstable.KeyValue:
+			cur.kvs = append(cur.kvs, expr)
+		case unstable.Table, unstable.ArrayTable:
+			start := expr.start
+			for start > cur.start && start-1 >= lastLine(cur) && doc.isComment(start-1) {
+				start--
+			}
+			cur.end = start
+			sections = append(sections, &tomlSection{header: expr, start: start, end: len(doc.lines)})
+		}
+	}
+	// Group the elements of arrays with their sub-tables
+	for _, s := range sections {
+		if len(doc.groups) > 0 {
+			g := doc.groups[len(doc.groups)-1]
+			if g.array != "" && !s.isArray() && len(s.key()) > 1 && s.key()[0] == g.array {
+				g.sections = append(g.sections, s)
+				continue
+			}
+		}
+		g := &tomlGroup{sections: []*tomlSection{s}}
+		if s.isArray() && len(s.key()) == 1 {
+			g.array = s.key()[0]
+		}
+		doc.groups = append(doc.groups, g)
+	}
+	return doc, nil
+}
+
+// lastLine returns the line after the header and key-values of a section.
+func lastLine(s *tomlSection) int {
+	if len(s.kvs) > 0 {
+		return s.kvs[len(s.kvs)-1].end
+	}
+	if s.header != nil {
+		return s.header.end
+	}
+	return s.start
+}
+
+// insertPos returns the line before which new key-values of a section are inserted.
+func (d *tomlDoc) insertPos(s *tomlSection) int {
+	if s.header != nil || len(s.kvs) > 0 {
+		return lastLine(s)
+	}
+	end := s.end
+	for end > s.start && strings.TrimSpace(d.lines[end-1]) == "" {
+		end--
+	}
+	return end
+}
+
+func (d *tomlDoc) isComment(line int) bool {
+	return strings.HasPrefix(strings.TrimSpace(d.lines[line]), "#")
+}
+
+// mergeTOML updates the TOML document src with the values of the config.
+// The old config is the config loaded from src.
+func (conf *ClientConfig) mergeTOML(src []byte, old *ClientConfig) ([]byte, error) {
+	doc, err := parseTOMLDoc(src)
+	if err != nil {
+		return nil, err
+	}
+	var orig map[string]any
+	if err = toml.Unmarshal(src, &orig); err != nil {
+		return nil, err
+	}
+	known, err := old.v1Map()
+	if err != nil {
+		return nil, err
+	}
+	gen, err := conf.v1Map()
+	if err != nil {
+		return nil, err
+	}
+	// Normalize values to the types of a decoded document
+	if known, err = normalizeTOML(known); err != nil {
+		return nil, err
+	}
+	if gen, err = normalizeTOML(gen); err != nil {
+		return nil, err
+	}
+	// Modeled arrays of tables are edited by elements
+	arrays := make(map[string]bool)
+	for _, m := range []map[string]any{gen, known} {
+		for k, v := range m {
+			if _, ok := orig[k]; ok && doc.hasArray(k) || !ok && isTableArray(v) {
+				arrays[k] = true
+			}
+		}
+	}
+	var rootGroups []*tomlGroup
+	for _, g := range doc.groups {
+		if !arrays[g.array] {
+			rootGroups = append(rootGroups, g)
+		}
+	}
+	var out tomlWriter
+	out.eol = doc.eol
+	newTables, err := doc.mergeScope(rootGroups, 0, orig, omitKeys(gen, arrays), omitKeys(known, arrays))
+	if err != nil {
+		return nil, err
+	}
+	written := make(map[string]bool)
+	for _, g := range doc.groups {
+		if !arrays[g.array] {
+			doc.writeGroup(&out, g)
+			continue
+		}
+		if !written[g.array] && newTables != nil {
+			out.block(newTables)
+			newTables = nil
+		}
+		if written[g.array] {
+			continue
+		}
+		written[g.array] = true
+		if err = doc.mergeArray(&out, g.array, orig, gen, known); err != nil {
+			return nil, err
+		}
+	}
+	if newTables != nil {
+		out.block(newTables)
+	}
+	// Arrays that are not in the document yet
+	for _, k := range sortedKeys(arrays) {
+		if written[k] {
+			continue
+		}
+		if err = doc.mergeArray(&out, k, orig, gen, known); err != nil {
+			return nil, err
+		}
+	}
+	return []byte(out.String()), nil
+}
+
+func (d *tomlDoc) hasArray(key string) bool {
+	return slices.ContainsFunc(d.groups, func(g *tomlGroup) bool { return g.array == key })
+}
+
+// mergeArray writes the elements of an array of tables. Elements are matched with the elements
+// of the document by matchNames, so the comments and formatting of renamed elements are kept.
+// Proxies are written in the order of the generated config, while visitors are kept in the
+// order of the document, because the order of visitors in the config is stored in their sort fields.
+func (d *tomlDoc) mergeArray(out *tomlWriter, key string, orig, gen, known map[string]any) error {
+	origElems, _ := orig[key].([]any)
+	knownElems, _ := known[key].([]any)
+	var groups []*tomlGroup
+	for _, g := range d.groups {
+		if g.array == key {
+			groups = append(groups, g)
+		}
+	}
+	genElems, _ := gen[key].([]any)
+	// Elements are matched in the order of the config, which is the order of the document
+	// except for visitors. The known visitors are in the order of their sort fields.
+	origNames := elemNames(origElems)
+	order := lo.Range(len(origElems))
+	if key == "visitors" {
+		knownNames := elemNames(knownElems)
+		pos := func(i int) int {
+			if k := slices.Index(knownNames, origNames[i]); k >= 0 {
+				return k
+			}
+			return len(knownNames)
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			return cmp.Compare(pos(a), pos(b))
+		})
+	}
+	match := matchNames(lo.Map(order, func(i int, _ int) string {
+		return origNames[i]
+	}), elemNames(genElems))
+	for j, k := range match {
+		if k >= 0 {
+			match[j] = order[k]
+		}
+	}
+	write := func(elem any, i int) error {
+		e, _ := elem.(map[string]any)
+		if i < 0 || i >= len(groups) {
+			b, err := toml.Marshal(map[string]any{key: []any{e}})
+			if err != nil {
+				return err
+			}
+			out.block(splitLines(string(b), d.eol))
+			return nil
+		}
+		origElem, _ := origElems[i].(map[string]any)
+		// The known element has the name in the document, which is the previous name of a renamed element
+		var prev map[string]any
+		for _, v := range knownElems {
+			if m, ok := v.(map[string]any); ok && m["name"] == origElem["name"] {
+				prev = m
+			}
+		}
+		newTables, err := d.mergeScope([]*tomlGroup{groups[i]}, 1, origElem, e, prev)
+		if err != nil {
+			return err
+		}
+		d.writeGroup(out, groups[i])
+		if newTables != nil {
+			// Strip the header of the element
+			newTables = newTables[1:]
+			for len(newTables) > 0 && strings.TrimSpace(newTables[0]) == "" {
+				newTables = newTables[1:]
+			}
+			out.block(newTables)
+		}
+		return nil
+	}
+	if key != "visitors" {
+		for j, elem := range genElems {
+			if err := write(elem, match[j]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	written := make([]bool, len(genElems))
+	for i := range origElems {
+		if j := slices.Index(match, i); j >= 0 {
+			if err := write(genElems[j], i); err != nil {
+				return err
+			}
+			written[j] = true
+		}
+	}
+	for j, elem := range genElems {
+		if !written[j] {
+			if err := write(elem, -1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// matchNames matches the generated elements of a list with the elements of the document by names.
+// It returns the index of the document element for each generated element, or -1 if there is none.
+// Elements are matched by name first. The other elements between two matched elements are then
+// matched by position if there are as many of them in both lists, such as after a rename.
+func matchNames(orig, gen []string) []int {
+	match := make([]int, len(gen))
+	used := make([]bool, len(orig))
+	for j, name := range gen {
+		match[j] = -1
+		if i := slices.Index(orig, name); name != "" && i >= 0 && !used[i] {
+			match[j], used[i] = i, true
+		}
+	}
+	for j := 0; j < len(gen); j++ {
+		if match[j] >= 0 {
+			continue
+		}
+		// The run of unmatched elements and the matched elements around it
+		end := j
+		for end < len(gen) && match[end] < 0 {
+			end++
+		}
+		prev, next := -1, len(orig)
+		if j > 0 {
+			prev = match[j-1]
+		}
+		if end < len(gen) {
+			next = match[end]
+		}
+		if next-prev-1 == end-j && !slices.Contains(used[prev+1:max(next, prev+1)], true) {
+			for k := j; k < end; k++ {
+				match[k] = prev + 1 + k - j
+				used[match[k]] = true
+			}
+		}
+		j = end
+	}
+	return match
+}
+
+// elemNames returns the names of the elements of an array of tables.
+func elemNames(elems []any) []string {
+	return lo.Map(elems, func(v any, i int) string {
+		m, _ := v.(map[string]any)
+		name, _ := m["name"].(string)
+		return name
+	})
+}
+
+// mergeScope edits the key-values of the given groups to match the generated values.
+// The prefix is the length of the section keys not part of the scope. Values missing
+// in the document are inserted into the existing sections, or returned as new tables.
+func (d *tomlDoc) mergeScope(groups []*tomlGroup, prefix int, orig, gen, known map[string]any) ([]string, error) {
+	genLeaves := flattenTOML(gen, nil, nil)
+	knownLeaves := flattenTOML(known, nil, nil)
+	placed := make(map[string]bool)
+	var sections []*tomlSection
+	for _, g := range groups {
+		sections = append(sections, g.sections...)
+	}
+	for _, s := range sections {
+		for _, kv := range s.kvs {
+			path := append(slices.Clone(s.key()[min(prefix, len(s.key())):]), kv.key...)
+			p := joinPath(path)
+			value := lookupTOML(orig, path)
+			if v, ok := genLeaves[p]; ok {
+				placed[p] = true
+				if !reflect.DeepEqual(v, value) {
+					if err := d.replaceValue(kv, v); err != nil {
+						return nil, err
+					}
+				}
+				continue
+			}
+			if _, ok := value.(map[string]any); ok {
+				// Inline tables are replaced as a whole
+				sub := lookupTOML(gen, path)
+				if sub != nil {
+					for leaf := range genLeaves {
+						if strings.HasPrefix(leaf, p+pathSep) {
+							placed[leaf] = true
+						}
+					}
+					if !reflect.DeepEqual(sub, value) {
+						if err := d.replaceValue(kv, sub); err != nil {
+							return nil, err
+						}
+					}
+					continue
+				}
+				if lookupTOML(known, path) != nil {
+					d.dropExpr(s, kv)
+				}
+				continue
+			}
+			if _, ok := knownLeaves[p]; ok {
+				d.dropExpr(s, kv)
+			}
+		}
+	}
+	// Insert the remaining values
+	newTables := make(map[string]any)
+	for _, p := range sortedKeys(genLeaves) {
+		if placed[p] {
+			continue
+		}
+		path := splitPath(p)
+		var target *tomlSection
+		for _, s := range sections {
+			key := s.key()[min(prefix, len(s.key())):]
+			if s.isArray() && len(key) > 0 || len(key) >= len(path) || !slices.Equal(key, path[:len(key)]) {
+				continue
+			}
+			if target == nil || len(key) > len(target.key())-min(prefix, len(target.key())) {
+				target = s
+			}
+		}
+		if target == nil || len(path) > 1 && len(target.key()) <= prefix && !d.hasDottedKey(target, path[0]) {
+			setTOML(newTables, path, genLeaves[p])
+			continue
+		}
+		key := target.key()[min(prefix, len(target.key())):]
+		line, err := formatKeyValue(path[len(key):], genLeaves[p])
+		if err != nil {
+			return nil, err
+		}
+		pos := d.insertPos(target)
+		d.insert[pos] = append(d.insert[pos], line+d.eol)
+	}
+	if len(newTables) == 0 {
+		return nil, nil
+	}
+	var root map[string]any = newTables
+	if prefix > 0 {
+		root = map[string]any{groups[0].array: []any{newTables}}
+	}
+	b, err := toml.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(string(b), d.eol), nil
+}
+
+// hasDottedKey reports whether the section has a dotted key starting with the given key.
+func (d *tomlDoc) hasDottedKey(s *tomlSection, key string) bool {
+	return slices.ContainsFunc(s.kvs, func(kv *tomlExpr) bool { return len(kv.key) > 1 && kv.key[0] == key })
+}
+
+func (d *tomlDoc) replaceValue(kv *tomlExpr, v any) error {
+	value, err := formatValue(v)
+	if err != nil {
+		return err
+	}
+	line := strings.TrimRight(d.lines[kv.start], "\r\n")
+	line = line[:kv.value] + value
+	if kv.comment != "" {
+		line += " " + kv.comment
+	}
+	d.replace[kv.start] = line + d.eol
+	for i := kv.start + 1; i < kv.end; i++ {
+		d.drop[i] = true
+	}
+	return nil
+}
+
+// dropExpr removes a key-value with the comments right above it.
+func (d *tomlDoc) dropExpr(s *tomlSection, kv *tomlExpr) {
+	start := kv.start
+	top := s.start
+	if s.header != nil {
+		top = s.header.end
+	}
+	for start > top && d.isComment(start-1) {
+		start--
+	}
+	for i := start; i < kv.end; i++ {
+		d.drop[i] = true
+	}
+}
+
+// writeGroup writes the lines of a group with the edits applied.
+// Sections whose key-values are all removed are left out.
+func (d *tomlDoc) writeGroup(out *tomlWriter, g *tomlGroup) {
+	var lines []string
+	for _, s := range g.sections {
+		if s.header != nil && !s.isArray() && d.empty(s) {
+			continue
+		}
+		for i := s.start; i <= s.end; i++ {
+			// Lines inserted at the start belong to the previous section
+			if i > s.start || s.header == nil {
+				lines = append(lines, d.insert[i]...)
+			}
+			if i == s.end {
+				break
+			}
+			if line, ok := d.replace[i]; ok {
+				lines = append(lines, line)
+			} else if !d.drop[i] {
+				lines = append(lines, d.lines[i])
+			}
+		}
+	}
+	if g.sections[0].header == nil {
+		out.lines(lines)
+	} else {
+		out.block(lines)
+	}
+}
+
+// empty reports whether all key-values of a section are removed and nothing is inserted.
+func (d *tomlDoc) empty(s *tomlSection) bool {
+	for i := s.header.end; i <= s.end; i++ {
+		if len(d.insert[i]) > 0 {
+			return false
+		}
+		if i < s.end && !d.drop[i] && strings.TrimSpace(d.lines[i]) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// tomlWriter joins the lines of a TOML document.
+type tomlWriter struct {
+	strings.Builder
+	eol string
+}
+
+func (w *tomlWriter) lines(lines []string) {
+	for _, line := range lines {
+		if w.Len() > 0 && !strings.HasSuffix(w.String(), "\n") {
+			w.WriteString(w.eol)
+		}
+		w.WriteString(line)
+	}
+}
+
+// block writes lines separated from the previous content by a blank line.
+func (w *tomlWriter) block(lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	if s := w.String(); s != "" && !strings.HasSuffix(s, "\n\n") && !strings.HasSuffix(s, "\n\r\n") {
+		if !strings.HasSuffix(s, "\n") {
+			w.WriteString(w.eol)
+		}
+		w.WriteString(w.eol)
+	}
+	w.lines(lines)
+}
+
+// splitLines splits text into lines ending with eol.
+func splitLines(s, eol string) []string {
+	lines := strings.SplitAfter(strings.TrimSuffix(s, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\n") + eol
+	}
+	return lines
+}
+
+const pathSep = "\x00"
+
+func joinPath(path []string) string {
+	return strings.Join(path, pathSep)
+}
+
+func splitPath(p string) []string {
+	return strings.Split(p, pathSep)
+}
+
+// flattenTOML returns the leaf values of a table by their joined key paths.
+func flattenTOML(m map[string]any, prefix []string, out map[string]any) map[string]any {
+	if out == nil {
+		out = make(map[string]any)
+	}
+	for k, v := range m {
+		path := append(slices.Clone(prefix), k)
+		if sub, ok := v.(map[string]any); ok {
+			flattenTOML(sub, path, out)
+		} else {
+			out[joinPath(path)] = v
+		}
+	}
+	return out
+}
+
+func lookupTOML(m map[string]any, path []string) any {
+	var v any = m
+	for _, k := range path {
+		t, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = t[k]
+	}
+	return v
+}
+
+func setTOML(m map[string]any, path []string, v any) {
+	for _, k := range path[:len(path)-1] {
+		sub, ok := m[k].(map[string]any)
+		if !ok {
+			sub = make(map[string]any)
+			m[k] = sub
+		}
+		m = sub
+	}
+	m[path[len(path)-1]] = v
+}
+
+// isTableArray reports whether the value is a non-empty array of tables.
+func isTableArray(v any) bool {
+	a, ok := v.([]any)
+	return ok && len(a) > 0 && !slices.ContainsFunc(a, func(e any) bool {
+		_, ok := e.(map[string]any)
+		return !ok
+	})
+}
+
+func omitKeys(m map[string]any, keys map[string]bool) map[string]any {
+	r := make(map[string]any, len(m))
+	for k, v := range m {
+		if !keys[k] {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// normalizeTOML converts the values of a table to the types of a decoded document.
+func normalizeTOML(m map[string]any) (map[string]any, error) {
+	b, err := toml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	var r map[string]any
+	if err = toml.Unmarshal(b, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+var bareKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// formatKeyValue returns a key-value line of a dotted key.
+func formatKeyValue(path []string, v any) (string, error) {
+	keys := make([]string, len(path))
+	for i, k := range path {
+		if bareKeyRegexp.MatchString(k) {
+			keys[i] = k
+			continue
+		}
+		b, err := toml.Marshal(k)
+		if err != nil {
+			return "", err
+		}
+		keys[i] = string(b)
+	}
+	value, err := formatValue(v)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(keys, ".") + " = " + value, nil
+}
+
+// formatValue returns the inline representation of a value.
+func formatValue(v any) (string, error) {
+	var b bytes.Buffer
+	enc := toml.NewEncoder(&b)
+	enc.SetTablesInline(true)
+	if err := enc.Encode(map[string]any{"v": v}); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(b.String(), "v = "), "\n"), nil
+}
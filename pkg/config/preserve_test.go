@@ -0,0 +1,115 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSavePreserve(t *testing.T) {
+	tests := []struct {
+		input  string
+		golden string
+		edit   func(conf *ClientConfig)
+	}{
+		{input: "preserve/client.toml", golden: "preserve/client.golden.toml", edit: editPreserveClient},
+		{input: "preserve/client.ini", golden: "preserve/client.golden.ini", edit: editPreserveClient},
+		{input: "preserve/rename.toml", golden: "preserve/rename.golden.toml", edit: renamePreserveProxy},
+		{input: "preserve/rename.ini", golden: "preserve/rename.golden.ini", edit: renamePreserveProxy},
+		{input: "preserve/visitors.toml", golden: "preserve/visitors.golden.toml", edit: func(conf *ClientConfig) {
+			// Visitors are kept in the order of the document
+			conf.Proxies[2].Name = "shell"
+			conf.Proxies[2].BindPort = 6011
+			conf.Proxies[1].BindPort = 6012
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			src, err := os.ReadFile(test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(t.TempDir(), filepath.Base(test.input))
+			if err = os.WriteFile(path, src, 0666); err != nil {
+				t.Fatal(err)
+			}
+			conf, err := UnmarshalClientConf(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test.edit(conf)
+			conf.Complete(false)
+			if err = conf.SavePreserve(path); err != nil {
+				t.Fatal(err)
+			}
+			output, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected, err := os.ReadFile(test.golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(output) != string(expected) {
+				t.Errorf("Expected: %v, got: %v", string(expected), string(output))
+			}
+			// Saving again without changes keeps the document
+			if err = conf.SavePreserve(path); err != nil {
+				t.Fatal(err)
+			}
+			if output, err = os.ReadFile(path); err != nil {
+				t.Fatal(err)
+			}
+			if string(output) != string(expected) {
+				t.Errorf("Expected: %v, got: %v", string(expected), string(output))
+			}
+		})
+	}
+}
+
+func editPreserveClient(conf *ClientConfig) {
+	// Update a value, remove a value and add a value of the common section
+	conf.ServerAddress = "frp.example.com"
+	conf.LogMaxDays = 0
+	conf.User = "alice"
+	// Update, remove and add proxies
+	conf.Proxies[0].RemotePort = "6010"
+	conf.DeleteProxy(1)
+	conf.Proxies[1].UseEncryption = false
+	conf.Proxies[1].UseCompression = true
+	proxy := NewDefaultProxyConfig("ssh")
+	proxy.Type = "tcp"
+	proxy.LocalPort = "22"
+	proxy.RemotePort = "6022"
+	conf.AddProxy(proxy)
+}
+
+func renamePreserveProxy(conf *ClientConfig) {
+	// A renamed proxy keeps its comments and the order of its keys
+	conf.Proxies[0].Name = "desktop"
+	conf.Proxies[0].RemotePort = "6010"
+}
+
+func TestMatchNames(t *testing.T) {
+	tests := []struct {
+		orig     []string
+		gen      []string
+		expected []int
+	}{
+		{[]string{"a", "b", "c"}, []string{"a", "b", "c"}, []int{0, 1, 2}},
+		{[]string{"a", "b", "c"}, []string{"c", "a"}, []int{2, 0}},
+		// Renamed elements are matched by position
+		{[]string{"a", "b", "c"}, []string{"a", "x", "c"}, []int{0, 1, 2}},
+		{[]string{"a", "b", "c"}, []string{"x", "y", "c"}, []int{0, 1, 2}},
+		// The number of unmatched elements differs
+		{[]string{"a", "b", "c"}, []string{"x", "c"}, []int{-1, 2}},
+		{[]string{"a", "b"}, []string{"a", "b", "x"}, []int{0, 1, -1}},
+		{[]string{"a", "b", "c"}, []string{"c", "x", "a"}, []int{2, -1, 0}},
+	}
+	for i, test := range tests {
+		if match := matchNames(test.orig, test.gen); !reflect.DeepEqual(match, test.expected) {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.expected, match)
+		}
+	}
+}
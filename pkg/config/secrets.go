@@ -0,0 +1,60 @@
+package config
+
+// secret is a credential field of the config.
+type secret struct {
+	// Proxy is the name of the proxy, or empty for the common section.
+	Proxy string
+	// Key identifies the field in placeholders, e.g. "TOKEN".
+	Key   string
+	Value *string
+}
+
+// secretKeyField is the key of the secret keys of STCP, XTCP and SUDP proxies.
+const secretKeyField = "SECRET_KEY"
+
+// secrets returns the fields of the config that hold credentials.
+func (conf *ClientConfig) secrets() []secret {
+	fields := []secret{
+		{"", "TOKEN", &conf.Token},
+		{"", "OIDC_CLIENT_SECRET", &conf.OIDCClientSecret},
+		{"", "ADMIN_PASSWORD", &conf.AdminPwd},
+	}
+	for _, proxy := range conf.Proxies {
+		fields = append(fields,
+			secret{proxy.Name, secretKeyField, &proxy.SK},
+			secret{proxy.Name, "HTTP_PASSWORD", &proxy.HTTPPwd},
+			secret{proxy.Name, "PLUGIN_HTTP_PASSWORD", &proxy.PluginHttpPasswd},
+			secret{proxy.Name, "PLUGIN_PASSWORD", &proxy.PluginPasswd},
+		)
+	}
+	return fields
+}
+
+// MapSecrets replaces each non-empty credential of the config, such as the token
+// and the secret keys of proxies, with the result of fn.
+func (conf *ClientConfig) MapSecrets(fn func(string) (string, error)) error {
+	for _, field := range conf.secrets() {
+		if *field.Value == "" {
+			continue
+		}
+		s, err := fn(*field.Value)
+		if err != nil {
+			return err
+		}
+		*field.Value = s
+	}
+	return nil
+}
+
+// Clone returns a copy of this config that doesn't share proxies with it.
+func (conf *ClientConfig) Clone() *ClientConfig {
+	newConf := *conf
+	newConf.Extras = cloneExtras(conf.Extras)
+	newConf.Proxies = make([]*Proxy, len(conf.Proxies))
+	for i, proxy := range conf.Proxies {
+		newProxy := *proxy
+		newProxy.Extras = cloneExtras(proxy.Extras)
+		newConf.Proxies[i] = &newProxy
+	}
+	return &newConf
+}
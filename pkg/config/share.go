@@ -0,0 +1,191 @@
+package config
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/koho/frpmgr/pkg/consts"
+	"github.com/koho/frpmgr/pkg/sharelink"
+)
+
+// Redaction is the policy for the secrets of shared configs.
+type Redaction int
+
+const (
+	// RedactNone keeps the secrets.
+	RedactNone Redaction = iota
+	// RedactStrip removes the secrets.
+	RedactStrip
+	// RedactPlaceholder replaces the secrets with templates of environment variables,
+	// e.g. "{{ .Envs.FRP_TOKEN }}", which are rendered by frp when the config is loaded.
+	RedactPlaceholder
+)
+
+// ParseRedaction returns the redaction policy of the given name: "none", "strip" or "placeholder".
+func ParseRedaction(s string) (Redaction, error) {
+	switch s {
+	case "", "none":
+		return RedactNone, nil
+	case "strip":
+		return RedactStrip, nil
+	case "placeholder":
+		return RedactPlaceholder, nil
+	}
+	return RedactNone, fmt.Errorf("unknown redaction policy %q", s)
+}
+
+// ShareOptions selects the proxies of shared configs and how their secrets are redacted.
+type ShareOptions struct {
+	// Proxies are the names of the proxies and visitors to share.
+	// All of them are shared if it's nil.
+	Proxies []string
+	// VisitorSide replaces the selected STCP, XTCP and SUDP proxies with visitors that
+	// connect to them, so the receiver can access the services. Other proxies are dropped,
+	// while visitors are kept.
+	VisitorSide bool
+	Redaction   Redaction
+	// KeepSecretKeys keeps the secret keys of STCP, XTCP and SUDP proxies and visitors
+	// regardless of the redaction policy, as they are required to connect to each other.
+	KeepSecretKeys bool
+}
+
+// Share returns a copy of the config to share with others. The paths of the log file
+// and store file are removed as they only make sense to this machine.
+func Share(conf *ClientConfig, opts ShareOptions) (*ClientConfig, error) {
+	for _, name := range opts.Proxies {
+		if !slices.ContainsFunc(conf.Proxies, func(p *Proxy) bool { return p.Name == name }) {
+			return nil, fmt.Errorf("proxy %q not found", name)
+		}
+	}
+	return share(conf, opts), nil
+}
+
+func share(conf *ClientConfig, opts ShareOptions) *ClientConfig {
+	shared := conf.Clone()
+	shared.LogFile = ""
+	shared.Store.Path = ""
+	shared.issues = nil
+	shared.Proxies = nil
+	for _, proxy := range conf.Proxies {
+		if opts.Proxies != nil && !slices.Contains(opts.Proxies, proxy.Name) {
+			continue
+		}
+		p := *proxy
+		if opts.VisitorSide && !p.IsVisitor() {
+			if p = visitorOf(proxy, conf.User); p.Name == "" {
+				continue
+			}
+		}
+		shared.Proxies = append(shared.Proxies, &p)
+	}
+	if opts.Redaction != RedactNone {
+		for _, field := range shared.secrets() {
+			if *field.Value == "" || opts.KeepSecretKeys && field.Key == secretKeyField {
+				continue
+			}
+			if opts.Redaction == RedactStrip {
+				*field.Value = ""
+			} else {
+				*field.Value = placeholder(field)
+			}
+		}
+	}
+	return shared
+}
+
+// visitorOf returns a visitor that connects to the given STCP, XTCP or SUDP proxy of the user.
+// The visitor listens on the local port of the proxy. A zero value is returned for other proxies.
+func visitorOf(p *Proxy, user string) Proxy {
+	switch p.Type {
+	case consts.ProxyTypeSTCP, consts.ProxyTypeXTCP, consts.ProxyTypeSUDP:
+	default:
+		return Proxy{}
+	}
+	v := Proxy{
+		BaseProxyConf: BaseProxyConf{
+			Name: p.Name + "_visitor", Type: p.Type,
+			UseEncryption: p.UseEncryption, UseCompression: p.UseCompression,
+		},
+		Role:       "visitor",
+		SK:         p.SK,
+		ServerUser: user,
+		ServerName: p.Name,
+		BindAddr:   "127.0.0.1",
+	}
+	v.BindPort, _ = strconv.Atoi(p.LocalPort)
+	return v
+}
+
+// placeholder returns the template of the environment variable that holds the secret,
+// e.g. "{{ .Envs.FRP_SSH_SECRET_KEY }}" for the secret key of proxy "ssh".
+func placeholder(s secret) string {
+	name := "FRP_" + s.Key
+	if s.Proxy != "" {
+		name = "FRP_" + strings.Map(func(r rune) rune {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				return unicode.ToUpper(r)
+			}
+			return '_'
+		}, s.Proxy) + "_" + s.Key
+	}
+	return "{{ .Envs." + name + " }}"
+}
+
+// ShareLink returns a share link of the config shared with the given options.
+// The expiry date of the config is used if the link has no expiry.
+func ShareLink(conf *ClientConfig, opts ShareOptions, linkOpts sharelink.Options) (string, error) {
+	shared, err := Share(conf, opts)
+	if err != nil {
+		return "", err
+	}
+	b, err := shared.Marshal()
+	if err != nil {
+		return "", err
+	}
+	if linkOpts.Expires.IsZero() && shared.DeleteMethod == consts.DeleteAbsolute {
+		linkOpts.Expires = shared.DeleteAfterDate
+	}
+	return sharelink.Encode(b, linkOpts)
+}
+
+// ShareZip writes the configs shared with the given options to a ZIP archive.
+// Each selected proxy must exist in at least one of the configs, and configs without
+// any selected proxy are skipped.
+func ShareZip(w io.Writer, confs []*ClientConfig, opts ShareOptions) error {
+	for _, name := range opts.Proxies {
+		if !slices.ContainsFunc(confs, func(conf *ClientConfig) bool {
+			return slices.ContainsFunc(conf.Proxies, func(p *Proxy) bool { return p.Name == name })
+		}) {
+			return fmt.Errorf("proxy %q not found", name)
+		}
+	}
+	zw := zip.NewWriter(w)
+	names := make(map[string]int)
+	for _, conf := range confs {
+		shared := share(conf, opts)
+		if opts.Proxies != nil && len(shared.Proxies) == 0 {
+			continue
+		}
+		b, err := shared.Marshal()
+		if err != nil {
+			return err
+		}
+		name := conf.Name()
+		if names[name]++; names[name] > 1 {
+			name = fmt.Sprintf("%s_%d", name, names[name])
+		}
+		fw, err := zw.Create(name + conf.Ext())
+		if err != nil {
+			return err
+		}
+		if _, err = fw.Write(b); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
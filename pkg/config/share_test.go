@@ -0,0 +1,126 @@
+package config
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/koho/frpmgr/pkg/sharelink"
+)
+
+func newShareTestConfig() *ClientConfig {
+	conf := NewDefaultClientConfig()
+	conf.ClientCommon.Name = "test"
+	conf.ServerAddress = "example.com"
+	conf.User = "alice"
+	conf.Token = "token"
+	conf.AdminPort = 7400
+	conf.AdminPwd = "admin"
+	conf.LogFile = "logs/test.log"
+	conf.Proxies = []*Proxy{
+		{BaseProxyConf: BaseProxyConf{Name: "web", Type: "http", LocalPort: "80"}, CustomDomains: "example.com", HTTPPwd: "web"},
+		{BaseProxyConf: BaseProxyConf{Name: "ssh", Type: "stcp", LocalPort: "22"}, SK: "ssh"},
+		{BaseProxyConf: BaseProxyConf{Name: "db", Type: "xtcp"}, Role: "visitor", SK: "db", ServerName: "db", BindPort: 3306},
+	}
+	return conf
+}
+
+func TestShare(t *testing.T) {
+	conf := newShareTestConfig()
+	tests := []struct {
+		opts     ShareOptions
+		proxies  []Proxy
+		token    string
+		adminPwd string
+	}{
+		{
+			opts:     ShareOptions{Proxies: []string{"web"}, Redaction: RedactStrip},
+			proxies:  []Proxy{{BaseProxyConf: BaseProxyConf{Name: "web", Type: "http", LocalPort: "80"}, CustomDomains: "example.com"}},
+			token:    "",
+			adminPwd: "",
+		},
+		{
+			opts: ShareOptions{Proxies: []string{"ssh"}, Redaction: RedactPlaceholder},
+			proxies: []Proxy{
+				{BaseProxyConf: BaseProxyConf{Name: "ssh", Type: "stcp", LocalPort: "22"}, SK: "{{ .Envs.FRP_SSH_SECRET_KEY }}"},
+			},
+			token:    "{{ .Envs.FRP_TOKEN }}",
+			adminPwd: "{{ .Envs.FRP_ADMIN_PASSWORD }}",
+		},
+		{
+			opts: ShareOptions{VisitorSide: true, Redaction: RedactPlaceholder, KeepSecretKeys: true},
+			proxies: []Proxy{
+				{BaseProxyConf: BaseProxyConf{Name: "ssh_visitor", Type: "stcp"}, Role: "visitor", SK: "ssh", ServerUser: "alice", ServerName: "ssh", BindAddr: "127.0.0.1", BindPort: 22},
+				{BaseProxyConf: BaseProxyConf{Name: "db", Type: "xtcp"}, Role: "visitor", SK: "db", ServerName: "db", BindPort: 3306},
+			},
+			token:    "{{ .Envs.FRP_TOKEN }}",
+			adminPwd: "{{ .Envs.FRP_ADMIN_PASSWORD }}",
+		},
+	}
+	for i, test := range tests {
+		shared, err := Share(conf, test.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var proxies []Proxy
+		for _, p := range shared.Proxies {
+			proxies = append(proxies, *p)
+		}
+		if !reflect.DeepEqual(proxies, test.proxies) {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.proxies, proxies)
+		}
+		if shared.Token != test.token || shared.AdminPwd != test.adminPwd {
+			t.Errorf("Test %d: Expected: %v, %v, got: %v, %v", i, test.token, test.adminPwd, shared.Token, shared.AdminPwd)
+		}
+		if shared.LogFile != "" {
+			t.Errorf("Test %d: Expected empty log file, got: %v", i, shared.LogFile)
+		}
+	}
+	// The source config is not modified
+	if !reflect.DeepEqual(conf, newShareTestConfig()) {
+		t.Errorf("Expected: %v, got: %v", newShareTestConfig(), conf)
+	}
+	if _, err := Share(conf, ShareOptions{Proxies: []string{"ftp"}}); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestShareLink(t *testing.T) {
+	conf := newShareTestConfig()
+	opts := ShareOptions{Proxies: []string{"ssh"}, VisitorSide: true, Redaction: RedactStrip, KeepSecretKeys: true}
+	s, err := ShareLink(conf, opts, sharelink.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	link, err := sharelink.Decode(s, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := UnmarshalClientConf(link.Content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(output.Proxies) != 1 || output.Proxies[0].Name != "ssh_visitor" || output.Proxies[0].SK != "ssh" || output.Token != "" {
+		t.Errorf("Unexpected config: %s", link.Content)
+	}
+
+	// ZIP
+	other := NewDefaultClientConfig()
+	other.ClientCommon.Name = "other"
+	var buf bytes.Buffer
+	if err = ShareZip(&buf, []*ClientConfig{conf, other}, ShareOptions{Proxies: []string{"web"}}); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if expected := []string{"test.toml"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected: %v, got: %v", expected, names)
+	}
+}
@@ -45,13 +45,17 @@ const (
 	PluginHttp2Http   = "http2http"
 	PluginUnixDomain  = "unix_domain_socket"
 	PluginTLS2Raw     = "tls2raw"
+	PluginVirtualNet  = "virtual_net"
 )
 
 var PluginTypes = []string{
 	PluginHttp2Http, PluginHttp2Https, PluginHttps2Http, PluginHttps2Https,
-	PluginHttpProxy, PluginSocks5, PluginStaticFile, PluginUnixDomain, PluginTLS2Raw,
+	PluginHttpProxy, PluginSocks5, PluginStaticFile, PluginUnixDomain, PluginTLS2Raw, PluginVirtualNet,
 }
 
+// VisitorPluginTypes are the plugins that can be used by visitors.
+var VisitorPluginTypes = []string{PluginVirtualNet}
+
 // Auth methods
 const (
 	AuthToken = "token"
@@ -87,3 +91,12 @@ const (
 var LogLevels = []string{LogLevelTrace, LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError}
 
 const DefaultLogMaxDays = 3
+
+// File formats of v1 configs
+const (
+	FormatTOML = "toml"
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+var Formats = []string{FormatTOML, FormatYAML, FormatJSON}
@@ -1,6 +1,23 @@
 package ipc
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/fatedier/frp/client/proxy"
+)
+
+// Protocol versions. A request without version is treated as ProtocolV1.
+const (
+	// ProtocolV1 carries the phase, error and remote address of proxies.
+	ProtocolV1 = 1
+	// ProtocolV2 adds traffic counters, connection counts and timestamps.
+	ProtocolV2 = 2
+	// ProtocolV3 adds the statistics of the service.
+	ProtocolV3 = 3
+	// ProtocolVersion is the latest version supported by this package.
+	ProtocolVersion = ProtocolV3
+)
 
 // ProxyMessage is the status information of a proxy.
 type ProxyMessage struct {
@@ -9,6 +26,105 @@ type ProxyMessage struct {
 	Status     string
 	Err        string
 	RemoteAddr string
+	// ProxyStats is zero for clients older than ProtocolV2.
+	ProxyStats
+}
+
+// ProxyStats is the traffic and history information of a proxy.
+type ProxyStats struct {
+	// BytesIn is the number of bytes received from the server.
+	BytesIn int64
+	// BytesOut is the number of bytes sent to the server.
+	BytesOut int64
+	// CurConns is the number of open work connections.
+	CurConns int64
+	// TotalConns is the number of work connections since the service started.
+	TotalConns int64
+	// LastStart is the last time the proxy entered the running phase.
+	LastStart time.Time
+	// LastError is the last time the proxy failed to start or failed the health check.
+	LastError time.Time
+	// Retries is the number of times the proxy has been registered again.
+	Retries int
+	// Errors is the number of times the proxy failed to start or failed the health check.
+	Errors int
+	// NoTraffic indicates that traffic counters and connection counts are not collected
+	// for the type of the proxy, so they must not be reported as zero.
+	NoTraffic bool
+}
+
+// ServiceStats is the statistics of the service running the frp client.
+type ServiceStats struct {
+	StartTime time.Time
+	// Reloads is the number of successful reloads.
+	Reloads int
+}
+
+// StatsExporter provides the statistics of proxies.
+// The status exporter of a server may implement this interface to support ProtocolV2.
+type StatsExporter interface {
+	GetProxyStats(name string) (ProxyStats, bool)
+}
+
+// ServiceExporter provides the statistics of the service.
+// The status exporter of a server may implement this interface to support ProtocolV3.
+type ServiceExporter interface {
+	GetServiceStats() ServiceStats
+}
+
+// Request registers the proxies that a client is interested in.
+// It replaces the names registered by the previous request on the same connection.
+type Request struct {
+	// Version is the protocol version preferred by the client.
+	Version int
+	Names   []string
+}
+
+// Delta is the status change of the registered proxies pushed by the server.
+type Delta struct {
+	// Version is the protocol version negotiated for the connection.
+	Version int
+	// Full indicates that Updated contains the status of all registered proxies,
+	// and any previously received status should be discarded.
+	Full bool
+	// Updated contains the proxies whose status has changed.
+	Updated []ProxyMessage
+	// Removed contains the names of proxies that no longer have a status.
+	Removed []string
+	// Service is only set in full deltas since ProtocolV3.
+	Service ServiceStats
+}
+
+// GetProxyStatus returns the status of a proxy in the delta.
+// Together with GetProxyStats, it allows a full delta to be used as a status exporter.
+func (d *Delta) GetProxyStatus(name string) (*proxy.WorkingStatus, bool) {
+	for _, m := range d.Updated {
+		if m.Name == name {
+			return &proxy.WorkingStatus{
+				Name:       m.Name,
+				Type:       m.Type,
+				Phase:      m.Status,
+				Err:        m.Err,
+				RemoteAddr: m.RemoteAddr,
+			}, true
+		}
+	}
+	return nil, false
+}
+
+// GetProxyStats returns the statistics of a proxy in the delta.
+func (d *Delta) GetProxyStats(name string) (ProxyStats, bool) {
+	for _, m := range d.Updated {
+		if m.Name == name {
+			return m.ProxyStats, true
+		}
+	}
+	return ProxyStats{}, false
+}
+
+// GetServiceStats returns the statistics of the service in the delta.
+func (d *Delta) GetServiceStats() ServiceStats {
+	return d.Service
 }
 
 // Client is used to query proxy state from the frp client.
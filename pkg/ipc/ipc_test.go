@@ -0,0 +1,376 @@
+package ipc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/gob"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fatedier/frp/client/proxy"
+)
+
+type fakeExporter struct {
+	sync.Mutex
+	Notifier
+	status map[string]proxy.WorkingStatus
+	stats  map[string]ProxyStats
+	svc    ServiceStats
+}
+
+func (f *fakeExporter) GetServiceStats() ServiceStats {
+	return f.svc
+}
+
+func (f *fakeExporter) GetProxyStats(name string) (ProxyStats, bool) {
+	f.Lock()
+	defer f.Unlock()
+	stats, ok := f.stats[name]
+	return stats, ok
+}
+
+func (f *fakeExporter) GetProxyStatus(name string) (*proxy.WorkingStatus, bool) {
+	f.Lock()
+	defer f.Unlock()
+	status, ok := f.status[name]
+	if !ok {
+		return nil, false
+	}
+	return &status, true
+}
+
+func (f *fakeExporter) set(status proxy.WorkingStatus) {
+	f.Lock()
+	defer f.Unlock()
+	f.status[status.Name] = status
+	f.Notify()
+}
+
+// setStats changes the statistics of a proxy without notification,
+// like the traffic counters of a service.
+func (f *fakeExporter) setStats(name string, stats ProxyStats) {
+	f.Lock()
+	defer f.Unlock()
+	if f.stats == nil {
+		f.stats = make(map[string]ProxyStats)
+	}
+	f.stats[name] = stats
+}
+
+func (f *fakeExporter) remove(name string) {
+	f.Lock()
+	defer f.Unlock()
+	delete(f.status, name)
+	f.Notify()
+}
+
+func testTransport(t *testing.T, transport Transport) {
+	exporter := &fakeExporter{status: map[string]proxy.WorkingStatus{
+		"ssh": {Name: "ssh", Type: "tcp", Phase: proxy.ProxyPhaseRunning, RemoteAddr: "example.com:6000"},
+		"web": {Name: "web", Type: "http", Phase: proxy.ProxyPhaseStartErr, Err: "port already used"},
+	}}
+	s, err := NewServer(transport, exporter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	go s.Run()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ch := make(chan []ProxyMessage, 4)
+	c := NewClient(transport, func() []string { return []string{"ssh", "web", "unknown"} })
+	c.SetCallback(func(msg []ProxyMessage) {
+		ch <- msg
+	})
+	go c.Run(ctx)
+
+	receive := func(expected []ProxyMessage) {
+		select {
+		case msg := <-ch:
+			if !reflect.DeepEqual(msg, expected) {
+				t.Errorf("Expected: %v, got: %v", expected, msg)
+			}
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		}
+	}
+	// Full snapshot
+	receive([]ProxyMessage{
+		{Name: "ssh", Type: "tcp", Status: proxy.ProxyPhaseRunning, RemoteAddr: "example.com:6000"},
+		{Name: "web", Type: "http", Status: proxy.ProxyPhaseStartErr, Err: "port already used"},
+	})
+	// Pushed changes
+	exporter.set(proxy.WorkingStatus{Name: "ssh", Type: "tcp", Phase: proxy.ProxyPhaseCheckFailed, Err: "health check failed"})
+	receive([]ProxyMessage{
+		{Name: "ssh", Type: "tcp", Status: proxy.ProxyPhaseCheckFailed, Err: "health check failed"},
+		{Name: "web", Type: "http", Status: proxy.ProxyPhaseStartErr, Err: "port already used"},
+	})
+	exporter.remove("web")
+	receive([]ProxyMessage{
+		{Name: "ssh", Type: "tcp", Status: proxy.ProxyPhaseCheckFailed, Err: "health check failed"},
+	})
+	// Changes found by the periodic check
+	exporter.setStats("ssh", ProxyStats{BytesIn: 100, BytesOut: 200})
+	receive([]ProxyMessage{
+		{Name: "ssh", Type: "tcp", Status: proxy.ProxyPhaseCheckFailed, Err: "health check failed",
+			ProxyStats: ProxyStats{BytesIn: 100, BytesOut: 200}},
+	})
+}
+
+func TestProtocolVersion(t *testing.T) {
+	lastStart := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	exporter := &fakeExporter{
+		status: map[string]proxy.WorkingStatus{
+			"ssh": {Name: "ssh", Type: "tcp", Phase: proxy.ProxyPhaseRunning},
+		},
+		stats: map[string]ProxyStats{
+			"ssh": {BytesIn: 100, BytesOut: 200, CurConns: 1, TotalConns: 3, LastStart: lastStart, Retries: 2},
+		},
+		svc: ServiceStats{StartTime: lastStart, Reloads: 3},
+	}
+	transport := NewUnixTransport(filepath.Join(t.TempDir(), "test.sock"))
+	s, err := NewServer(transport, exporter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	go s.Run()
+
+	tests := []struct {
+		version  int
+		expected Delta
+	}{
+		{0, Delta{Version: ProtocolV1, Full: true, Updated: []ProxyMessage{
+			{Name: "ssh", Type: "tcp", Status: proxy.ProxyPhaseRunning},
+		}}},
+		{ProtocolV2, Delta{Version: ProtocolV2, Full: true, Updated: []ProxyMessage{
+			{Name: "ssh", Type: "tcp", Status: proxy.ProxyPhaseRunning, ProxyStats: exporter.stats["ssh"]},
+		}}},
+		{ProtocolVersion + 1, Delta{Version: ProtocolV3, Full: true, Updated: []ProxyMessage{
+			{Name: "ssh", Type: "tcp", Status: proxy.ProxyPhaseRunning, ProxyStats: exporter.stats["ssh"]},
+		}, Service: exporter.svc}},
+	}
+	for i, test := range tests {
+		conn, err := transport.Dial(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = gob.NewEncoder(conn).Encode(Request{Version: test.version, Names: []string{"ssh"}}); err != nil {
+			t.Fatal(err)
+		}
+		var delta Delta
+		if err = gob.NewDecoder(conn).Decode(&delta); err != nil {
+			t.Fatal(err)
+		}
+		conn.Close()
+		if !reflect.DeepEqual(delta, test.expected) {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.expected, delta)
+		}
+	}
+}
+
+func TestPolling(t *testing.T) {
+	defer func(d []time.Duration) { checkIntervals = d }(checkIntervals)
+	checkIntervals = []time.Duration{10 * time.Millisecond}
+	exporter := &fakeExporter{status: map[string]proxy.WorkingStatus{
+		"ssh": {Name: "ssh", Type: "tcp", Phase: proxy.ProxyPhaseRunning},
+	}}
+	transport := NewUnixTransport(filepath.Join(t.TempDir(), "test.sock"))
+	s, err := NewServer(transport, exporter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	go s.Run()
+
+	tests := []struct {
+		version int
+		stats   bool
+		polled  bool
+	}{
+		// Status changes are only pushed by the notifier
+		{ProtocolV1, false, false},
+		// Traffic counters are polled
+		{ProtocolV2, true, true},
+	}
+	for i, test := range tests {
+		conn, err := transport.Dial(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = gob.NewEncoder(conn).Encode(Request{Version: test.version, Names: []string{"ssh"}}); err != nil {
+			t.Fatal(err)
+		}
+		dec := gob.NewDecoder(conn)
+		var delta Delta
+		if err = dec.Decode(&delta); err != nil {
+			t.Fatal(err)
+		}
+		// Change the proxy without notification
+		exporter.Lock()
+		if test.stats {
+			exporter.stats = map[string]ProxyStats{"ssh": {BytesIn: int64(i + 1)}}
+		} else {
+			status := exporter.status["ssh"]
+			status.Err = strconv.Itoa(i)
+			exporter.status["ssh"] = status
+		}
+		exporter.Unlock()
+		conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+		polled := dec.Decode(&delta) == nil
+		conn.Close()
+		if polled != test.polled {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.polled, polled)
+		}
+	}
+}
+
+func TestLegacyRequest(t *testing.T) {
+	exporter := &fakeExporter{status: map[string]proxy.WorkingStatus{
+		"ssh": {Name: "ssh", Type: "tcp", Phase: proxy.ProxyPhaseRunning, RemoteAddr: "example.com:6000"},
+		"web": {Name: "web", Type: "http", Phase: proxy.ProxyPhaseStartErr, Err: "port already used"},
+	}}
+	transport := NewUnixTransport(filepath.Join(t.TempDir(), "test.sock"))
+	s, err := NewServer(transport, exporter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	go s.Run()
+
+	// The status message of older versions
+	type ProxyMessage struct {
+		Name       string
+		Type       string
+		Status     string
+		Err        string
+		RemoteAddr string
+	}
+	conn, err := transport.Dial(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	tests := []struct {
+		names    []string
+		expected []ProxyMessage
+	}{
+		{[]string{"ssh", "web", "unknown"}, []ProxyMessage{
+			{Name: "ssh", Type: "tcp", Status: proxy.ProxyPhaseRunning, RemoteAddr: "example.com:6000"},
+			{Name: "web", Type: "http", Status: proxy.ProxyPhaseStartErr, Err: "port already used"},
+		}},
+		{[]string{"web"}, []ProxyMessage{
+			{Name: "web", Type: "http", Status: proxy.ProxyPhaseStartErr, Err: "port already used"},
+		}},
+		{[]string{"unknown"}, nil},
+	}
+	// Older versions use a new encoder and decoder for every query on the same connection
+	for i, test := range tests {
+		if err = gob.NewEncoder(conn).Encode(test.names); err != nil {
+			t.Fatal(err)
+		}
+		var msg []ProxyMessage
+		if err = gob.NewDecoder(conn).Decode(&msg); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(msg, test.expected) {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.expected, msg)
+		}
+	}
+}
+
+func TestReadFrame(t *testing.T) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	first := Request{Version: ProtocolVersion, Names: []string{strings.Repeat("a", 300)}}
+	second := Request{Names: []string{"ssh"}}
+	if err := enc.Encode(first); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Encode(second); err != nil {
+		t.Fatal(err)
+	}
+	r := bufio.NewReader(&buf)
+	frame, err := readFrame(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The frame and the rest of the stream can be decoded together
+	dec := gob.NewDecoder(io.MultiReader(bytes.NewReader(frame), r))
+	for i, expected := range []Request{first, second} {
+		var req Request
+		if err = dec.Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(req, expected) {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, expected, req)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	prev := []ProxyMessage{
+		{Name: "a", Status: proxy.ProxyPhaseRunning},
+		{Name: "b", Status: proxy.ProxyPhaseRunning},
+		{Name: "c", Status: proxy.ProxyPhaseRunning},
+	}
+	cur := []ProxyMessage{
+		{Name: "a", Status: proxy.ProxyPhaseRunning},
+		{Name: "b", Status: proxy.ProxyPhaseRunning, RemoteAddr: "example.com:6000"},
+		{Name: "d", Status: proxy.ProxyPhaseWaitStart},
+	}
+	expected := Delta{
+		Updated: []ProxyMessage{cur[1], cur[2]},
+		Removed: []string{"c"},
+	}
+	if delta := diff(prev, cur); !reflect.DeepEqual(delta, expected) {
+		t.Errorf("Expected: %v, got: %v", expected, delta)
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	var n Notifier
+	ch, cancel := n.Subscribe()
+	// Notifications are merged while the subscriber is busy
+	n.Notify()
+	n.Notify()
+	select {
+	case <-ch:
+	default:
+		t.Fatal("Expected a notification")
+	}
+	select {
+	case <-ch:
+		t.Fatal("Expected a single notification")
+	default:
+	}
+	cancel()
+	n.Notify()
+	select {
+	case <-ch:
+		t.Fatal("Expected no notification after cancel")
+	default:
+	}
+}
+
+func TestUnixTransport(t *testing.T) {
+	testTransport(t, NewUnixTransport(filepath.Join(t.TempDir(), "test.sock")))
+}
+
+func TestTCPTransport(t *testing.T) {
+	testTransport(t, NewTCPTransport("127.0.0.1:0"))
+}
+
+func TestTCPTransportLoopback(t *testing.T) {
+	if _, err := NewTCPTransport("0.0.0.0:0").Listen(); err == nil {
+		t.Error("Expected an error for non-loopback address")
+	}
+}
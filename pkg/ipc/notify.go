@@ -0,0 +1,46 @@
+package ipc
+
+import "sync"
+
+// ChangeNotifier signals status changes of proxies.
+// The status exporter of a server may implement this interface, so changes are
+// pushed to clients as soon as they happen instead of at the next check.
+type ChangeNotifier interface {
+	// Subscribe returns a channel which receives a value after the status of proxies
+	// has changed, and a function to cancel the subscription.
+	Subscribe() (<-chan struct{}, func())
+}
+
+// Notifier implements ChangeNotifier. The zero value is ready to use.
+type Notifier struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+func (n *Notifier) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.subs == nil {
+		n.subs = make(map[chan struct{}]struct{})
+	}
+	n.subs[ch] = struct{}{}
+	return ch, func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		delete(n.subs, ch)
+	}
+}
+
+// Notify wakes up all subscribers. It never blocks, and notifications
+// are merged if a subscriber has not received the previous one.
+func (n *Notifier) Notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for ch := range n.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package ipc
+
+import (
+	"context"
+	"net"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// PipeTransport uses a Windows named pipe.
+type PipeTransport struct {
+	path string
+}
+
+func NewPipeTransport(name string) *PipeTransport {
+	return &PipeTransport{path: `\\.\pipe\` + name}
+}
+
+func (t *PipeTransport) Listen() (net.Listener, error) {
+	return winio.ListenPipe(t.path, &winio.PipeConfig{
+		MessageMode:      true,
+		InputBufferSize:  1024,
+		OutputBufferSize: 2048,
+	})
+}
+
+func (t *PipeTransport) Dial(ctx context.Context) (net.Conn, error) {
+	return winio.DialPipeContext(ctx, t.path)
+}
+
+// NewTransport returns the default transport of the given service name.
+// On Windows, it's a named pipe.
+func NewTransport(name string) Transport {
+	return NewPipeTransport(name)
+}
@@ -1,24 +1,42 @@
 package ipc
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/gob"
+	"errors"
+	"io"
 	"net"
+	"time"
 
-	"github.com/Microsoft/go-winio"
 	"github.com/fatedier/frp/client"
 )
 
+// checkIntervals are the intervals at which the server checks for changes that are not
+// signaled by the exporter, such as traffic counters. The server moves to the next
+// interval while nothing changes, and goes back to the first one after a change.
+var checkIntervals = []time.Duration{time.Second, 2 * time.Second, 5 * time.Second}
+
+// maxFrameSize limits the size of the first value read from a connection.
+const maxFrameSize = 1 << 20
+
+// legacyMessage is the status message of clients older than the stream protocol.
+type legacyMessage struct {
+	Name       string
+	Type       string
+	Status     string
+	Err        string
+	RemoteAddr string
+}
+
 type Server struct {
 	listener net.Listener
 	exporter client.StatusExporter
 }
 
-func NewServer(name string, exporter client.StatusExporter) (*Server, error) {
-	listener, err := winio.ListenPipe(`\\.\pipe\`+name, &winio.PipeConfig{
-		MessageMode:      true,
-		InputBufferSize:  1024,
-		OutputBufferSize: 2048,
-	})
+// NewServer creates a server which pushes status changes on the given transport.
+func NewServer(transport Transport, exporter client.StatusExporter) (*Server, error) {
+	listener, err := transport.Listen()
 	if err != nil {
 		return nil, err
 	}
@@ -35,31 +53,263 @@ func (s *Server) Run() {
 	}
 }
 
+// handle serves a connection. Clients older than the stream protocol send a list
+// of names instead of a request, so the first value is examined before decoding.
 func (s *Server) handle(conn net.Conn) {
 	defer conn.Close()
+	r := bufio.NewReader(conn)
+	frame, err := readFrame(r)
+	if err != nil {
+		return
+	}
+	var names []string
+	if gob.NewDecoder(bytes.NewReader(frame)).Decode(&names) == nil {
+		s.handleLegacy(conn, r, names)
+		return
+	}
+	s.handleStream(conn, io.MultiReader(bytes.NewReader(frame), r))
+}
+
+// handleLegacy answers the clients older than the stream protocol. They use a new gob
+// stream for every request and response, and expect the status of all given proxies.
+func (s *Server) handleLegacy(conn net.Conn, r *bufio.Reader, names []string) {
 	for {
-		var names []string
-		if err := gob.NewDecoder(conn).Decode(&names); err != nil {
+		current := s.snapshot(names, ProtocolV1)
+		msg := make([]legacyMessage, 0, len(current))
+		for _, m := range current {
+			msg = append(msg, legacyMessage{m.Name, m.Type, m.Status, m.Err, m.RemoteAddr})
+		}
+		if err := gob.NewEncoder(conn).Encode(msg); err != nil {
+			return
+		}
+		names = nil
+		if err := gob.NewDecoder(r).Decode(&names); err != nil {
 			return
 		}
-		msg := make([]ProxyMessage, 0, len(names))
-		for _, name := range names {
-			if status, _ := s.exporter.GetProxyStatus(name); status != nil {
-				msg = append(msg, ProxyMessage{
-					Name:       status.Name,
-					Type:       status.Type,
-					Status:     status.Phase,
-					Err:        status.Err,
-					RemoteAddr: status.RemoteAddr,
-				})
+	}
+}
+
+// handleStream pushes the status changes of the proxies registered by requests.
+func (s *Server) handleStream(conn net.Conn, r io.Reader) {
+	done := make(chan struct{})
+	defer close(done)
+	requests := make(chan Request)
+	go func() {
+		defer close(requests)
+		dec := gob.NewDecoder(r)
+		for {
+			var req Request
+			if err := dec.Decode(&req); err != nil {
+				return
+			}
+			select {
+			case requests <- req:
+			case <-done:
+				return
 			}
 		}
-		if err := gob.NewEncoder(conn).Encode(msg); err != nil {
-			return
+	}()
+
+	var changes <-chan struct{}
+	if cn, ok := s.exporter.(ChangeNotifier); ok {
+		var cancel func()
+		changes, cancel = cn.Subscribe()
+		defer cancel()
+	}
+	_, hasStats := s.exporter.(StatsExporter)
+	enc := gob.NewEncoder(conn)
+	timer := time.NewTimer(checkIntervals[0])
+	timer.Stop()
+	defer timer.Stop()
+	backoff := 0
+	var names []string
+	var last []ProxyMessage
+	version := ProtocolV1
+	// poll reports whether the proxies must be checked periodically. Status changes are
+	// pushed by the notifier if the exporter has one, so the timer is only needed for
+	// the exporters without a notifier, or for the traffic counters sent to V2+ clients,
+	// which change silently.
+	poll := func() bool {
+		return names != nil && (changes == nil || hasStats && version >= ProtocolV2)
+	}
+	// check pushes the changes since the last snapshot, and reports whether there are any.
+	check := func() (bool, error) {
+		if names == nil {
+			return false, nil
+		}
+		current := s.snapshot(names, version)
+		delta := diff(last, current)
+		last = current
+		if len(delta.Updated) == 0 && len(delta.Removed) == 0 {
+			return false, nil
+		}
+		delta.Version = version
+		return true, enc.Encode(delta)
+	}
+	for {
+		select {
+		case req, ok := <-requests:
+			if !ok {
+				return
+			}
+			version = negotiate(req.Version)
+			names = req.Names
+			last = s.snapshot(names, version)
+			delta := Delta{Version: version, Full: true, Updated: last}
+			if se, ok := s.exporter.(ServiceExporter); ok && version >= ProtocolV3 {
+				delta.Service = se.GetServiceStats()
+			}
+			if err := enc.Encode(delta); err != nil {
+				return
+			}
+			backoff = 0
+		case <-changes:
+			if _, err := check(); err != nil {
+				return
+			}
+			backoff = 0
+		case <-timer.C:
+			changed, err := check()
+			if err != nil {
+				return
+			}
+			if changed {
+				backoff = 0
+			} else if backoff < len(checkIntervals)-1 {
+				backoff++
+			}
+		}
+		if poll() {
+			timer.Reset(checkIntervals[backoff])
+		} else {
+			timer.Stop()
 		}
 	}
 }
 
+// snapshot returns the current status of the given proxies.
+// Statistics are included if the version supports them.
+func (s *Server) snapshot(names []string, version int) []ProxyMessage {
+	se, _ := s.exporter.(StatsExporter)
+	if version < ProtocolV2 {
+		se = nil
+	}
+	msg := make([]ProxyMessage, 0, len(names))
+	for _, name := range names {
+		if status, _ := s.exporter.GetProxyStatus(name); status != nil {
+			m := ProxyMessage{
+				Name:       status.Name,
+				Type:       status.Type,
+				Status:     status.Phase,
+				Err:        status.Err,
+				RemoteAddr: status.RemoteAddr,
+			}
+			if se != nil {
+				m.ProxyStats, _ = se.GetProxyStats(name)
+			}
+			msg = append(msg, m)
+		}
+	}
+	return msg
+}
+
+// negotiate returns the highest version supported by both sides.
+func negotiate(version int) int {
+	if version < ProtocolV1 {
+		return ProtocolV1
+	}
+	return min(version, ProtocolVersion)
+}
+
 func (s *Server) Close() error {
 	return s.listener.Close()
 }
+
+// diff returns the changes from the previous snapshot to the current one.
+func diff(prev, cur []ProxyMessage) (delta Delta) {
+	prevByName := make(map[string]ProxyMessage, len(prev))
+	for _, m := range prev {
+		prevByName[m.Name] = m
+	}
+	for _, m := range cur {
+		if old, ok := prevByName[m.Name]; !ok || old != m {
+			delta.Updated = append(delta.Updated, m)
+		}
+		delete(prevByName, m.Name)
+	}
+	for _, m := range prev {
+		if _, ok := prevByName[m.Name]; ok {
+			delta.Removed = append(delta.Removed, m.Name)
+		}
+	}
+	return
+}
+
+// readFrame reads the gob messages carrying the first value of a stream, which are
+// the definitions of the types used by the value, followed by the value itself.
+// Each message is prefixed by its length, and starts with a type id, which is
+// negative for a type definition.
+func readFrame(r *bufio.Reader) ([]byte, error) {
+	var frame []byte
+	for {
+		size, err := readUint(r)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 || size > uint64(maxFrameSize-len(frame)) {
+			return nil, errors.New("invalid message size")
+		}
+		frame = appendUint(frame, size)
+		start := len(frame)
+		frame = append(frame, make([]byte, size)...)
+		if _, err = io.ReadFull(r, frame[start:]); err != nil {
+			return nil, err
+		}
+		id, err := readUint(bytes.NewReader(frame[start:]))
+		if err != nil {
+			return nil, err
+		}
+		// Signed integers are stored with the sign in the lowest bit
+		if id&1 == 0 {
+			return frame, nil
+		}
+	}
+}
+
+// readUint reads an unsigned integer in gob encoding. A value less than 128 is stored
+// in a single byte. Otherwise, the negated byte count precedes the big-endian value.
+func readUint(r io.ByteReader) (uint64, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if b < 0x80 {
+		return uint64(b), nil
+	}
+	n := -int(int8(b))
+	if n > 8 {
+		return 0, errors.New("invalid unsigned integer")
+	}
+	var x uint64
+	for i := 0; i < n; i++ {
+		if b, err = r.ReadByte(); err != nil {
+			return 0, err
+		}
+		x = x<<8 | uint64(b)
+	}
+	return x, nil
+}
+
+// appendUint appends an unsigned integer in gob encoding.
+func appendUint(b []byte, x uint64) []byte {
+	if x < 0x80 {
+		return append(b, byte(x))
+	}
+	var buf [8]byte
+	n := 8
+	for ; x > 0; x >>= 8 {
+		n--
+		buf[n] = byte(x)
+	}
+	return append(append(b, byte(-(8-n))), buf[n:]...)
+}
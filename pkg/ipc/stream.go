@@ -0,0 +1,131 @@
+package ipc
+
+import (
+	"context"
+	"encoding/gob"
+)
+
+// Query returns the full delta of the given proxies from the server listening on the transport.
+func Query(ctx context.Context, transport Transport, names []string) (*Delta, error) {
+	conn, err := transport.Dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if err = gob.NewEncoder(conn).Encode(Request{Version: ProtocolVersion, Names: names}); err != nil {
+		return nil, err
+	}
+	var delta Delta
+	if err = gob.NewDecoder(conn).Decode(&delta); err != nil {
+		return nil, err
+	}
+	return &delta, nil
+}
+
+// StreamClient subscribes to proxy state over a connection created by the transport.
+// The server pushes a delta whenever the status of a registered proxy changes.
+type StreamClient struct {
+	transport Transport
+	payload   func() []string
+	ch        chan struct{}
+	cb        func([]ProxyMessage)
+}
+
+// NewClient creates a client which registers the proxy names returned by payload
+// to the server listening on the given transport.
+func NewClient(transport Transport, payload func() []string) *StreamClient {
+	return &StreamClient{
+		transport: transport,
+		payload:   payload,
+		ch:        make(chan struct{}, 1),
+	}
+}
+
+func (p *StreamClient) SetCallback(cb func([]ProxyMessage)) {
+	p.cb = cb
+}
+
+func (p *StreamClient) Run(ctx context.Context) {
+	conn, err := p.transport.Dial(ctx)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	enc := gob.NewEncoder(conn)
+	names := make(chan []string, 1)
+	subscribe := func() error {
+		req := Request{Version: ProtocolVersion, Names: p.payload()}
+		// Only the latest names are needed to order the state.
+		select {
+		case <-names:
+		default:
+		}
+		names <- req.Names
+		return enc.Encode(req)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		dec := gob.NewDecoder(conn)
+		state := make(map[string]ProxyMessage)
+		var order []string
+		for {
+			var delta Delta
+			if err := dec.Decode(&delta); err != nil {
+				return
+			}
+			if delta.Full {
+				clear(state)
+				select {
+				case order = <-names:
+				default:
+				}
+			}
+			for _, m := range delta.Updated {
+				state[m.Name] = m
+			}
+			for _, name := range delta.Removed {
+				delete(state, name)
+			}
+			if p.cb != nil {
+				msg := make([]ProxyMessage, 0, len(state))
+				for _, name := range order {
+					if m, ok := state[name]; ok {
+						msg = append(msg, m)
+					}
+				}
+				p.cb(msg)
+			}
+		}
+	}()
+	if err = subscribe(); err != nil {
+		return
+	}
+	for {
+		select {
+		case <-p.ch:
+			if err = subscribe(); err != nil {
+				return
+			}
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Probe registers the proxy names again. The server replies with the full status immediately.
+func (p *StreamClient) Probe(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		return
+	case p.ch <- struct{}{}:
+	default:
+		return
+	}
+}
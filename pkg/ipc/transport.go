@@ -0,0 +1,72 @@
+package ipc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// Transport creates the connections used to exchange status messages.
+type Transport interface {
+	// Listen announces on the local address of this transport.
+	Listen() (net.Listener, error)
+	// Dial connects to the local address of this transport.
+	Dial(ctx context.Context) (net.Conn, error)
+}
+
+// UnixTransport uses a Unix domain socket at the given path.
+type UnixTransport struct {
+	Path string
+}
+
+func NewUnixTransport(path string) *UnixTransport {
+	return &UnixTransport{Path: path}
+}
+
+func (t *UnixTransport) Listen() (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(t.Path), 0700); err != nil {
+		return nil, err
+	}
+	// Remove the stale socket left by a previous process.
+	os.Remove(t.Path)
+	return net.Listen("unix", t.Path)
+}
+
+func (t *UnixTransport) Dial(ctx context.Context) (net.Conn, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, "unix", t.Path)
+}
+
+// TCPTransport uses a TCP connection on the loopback interface.
+type TCPTransport struct {
+	// Addr is the loopback address to listen on or connect to.
+	// If the port is zero, it's updated to the chosen port after listening.
+	Addr string
+}
+
+func NewTCPTransport(addr string) *TCPTransport {
+	return &TCPTransport{Addr: addr}
+}
+
+func (t *TCPTransport) Listen() (net.Listener, error) {
+	host, _, err := net.SplitHostPort(t.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, fmt.Errorf("ipc: %s is not a loopback address", t.Addr)
+	}
+	l, err := net.Listen("tcp", t.Addr)
+	if err != nil {
+		return nil, err
+	}
+	t.Addr = l.Addr().String()
+	return l, nil
+}
+
+func (t *TCPTransport) Dial(ctx context.Context) (net.Conn, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", t.Addr)
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package ipc
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// NewTransport returns the default transport of the given service name.
+// On non-Windows hosts, it's a Unix domain socket in the temporary directory.
+func NewTransport(name string) Transport {
+	return NewUnixTransport(filepath.Join(os.TempDir(), "frpmgr", name+".sock"))
+}
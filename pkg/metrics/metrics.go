@@ -0,0 +1,152 @@
+// Package metrics exports the status of frp clients in the Prometheus text format.
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fatedier/frp/client"
+	"github.com/fatedier/frp/client/proxy"
+
+	"github.com/koho/frpmgr/pkg/ipc"
+)
+
+// Phases are all proxy phases exported as a state set.
+var Phases = []string{
+	proxy.ProxyPhaseNew,
+	proxy.ProxyPhaseWaitStart,
+	proxy.ProxyPhaseStartErr,
+	proxy.ProxyPhaseRunning,
+	proxy.ProxyPhaseCheckFailed,
+	proxy.ProxyPhaseClosed,
+}
+
+// Target is a config whose metrics are collected.
+type Target struct {
+	ID   string
+	Name string
+	// Proxies are the names of proxies to export.
+	Proxies []string
+	// Exporter provides the proxy status of a running config. It's nil if the config is not running.
+	// Statistics are exported if it also implements ipc.StatsExporter or ipc.ServiceExporter.
+	Exporter client.StatusExporter
+	// Expiry is the remaining time before the config is deleted, or nil if there is no deadline.
+	Expiry *time.Duration
+}
+
+type sample struct {
+	labels []string
+	value  float64
+}
+
+type family struct {
+	name    string
+	help    string
+	typ     string
+	samples []sample
+}
+
+func (f *family) add(value float64, labels ...string) {
+	f.samples = append(f.samples, sample{labels, value})
+}
+
+// Write writes the metrics of targets in the Prometheus text exposition format.
+func Write(w io.Writer, targets []Target, now time.Time) error {
+	var (
+		up         = &family{name: "frpmgr_config_up", help: "Whether the service of the config is running.", typ: "gauge"}
+		uptime     = &family{name: "frpmgr_config_uptime_seconds", help: "Time since the service of the config started.", typ: "gauge"}
+		reloads    = &family{name: "frpmgr_config_reloads_total", help: "Number of successful reloads of the service.", typ: "counter"}
+		expiry     = &family{name: "frpmgr_config_expiry_seconds", help: "Time left before the config is deleted.", typ: "gauge"}
+		phase      = &family{name: "frpmgr_proxy_phase", help: "Current phase of the proxy.", typ: "gauge"}
+		errs       = &family{name: "frpmgr_proxy_errors_total", help: "Number of times the proxy failed to start or failed the health check.", typ: "counter"}
+		retries    = &family{name: "frpmgr_proxy_retries_total", help: "Number of times the proxy has been registered again.", typ: "counter"}
+		bytesIn    = &family{name: "frpmgr_proxy_received_bytes_total", help: "Number of bytes received from the server.", typ: "counter"}
+		bytesOut   = &family{name: "frpmgr_proxy_sent_bytes_total", help: "Number of bytes sent to the server.", typ: "counter"}
+		conns      = &family{name: "frpmgr_proxy_connections", help: "Number of open work connections.", typ: "gauge"}
+		totalConns = &family{name: "frpmgr_proxy_connections_total", help: "Number of work connections since the service started.", typ: "counter"}
+	)
+	for _, t := range targets {
+		labels := []string{"id", t.ID, "config", t.Name}
+		if t.Expiry != nil {
+			expiry.add(t.Expiry.Seconds(), labels...)
+		}
+		if t.Exporter == nil {
+			up.add(0, labels...)
+			continue
+		}
+		up.add(1, labels...)
+		if se, ok := t.Exporter.(ipc.ServiceExporter); ok {
+			stats := se.GetServiceStats()
+			if !stats.StartTime.IsZero() {
+				uptime.add(now.Sub(stats.StartTime).Seconds(), labels...)
+			}
+			reloads.add(float64(stats.Reloads), labels...)
+		}
+		pe, _ := t.Exporter.(ipc.StatsExporter)
+		for _, name := range t.Proxies {
+			status, ok := t.Exporter.GetProxyStatus(name)
+			if !ok {
+				continue
+			}
+			proxyLabels := append(labels[:len(labels):len(labels)], "proxy", name, "type", status.Type)
+			for _, p := range Phases {
+				phase.add(boolValue(status.Phase == p), append(proxyLabels[:len(proxyLabels):len(proxyLabels)], "phase", p)...)
+			}
+			if pe == nil {
+				continue
+			}
+			if stats, ok := pe.GetProxyStats(name); ok {
+				errs.add(float64(stats.Errors), proxyLabels...)
+				retries.add(float64(stats.Retries), proxyLabels...)
+				if stats.NoTraffic {
+					continue
+				}
+				bytesIn.add(float64(stats.BytesIn), proxyLabels...)
+				bytesOut.add(float64(stats.BytesOut), proxyLabels...)
+				conns.add(float64(stats.CurConns), proxyLabels...)
+				totalConns.add(float64(stats.TotalConns), proxyLabels...)
+			}
+		}
+	}
+	bw := bufio.NewWriter(w)
+	for _, f := range []*family{up, uptime, reloads, expiry, phase, errs, retries, bytesIn, bytesOut, conns, totalConns} {
+		if len(f.samples) == 0 {
+			continue
+		}
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.typ)
+		for _, s := range f.samples {
+			bw.WriteString(f.name)
+			bw.WriteByte('{')
+			for i := 0; i < len(s.labels); i += 2 {
+				if i > 0 {
+					bw.WriteByte(',')
+				}
+				fmt.Fprintf(bw, "%s=\"%s\"", s.labels[i], labelEscaper.Replace(s.labels[i+1]))
+			}
+			fmt.Fprintf(bw, "} %g\n", s.value)
+		}
+	}
+	return bw.Flush()
+}
+
+// Handler returns an HTTP handler which writes the metrics of targets returned by gather.
+func Handler(gather func(ctx context.Context) []Target) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		Write(w, gather(r.Context()), time.Now())
+	})
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
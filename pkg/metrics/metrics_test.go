@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatedier/frp/client/proxy"
+
+	"github.com/koho/frpmgr/pkg/ipc"
+)
+
+type fakeExporter map[string]proxy.WorkingStatus
+
+func (f fakeExporter) GetProxyStatus(name string) (*proxy.WorkingStatus, bool) {
+	status, ok := f[name]
+	if !ok {
+		return nil, false
+	}
+	return &status, true
+}
+
+type fakeStatsExporter struct {
+	fakeExporter
+	stats   map[string]ipc.ProxyStats
+	service ipc.ServiceStats
+}
+
+func (f fakeStatsExporter) GetProxyStats(name string) (ipc.ProxyStats, bool) {
+	stats, ok := f.stats[name]
+	return stats, ok
+}
+
+func (f fakeStatsExporter) GetServiceStats() ipc.ServiceStats {
+	return f.service
+}
+
+func TestWrite(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	expiry := 90 * time.Second
+	targets := []Target{
+		{ID: "a", Name: "plain", Proxies: []string{"ssh", "missing"}, Exporter: fakeExporter{
+			"ssh": {Name: "ssh", Type: "tcp", Phase: proxy.ProxyPhaseRunning},
+		}},
+		{ID: "b", Name: `quote"d`, Proxies: []string{"web", "dns"}, Exporter: fakeStatsExporter{
+			fakeExporter: fakeExporter{
+				"web": {Name: "web", Type: "http", Phase: proxy.ProxyPhaseStartErr},
+				"dns": {Name: "dns", Type: "udp", Phase: proxy.ProxyPhaseRunning},
+			},
+			stats: map[string]ipc.ProxyStats{
+				"web": {BytesIn: 10, BytesOut: 20, CurConns: 1, TotalConns: 5, Retries: 2, Errors: 3},
+				"dns": {Retries: 1, NoTraffic: true},
+			},
+			service: ipc.ServiceStats{StartTime: now.Add(-time.Hour), Reloads: 4},
+		}},
+		{ID: "c", Name: "stopped", Expiry: &expiry},
+	}
+	expected := `# HELP frpmgr_config_up Whether the service of the config is running.
+# TYPE frpmgr_config_up gauge
+frpmgr_config_up{id="a",config="plain"} 1
+frpmgr_config_up{id="b",config="quote\"d"} 1
+frpmgr_config_up{id="c",config="stopped"} 0
+# HELP frpmgr_config_uptime_seconds Time since the service of the config started.
+# TYPE frpmgr_config_uptime_seconds gauge
+frpmgr_config_uptime_seconds{id="b",config="quote\"d"} 3600
+# HELP frpmgr_config_reloads_total Number of successful reloads of the service.
+# TYPE frpmgr_config_reloads_total counter
+frpmgr_config_reloads_total{id="b",config="quote\"d"} 4
+# HELP frpmgr_config_expiry_seconds Time left before the config is deleted.
+# TYPE frpmgr_config_expiry_seconds gauge
+frpmgr_config_expiry_seconds{id="c",config="stopped"} 90
+# HELP frpmgr_proxy_phase Current phase of the proxy.
+# TYPE frpmgr_proxy_phase gauge
+frpmgr_proxy_phase{id="a",config="plain",proxy="ssh",type="tcp",phase="new"} 0
+frpmgr_proxy_phase{id="a",config="plain",proxy="ssh",type="tcp",phase="wait start"} 0
+frpmgr_proxy_phase{id="a",config="plain",proxy="ssh",type="tcp",phase="start error"} 0
+frpmgr_proxy_phase{id="a",config="plain",proxy="ssh",type="tcp",phase="running"} 1
+frpmgr_proxy_phase{id="a",config="plain",proxy="ssh",type="tcp",phase="check failed"} 0
+frpmgr_proxy_phase{id="a",config="plain",proxy="ssh",type="tcp",phase="closed"} 0
+frpmgr_proxy_phase{id="b",config="quote\"d",proxy="web",type="http",phase="new"} 0
+frpmgr_proxy_phase{id="b",config="quote\"d",proxy="web",type="http",phase="wait start"} 0
+frpmgr_proxy_phase{id="b",config="quote\"d",proxy="web",type="http",phase="start error"} 1
+frpmgr_proxy_phase{id="b",config="quote\"d",proxy="web",type="http",phase="running"} 0
+frpmgr_proxy_phase{id="b",config="quote\"d",proxy="web",type="http",phase="check failed"} 0
+frpmgr_proxy_phase{id="b",config="quote\"d",proxy="web",type="http",phase="closed"} 0
+frpmgr_proxy_phase{id="b",config="quote\"d",proxy="dns",type="udp",phase="new"} 0
+frpmgr_proxy_phase{id="b",config="quote\"d",proxy="dns",type="udp",phase="wait start"} 0
+frpmgr_proxy_phase{id="b",config="quote\"d",proxy="dns",type="udp",phase="start error"} 0
+frpmgr_proxy_phase{id="b",config="quote\"d",proxy="dns",type="udp",phase="running"} 1
+frpmgr_proxy_phase{id="b",config="quote\"d",proxy="dns",type="udp",phase="check failed"} 0
+frpmgr_proxy_phase{id="b",config="quote\"d",proxy="dns",type="udp",phase="closed"} 0
+# HELP frpmgr_proxy_errors_total Number of times the proxy failed to start or failed the health check.
+# TYPE frpmgr_proxy_errors_total counter
+frpmgr_proxy_errors_total{id="b",config="quote\"d",proxy="web",type="http"} 3
+frpmgr_proxy_errors_total{id="b",config="quote\"d",proxy="dns",type="udp"} 0
+# HELP frpmgr_proxy_retries_total Number of times the proxy has been registered again.
+# TYPE frpmgr_proxy_retries_total counter
+frpmgr_proxy_retries_total{id="b",config="quote\"d",proxy="web",type="http"} 2
+frpmgr_proxy_retries_total{id="b",config="quote\"d",proxy="dns",type="udp"} 1
+# HELP frpmgr_proxy_received_bytes_total Number of bytes received from the server.
+# TYPE frpmgr_proxy_received_bytes_total counter
+frpmgr_proxy_received_bytes_total{id="b",config="quote\"d",proxy="web",type="http"} 10
+# HELP frpmgr_proxy_sent_bytes_total Number of bytes sent to the server.
+# TYPE frpmgr_proxy_sent_bytes_total counter
+frpmgr_proxy_sent_bytes_total{id="b",config="quote\"d",proxy="web",type="http"} 20
+# HELP frpmgr_proxy_connections Number of open work connections.
+# TYPE frpmgr_proxy_connections gauge
+frpmgr_proxy_connections{id="b",config="quote\"d",proxy="web",type="http"} 1
+# HELP frpmgr_proxy_connections_total Number of work connections since the service started.
+# TYPE frpmgr_proxy_connections_total counter
+frpmgr_proxy_connections_total{id="b",config="quote\"d",proxy="web",type="http"} 5
+`
+	var b strings.Builder
+	if err := Write(&b, targets, now); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != expected {
+		t.Errorf("Expected: %v, got: %v", expected, b.String())
+	}
+}
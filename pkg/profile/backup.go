@@ -0,0 +1,352 @@
+package profile
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/sec"
+	"github.com/koho/frpmgr/pkg/util"
+)
+
+// BackupVersion is the version of the backup format written by Backup.
+const BackupVersion = 1
+
+const (
+	// DefaultBackupInterval is the interval between scheduled backups by default.
+	DefaultBackupInterval = 24 * time.Hour
+	// DefaultBackupRetention is the number of scheduled backups kept by default.
+	DefaultBackupRetention = 7
+)
+
+const (
+	backupManifestFile = "backup.json"
+	backupPrefix       = "frpmgr-backup-"
+	backupTimeLayout   = "20060102-150405"
+)
+
+// ErrInvalidBackup is returned when a file is not a backup of the data root.
+var ErrInvalidBackup = errors.New("invalid backup")
+
+// backupDirs are the directories of the data root included in a backup.
+var backupDirs = []string{ProfileDir, LogDir, StoreDir, HistoryDir, SubscriptionDir}
+
+// BackupManifest describes a backup.
+type BackupManifest struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	// Root is the absolute data root of the backup. The log and store paths
+	// under this directory are moved to the data root that the backup is restored to.
+	Root string `json:"root"`
+}
+
+// Backup writes the whole data root to a ZIP file, including the profiles, logs,
+// store files, history, subscriptions and the application configuration.
+// Log files that can't be read, such as the ones locked by a service, are skipped.
+func (r *Repository) Backup(w io.Writer) error {
+	root, err := filepath.Abs(r.root)
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(w)
+	for _, dir := range backupDirs {
+		err = filepath.WalkDir(filepath.Join(root, dir), func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			b, err := os.ReadFile(name)
+			if err != nil {
+				if dir == LogDir {
+					return nil
+				}
+				return err
+			}
+			rel, err := filepath.Rel(root, name)
+			if err != nil {
+				return err
+			}
+			_, err = writeZipFile(zw, filepath.ToSlash(rel), b)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if b, err := os.ReadFile(r.appFile); err == nil {
+		if _, err = writeZipFile(zw, config.DefaultAppFile, b); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	b, err := json.MarshalIndent(&BackupManifest{
+		Version: BackupVersion,
+		Created: time.Now().UTC().Truncate(time.Second),
+		Root:    filepath.ToSlash(root),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err = writeZipFile(zw, backupManifestFile, b); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// BackupTo writes a backup to a new file in the given directory, and removes the oldest
+// backups in the directory so that at most keep backups are left. Zero keep means
+// DefaultBackupRetention. It returns the path of the new backup.
+func (r *Repository) BackupTo(dir string, keep int) (string, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+	name := filepath.Join(dir, backupPrefix+time.Now().Format(backupTimeLayout)+".zip")
+	f, err := os.CreateTemp(dir, backupPrefix+"*.tmp")
+	if err != nil {
+		return "", err
+	}
+	err = r.Backup(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), name)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	if keep <= 0 {
+		keep = DefaultBackupRetention
+	}
+	if backups, err := Backups(dir); err == nil && len(backups) > keep {
+		for _, old := range backups[:len(backups)-keep] {
+			os.Remove(old)
+		}
+	}
+	return name, nil
+}
+
+// Backups returns the backups written by BackupTo in the given directory, from oldest to newest.
+func Backups(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, backupPrefix+"*.zip"))
+	if err != nil {
+		return nil, err
+	}
+	files = slices.DeleteFunc(files, func(name string) bool {
+		_, ok := backupTime(name)
+		return !ok
+	})
+	slices.Sort(files)
+	return files, nil
+}
+
+// backupTime returns the time of a backup written by BackupTo from its file name.
+func backupTime(name string) (time.Time, bool) {
+	s := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(name), backupPrefix), ".zip")
+	t, err := time.ParseInLocation(backupTimeLayout, s, time.Local)
+	return t, err == nil
+}
+
+// BackupDue writes a backup to the backup directory of the application configuration
+// if the latest backup is older than the interval at the given time. It returns the path
+// of the new backup, or an empty string if scheduled backups are disabled or not due.
+func (r *Repository) BackupDue(now time.Time) (string, error) {
+	bc := r.app.Backup
+	if bc.Dir == "" {
+		return "", nil
+	}
+	interval := time.Duration(bc.Interval) * time.Hour
+	if interval <= 0 {
+		interval = DefaultBackupInterval
+	}
+	backups, err := Backups(bc.Dir)
+	if err != nil {
+		return "", err
+	}
+	if len(backups) > 0 {
+		if last, _ := backupTime(backups[len(backups)-1]); now.Before(last.Add(interval)) {
+			return "", nil
+		}
+	}
+	return r.BackupTo(bc.Dir, bc.Keep)
+}
+
+// Scheduled reports whether backups are scheduled or any profile is subscribed,
+// so the schedules need to run in the background.
+func (r *Repository) Scheduled() bool {
+	if r.app.Backup.Dir != "" {
+		return true
+	}
+	subs, err := r.Subscriptions()
+	return err == nil && len(subs) > 0
+}
+
+// BackupApp returns the application configuration in a backup, or nil if there is none.
+// The master password of the configuration is required to restore a backup with a vault.
+func BackupApp(zr *zip.Reader) (*config.App, error) {
+	data, err := readZipFile(zr, config.DefaultAppFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var app config.App
+	if err = json.Unmarshal(data, &app); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// RestoreBackup extracts a backup into the data root and reloads the application configuration
+// and the vault. The vault of the backup is opened with the given master password of the backup,
+// which is checked before anything is extracted. Files in the data root that are not in the backup
+// are kept. The log and store paths of the restored profiles are moved from the data root of
+// the backup by Relocate. The restored profiles are returned, so the caller can register their
+// services again.
+func (r *Repository) RestoreBackup(zr *zip.Reader, password string) ([]*Profile, error) {
+	app, err := BackupApp(zr)
+	if err != nil {
+		return nil, err
+	}
+	// The current application configuration is kept without one in the backup
+	vault := r.vault
+	if app != nil {
+		if vault, err = app.OpenVault(password); err != nil {
+			return nil, fmt.Errorf("open vault: %w", err)
+		}
+	}
+	return r.restore(zr, vault)
+}
+
+// restore extracts a backup whose secrets are sealed by the given vault.
+func (r *Repository) restore(zr *zip.Reader, vault *sec.Vault) ([]*Profile, error) {
+	var m BackupManifest
+	data, err := readZipFile(zr, backupManifestFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrInvalidBackup
+		}
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	if m.Version < 1 || m.Version > BackupVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidBackup, m.Version)
+	}
+	var ids []string
+	for _, file := range zr.File {
+		dir, _, _ := strings.Cut(file.Name, "/")
+		if file.FileInfo().IsDir() || !fs.ValidPath(file.Name) ||
+			(file.Name != config.DefaultAppFile && !slices.Contains(backupDirs, dir)) {
+			continue
+		}
+		if data, err = readZipFile(zr, file.Name); err != nil {
+			return nil, err
+		}
+		if file.Name == config.DefaultAppFile {
+			var app config.App
+			if err = json.Unmarshal(data, &app); err != nil {
+				return nil, err
+			}
+			// The machine key is protected again, as the backup may come from another machine
+			if app.Vault != nil && vault != nil {
+				if app.Vault.MachineKey, err = vault.Protect(); err != nil {
+					return nil, err
+				}
+			}
+			*r.app = app
+			if err = r.app.Save(r.appFile); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		name := filepath.Join(r.root, filepath.FromSlash(file.Name))
+		if err = os.MkdirAll(filepath.Dir(name), os.ModePerm); err != nil {
+			return nil, err
+		}
+		if err = os.WriteFile(name, data, 0666); err != nil {
+			// Logs may be locked by running services
+			if dir == LogDir {
+				continue
+			}
+			return nil, err
+		}
+		if dir == ProfileDir && path.Dir(file.Name) == ProfileDir && path.Ext(file.Name) == Ext {
+			ids = append(ids, strings.TrimSuffix(path.Base(file.Name), Ext))
+		}
+	}
+	if err = r.Relocate(m.Root); err != nil {
+		return nil, err
+	}
+	r.vault = vault
+	list := make([]*Profile, 0, len(ids))
+	for _, id := range ids {
+		p, err := r.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, p)
+	}
+	r.sort(list)
+	return list, nil
+}
+
+// Relocate moves the log and store paths of all profiles from the old data root to the
+// current data root. Other paths are kept, and the files are not changed otherwise.
+// Secrets are not decrypted, so the profiles can be relocated without the vault.
+func (r *Repository) Relocate(oldRoot string) error {
+	root, err := filepath.Abs(r.root)
+	if err != nil {
+		return err
+	}
+	oldPrefix := strings.TrimSuffix(filepath.ToSlash(oldRoot), "/") + "/"
+	newPrefix := strings.TrimSuffix(filepath.ToSlash(root), "/") + "/"
+	if strings.EqualFold(oldPrefix, newPrefix) {
+		return nil
+	}
+	remap := func(p string) (string, bool) {
+		if len(p) > len(oldPrefix) && strings.EqualFold(p[:len(oldPrefix)], oldPrefix) {
+			return newPrefix + p[len(oldPrefix):], true
+		}
+		return p, false
+	}
+	files, err := filepath.Glob(filepath.Join(r.Dir(), "*"+Ext))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		data, err := config.UnmarshalClientConf(f)
+		if err != nil {
+			continue
+		}
+		var logMoved, storeMoved bool
+		data.LogFile, logMoved = remap(data.LogFile)
+		data.Store.Path, storeMoved = remap(data.Store.Path)
+		if !logMoved && !storeMoved {
+			continue
+		}
+		if err = data.SavePreserve(f); err != nil {
+			return &Error{"relocate", util.FileNameWithoutExt(f), err}
+		}
+	}
+	return nil
+}
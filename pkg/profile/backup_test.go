@@ -0,0 +1,166 @@
+package profile
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/sec"
+)
+
+func TestBackup(t *testing.T) {
+	app := config.App{Defaults: config.DefaultValue{User: "alice"}}
+	src := NewRepository(t.TempDir(), &app)
+	var ids []string
+	for _, name := range []string{"a", "b"} {
+		conf := newTestConfig(name)
+		conf.ManualStart = name == "b"
+		p, err := src.Create(conf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, p.ID())
+	}
+	if err := src.Reorder([]string{ids[1], ids[0]}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{filepath.Join(LogDir, ids[0]+".log"), filepath.Join(StoreDir, ids[0]+".json")} {
+		if err := os.MkdirAll(filepath.Join(src.Root(), filepath.Dir(name)), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(src.Root(), name), []byte(name), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := src.Backup(&buf); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dstApp := config.App{}
+	dst := NewRepository(t.TempDir(), &dstApp)
+	list, err := dst.RestoreBackup(zr, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored := lo.Map(list, func(p *Profile, i int) string { return p.ID() }); !reflect.DeepEqual(restored, []string{ids[1], ids[0]}) {
+		t.Errorf("Expected: %v, got: %v", []string{ids[1], ids[0]}, restored)
+	}
+	if !list[0].Data.ManualStart || list[1].Data.ManualStart {
+		t.Errorf("Expected: %v, got: %v, %v", "b is started manually", list[0].Data.ManualStart, list[1].Data.ManualStart)
+	}
+	if dstApp.Defaults.User != "alice" {
+		t.Errorf("Expected: %v, got: %v", "alice", dstApp.Defaults.User)
+	}
+	// The log and store paths are moved to the new data root
+	root, _ := filepath.Abs(dst.Root())
+	for _, p := range list {
+		for _, path := range []string{p.Data.LogFile, p.Data.Store.Path} {
+			if !strings.HasPrefix(path, filepath.ToSlash(root)+"/") {
+				t.Errorf("Expected: %v, got: %v", "path in "+root, path)
+			}
+		}
+	}
+	if b, err := os.ReadFile(filepath.Join(dst.Root(), LogDir, ids[0]+".log")); err != nil || string(b) != filepath.Join(LogDir, ids[0]+".log") {
+		t.Errorf("Expected: %v, got: %v, %v", "restored log", string(b), err)
+	}
+	if revs, _ := dst.Revisions(ids[0]); len(revs) != 1 {
+		t.Errorf("Expected: %v, got: %v", 1, len(revs))
+	}
+
+	// A bundle is not a backup
+	buf.Reset()
+	if err = src.Export(&buf, list, ExportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	zr, _ = zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if _, err = dst.RestoreBackup(zr, ""); !errors.Is(err, ErrInvalidBackup) {
+		t.Errorf("Expected: %v, got: %v", ErrInvalidBackup, err)
+	}
+}
+
+func TestRestoreBackupPassword(t *testing.T) {
+	v, err := sec.NewVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := v.Wrap("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := config.App{Vault: &config.VaultConfig{Key: key}}
+	src := NewRepository(t.TempDir(), &app)
+	src.SetVault(v)
+	if _, err = src.Create(newTestConfig("a")); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = src.Backup(&buf); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backupApp, err := BackupApp(zr); err != nil || backupApp.Vault == nil {
+		t.Errorf("Expected: %v, got: %v, %v", "vault in backup", backupApp, err)
+	}
+	// Nothing is extracted with a wrong password
+	dst := NewRepository(t.TempDir(), &config.App{})
+	if _, err = dst.RestoreBackup(zr, "wrong"); !errors.Is(err, sec.ErrWrongPassword) {
+		t.Errorf("Expected: %v, got: %v", sec.ErrWrongPassword, err)
+	}
+	if list, _ := dst.List(); len(list) != 0 {
+		t.Errorf("Expected: %v, got: %v", 0, len(list))
+	}
+}
+
+func TestBackupSchedule(t *testing.T) {
+	dir := t.TempDir()
+	app := config.App{}
+	repo := NewRepository(t.TempDir(), &app)
+	if _, err := repo.Create(newTestConfig("a")); err != nil {
+		t.Fatal(err)
+	}
+	if name, err := repo.BackupDue(time.Now()); err != nil || name != "" {
+		t.Errorf("Expected: %v, got: %v, %v", "disabled", name, err)
+	}
+	if repo.Scheduled() {
+		t.Errorf("Expected: %v, got: %v", false, true)
+	}
+	app.Backup = config.BackupConfig{Dir: dir, Interval: 1, Keep: 2}
+	// Old backups are removed
+	for _, s := range []string{"20250101-000000", "20250102-000000", "20250103-000000"} {
+		if err := os.WriteFile(filepath.Join(dir, backupPrefix+s+".zip"), nil, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !repo.Scheduled() {
+		t.Errorf("Expected: %v, got: %v", true, false)
+	}
+	name, err := repo.BackupDue(time.Now())
+	if err != nil || name == "" {
+		t.Fatalf("Expected: %v, got: %v, %v", "a backup", name, err)
+	}
+	backups, _ := Backups(dir)
+	if expected := []string{filepath.Join(dir, backupPrefix+"20250103-000000.zip"), name}; !reflect.DeepEqual(backups, expected) {
+		t.Errorf("Expected: %v, got: %v", expected, backups)
+	}
+	// Not due within the interval
+	if name, err = repo.BackupDue(time.Now().Add(30 * time.Minute)); err != nil || name != "" {
+		t.Errorf("Expected: %v, got: %v, %v", "not due", name, err)
+	}
+}
@@ -0,0 +1,403 @@
+package profile
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/util"
+)
+
+// BundleVersion is the version of the bundle format written by Export.
+const BundleVersion = 1
+
+// ManifestFile is the name of the manifest in a bundle.
+const ManifestFile = "manifest.json"
+
+// ErrInvalidBundle is returned when the manifest of a bundle can't be read or a file
+// of a bundle does not match its checksum.
+var ErrInvalidBundle = errors.New("invalid bundle")
+
+// configExts are the file extensions of configs in a ZIP file without a manifest.
+var configExts = []string{".ini", ".toml", ".json", ".yml", ".yaml"}
+
+// Manifest describes the content of a bundle.
+type Manifest struct {
+	Version  int           `json:"version"`
+	Created  time.Time     `json:"created"`
+	Profiles []BundleEntry `json:"profiles"`
+	// App is the file of the default values of the application configuration, if it's included.
+	App string `json:"app,omitempty"`
+	// Sealed reports whether the secrets in configs are encrypted by the vault of the exporter.
+	Sealed bool `json:"sealed,omitempty"`
+}
+
+// BundleEntry describes a profile in a bundle.
+type BundleEntry struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Order is the position of the profile in the saved order.
+	Order int `json:"order"`
+	// Format of the config file, e.g. "toml".
+	Format string `json:"format"`
+	// File is the config file in the bundle, and SHA256 is its hex checksum.
+	File   string `json:"file"`
+	SHA256 string `json:"sha256"`
+	// Store is the store file of frp in the bundle, if it's included.
+	Store       string `json:"store,omitempty"`
+	StoreSHA256 string `json:"storeSha256,omitempty"`
+}
+
+// ExportOptions configures the content of a bundle.
+type ExportOptions struct {
+	// App includes the default values of the application configuration.
+	App bool
+	// Stores includes the store files of frp.
+	Stores bool
+	// Plain decrypts the secrets encrypted by the vault, so the bundle can be imported on another computer.
+	Plain bool
+}
+
+// Export writes the given profiles to a ZIP bundle with a manifest. The config files are placed
+// at the root of the archive and named after the profiles. Comments and unknown keys of the
+// config files are kept.
+func (r *Repository) Export(w io.Writer, list []*Profile, opts ExportOptions) error {
+	m := Manifest{
+		Version:  BundleVersion,
+		Created:  time.Now().UTC().Truncate(time.Second),
+		Profiles: make([]BundleEntry, 0, len(list)),
+		Sealed:   r.vault != nil && !opts.Plain,
+	}
+	var tempDir string
+	if r.vault != nil && opts.Plain {
+		var err error
+		if tempDir, err = os.MkdirTemp("", "frpmgr"); err != nil {
+			return err
+		}
+		defer os.RemoveAll(tempDir)
+	}
+	zw := zip.NewWriter(w)
+	names := make(map[string]int)
+	for i, p := range list {
+		src := p.Path
+		if tempDir != "" {
+			var err error
+			if src, err = r.ExportPlain(p, tempDir); err != nil {
+				return err
+			}
+		}
+		b, err := os.ReadFile(src)
+		if err != nil {
+			return &Error{"export", p.ID(), err}
+		}
+		name := p.Name()
+		if strings.ContainsAny(name, `/\`) {
+			name = p.ID()
+		}
+		if names[name]++; names[name] > 1 {
+			name = fmt.Sprintf("%s_%d", name, names[name])
+		}
+		e := BundleEntry{
+			ID:     p.ID(),
+			Name:   p.Name(),
+			Order:  i,
+			Format: strings.TrimPrefix(p.Data.Ext(), "."),
+			File:   name + p.Data.Ext(),
+		}
+		if e.SHA256, err = writeZipFile(zw, e.File, b); err != nil {
+			return err
+		}
+		if opts.Stores {
+			if b, err = os.ReadFile(filepath.Join(r.root, StoreDir, p.ID()+".json")); err == nil {
+				e.Store = path.Join(StoreDir, p.ID()+".json")
+				if e.StoreSHA256, err = writeZipFile(zw, e.Store, b); err != nil {
+					return err
+				}
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return &Error{"export", p.ID(), err}
+			}
+		}
+		m.Profiles = append(m.Profiles, e)
+	}
+	if opts.App {
+		b, err := json.MarshalIndent(bundleApp{r.app.Defaults}, "", "  ")
+		if err != nil {
+			return err
+		}
+		m.App = config.DefaultAppFile
+		if _, err = writeZipFile(zw, m.App, b); err != nil {
+			return err
+		}
+	}
+	b, err := json.MarshalIndent(&m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err = writeZipFile(zw, ManifestFile, b); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// bundleApp is the part of the application configuration included in a bundle.
+type bundleApp struct {
+	Defaults config.DefaultValue `json:"defaults"`
+}
+
+func writeZipFile(zw *zip.Writer, name string, b []byte) (string, error) {
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return "", err
+	}
+	if _, err = fw.Write(b); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Bundle is a ZIP file of profiles opened for import.
+type Bundle struct {
+	Manifest Manifest
+	zr       *zip.Reader
+}
+
+// OpenBundle reads the manifest of a bundle. A ZIP file without a manifest is taken as a bundle
+// of the config files in it, in the order of the archive, without checksums.
+func OpenBundle(zr *zip.Reader) (*Bundle, error) {
+	b := &Bundle{zr: zr}
+	if data, err := readZipFile(zr, ManifestFile); err == nil {
+		if err = json.Unmarshal(data, &b.Manifest); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+		}
+		if b.Manifest.Version < 1 || b.Manifest.Version > BundleVersion {
+			return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidBundle, b.Manifest.Version)
+		}
+		slices.SortStableFunc(b.Manifest.Profiles, func(a, b BundleEntry) int { return a.Order - b.Order })
+		return b, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, file := range zr.File {
+		ext := strings.ToLower(path.Ext(file.Name))
+		if file.FileInfo().IsDir() || !slices.Contains(configExts, ext) {
+			continue
+		}
+		b.Manifest.Profiles = append(b.Manifest.Profiles, BundleEntry{
+			Name:   util.FileNameWithoutExt(file.Name),
+			Order:  len(b.Manifest.Profiles),
+			Format: strings.TrimPrefix(ext, "."),
+			File:   file.Name,
+		})
+	}
+	return b, nil
+}
+
+// Config reads and parses the config file of an entry after verifying its checksum.
+// An empty name of the config is replaced by the name of the entry.
+func (b *Bundle) Config(e *BundleEntry) (*config.ClientConfig, error) {
+	data, err := b.read(e.File, e.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	conf, err := config.UnmarshalClientConf(data)
+	if err != nil {
+		return nil, err
+	}
+	if conf.Name() == "" {
+		conf.ClientCommon.Name = e.Name
+	}
+	return conf, nil
+}
+
+// Defaults returns the default values of the application configuration in the bundle,
+// or nil if they're not included.
+func (b *Bundle) Defaults() (*config.DefaultValue, error) {
+	if b.Manifest.App == "" {
+		return nil, nil
+	}
+	data, err := b.read(b.Manifest.App, "")
+	if err != nil {
+		return nil, err
+	}
+	var app bundleApp
+	if err = json.Unmarshal(data, &app); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	return &app.Defaults, nil
+}
+
+func (b *Bundle) read(name, checksum string) ([]byte, error) {
+	data, err := readZipFile(b.zr, name)
+	if err != nil {
+		return nil, err
+	}
+	if checksum != "" {
+		if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != strings.ToLower(checksum) {
+			return nil, fmt.Errorf("%w: checksum mismatch of %s", ErrInvalidBundle, name)
+		}
+	}
+	return data, nil
+}
+
+func readZipFile(zr *zip.Reader, name string) ([]byte, error) {
+	fr, err := zr.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fr.Close()
+	return io.ReadAll(fr)
+}
+
+// ImportStrategy defines how a profile is imported when a profile with the same name exists.
+type ImportStrategy string
+
+const (
+	// ImportDuplicate imports the profile as a new profile with the same name.
+	ImportDuplicate ImportStrategy = "duplicate"
+	// ImportSkip keeps the existing profile and skips the imported one.
+	ImportSkip ImportStrategy = "skip"
+	// ImportOverwrite replaces the config of the existing profile. The identifier,
+	// position and history of the existing profile are kept.
+	ImportOverwrite ImportStrategy = "overwrite"
+)
+
+// ImportStatus is the result of importing a file.
+type ImportStatus string
+
+const (
+	ImportCreated     ImportStatus = "created"
+	ImportOverwritten ImportStatus = "overwritten"
+	ImportSkipped     ImportStatus = "skipped"
+	ImportFailed      ImportStatus = "failed"
+)
+
+// ImportOptions configures the import of a bundle.
+type ImportOptions struct {
+	// Strategy for name collisions. An empty value means ImportDuplicate.
+	Strategy ImportStrategy
+	// App replaces the default values of the application configuration with the ones in the bundle.
+	App bool
+	// Stores restores the store files of frp.
+	Stores bool
+}
+
+// ImportResult is the result of importing a file of a bundle.
+type ImportResult struct {
+	File   string
+	Name   string
+	Status ImportStatus
+	// Profile is the created or overwritten profile, or the existing profile if it's skipped.
+	Profile *Profile
+	Err     error
+}
+
+// Import imports the profiles of a bundle in the order of the manifest. The identifiers in the
+// manifest are kept unless they're taken by other profiles. A failed file does not stop the import,
+// and the results of all files are returned. The returned error is only about the application defaults.
+func (r *Repository) Import(b *Bundle, opts ImportOptions) ([]ImportResult, error) {
+	switch opts.Strategy {
+	case "", ImportDuplicate, ImportSkip, ImportOverwrite:
+	default:
+		return nil, fmt.Errorf("invalid import strategy %q", opts.Strategy)
+	}
+	list, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]ImportResult, 0, len(b.Manifest.Profiles))
+	for i := range b.Manifest.Profiles {
+		e := &b.Manifest.Profiles[i]
+		result := ImportResult{File: e.File, Name: e.Name}
+		result.Status, result.Profile, result.Err = r.importEntry(b, e, list, opts)
+		if result.Profile != nil {
+			result.Name = result.Profile.Name()
+			if result.Status == ImportCreated {
+				list = append(list, result.Profile)
+			}
+		}
+		results = append(results, result)
+	}
+	if opts.App {
+		defaults, err := b.Defaults()
+		if err != nil {
+			return results, err
+		}
+		if defaults != nil {
+			r.app.Defaults = *defaults
+			if err = r.app.Save(r.appFile); err != nil {
+				return results, err
+			}
+		}
+	}
+	return results, nil
+}
+
+func (r *Repository) importEntry(b *Bundle, e *BundleEntry, list []*Profile, opts ImportOptions) (ImportStatus, *Profile, error) {
+	data, err := b.Config(e)
+	if err != nil {
+		return ImportFailed, nil, err
+	}
+	if b.Manifest.Sealed {
+		if r.vault == nil {
+			return ImportFailed, nil, errors.New("secrets are encrypted by a vault")
+		}
+		if err = r.open(data); err != nil {
+			return ImportFailed, nil, err
+		}
+	}
+	status := ImportCreated
+	var p *Profile
+	if i := slices.IndexFunc(list, func(item *Profile) bool { return item.Name() == data.Name() }); i >= 0 {
+		switch opts.Strategy {
+		case ImportSkip:
+			return ImportSkipped, list[i], nil
+		case ImportOverwrite:
+			p, status = &Profile{Path: list[i].Path, Data: data}, ImportOverwritten
+		}
+	}
+	if p == nil {
+		// Keep the identifier of the exporter if it's free
+		path := ""
+		if validateID(e.ID) == nil && !slices.ContainsFunc(list, func(item *Profile) bool { return item.ID() == e.ID }) {
+			if _, err = os.Stat(r.PathOf(e.ID)); errors.Is(err, os.ErrNotExist) {
+				path = r.PathOf(e.ID)
+			}
+		}
+		if p, err = r.NewProfile(path, data); err != nil {
+			return ImportFailed, nil, err
+		}
+	}
+	if opts.Stores && e.Store != "" {
+		store, err := b.read(e.Store, e.StoreSHA256)
+		if err != nil {
+			return ImportFailed, nil, err
+		}
+		if err = os.MkdirAll(filepath.Join(r.root, StoreDir), os.ModePerm); err != nil {
+			return ImportFailed, nil, err
+		}
+		if err = os.WriteFile(filepath.Join(r.root, StoreDir, p.ID()+".json"), store, 0666); err != nil {
+			return ImportFailed, nil, err
+		}
+	}
+	if status == ImportOverwritten {
+		err = r.Update(p)
+	} else {
+		err = r.create(p)
+	}
+	if err != nil {
+		return ImportFailed, nil, err
+	}
+	return status, p, nil
+}
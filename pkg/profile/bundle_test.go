@@ -0,0 +1,177 @@
+package profile
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/samber/lo"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/sec"
+)
+
+func openTestBundle(t *testing.T, b []byte) *Bundle {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := OpenBundle(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bundle
+}
+
+func TestBundle(t *testing.T) {
+	app := config.App{Defaults: config.DefaultValue{User: "alice", LogLevel: "debug"}}
+	src := NewRepository(t.TempDir(), &app)
+	var list []*Profile
+	for _, name := range []string{"a", "b", "c"} {
+		p, err := src.Create(newTestConfig(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		list = append(list, p)
+	}
+	if err := src.Reorder([]string{list[2].ID(), list[0].ID(), list[1].ID()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(src.Root(), StoreDir), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src.Root(), StoreDir, list[0].ID()+".json"), []byte(`{"proxies":[]}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	list, _ = src.List()
+	var buf bytes.Buffer
+	if err := src.Export(&buf, list, ExportOptions{App: true, Stores: true}); err != nil {
+		t.Fatal(err)
+	}
+	bundle := openTestBundle(t, buf.Bytes())
+	if names := lo.Map(bundle.Manifest.Profiles, func(e BundleEntry, i int) string { return e.Name }); !reflect.DeepEqual(names, []string{"c", "a", "b"}) {
+		t.Errorf("Expected: %v, got: %v", []string{"c", "a", "b"}, names)
+	}
+
+	// The order, identifiers, defaults and stores are restored
+	dstApp := config.App{}
+	dst := NewRepository(t.TempDir(), &dstApp)
+	results, err := dst.Import(bundle, ImportOptions{App: true, Stores: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statuses := lo.Map(results, func(r ImportResult, i int) ImportStatus { return r.Status }); !reflect.DeepEqual(statuses, []ImportStatus{ImportCreated, ImportCreated, ImportCreated}) {
+		t.Errorf("Expected: %v, got: %v", "all created", statuses)
+	}
+	imported, _ := dst.List()
+	if ids := lo.Map(imported, func(p *Profile, i int) string { return p.ID() }); !reflect.DeepEqual(ids, lo.Map(list, func(p *Profile, i int) string { return p.ID() })) {
+		t.Errorf("Expected: %v, got: %v", "same identifiers and order", ids)
+	}
+	if dstApp.Defaults != app.Defaults {
+		t.Errorf("Expected: %v, got: %v", app.Defaults, dstApp.Defaults)
+	}
+	if b, err := os.ReadFile(filepath.Join(dst.Root(), StoreDir, list[1].ID()+".json")); err != nil || string(b) != `{"proxies":[]}` {
+		t.Errorf("Expected: %v, got: %v, %v", "restored store", string(b), err)
+	}
+
+	// Name collisions
+	tests := []struct {
+		strategy ImportStrategy
+		status   ImportStatus
+		count    int
+	}{
+		{ImportSkip, ImportSkipped, 3},
+		{ImportOverwrite, ImportOverwritten, 3},
+		{ImportDuplicate, ImportCreated, 6},
+	}
+	for i, test := range tests {
+		results, err = dst.Import(openTestBundle(t, buf.Bytes()), ImportOptions{Strategy: test.strategy})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, result := range results {
+			if result.Status != test.status || result.Err != nil {
+				t.Errorf("Test %d: Expected: %v, got: %v, %v", i, test.status, result.Status, result.Err)
+			}
+		}
+		if imported, _ = dst.List(); len(imported) != test.count {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.count, len(imported))
+		}
+	}
+	if revs, _ := dst.Revisions(list[0].ID()); len(revs) != 1 {
+		t.Errorf("Expected: %v, got: %v", 1, len(revs))
+	}
+	if _, err = dst.Import(bundle, ImportOptions{Strategy: "merge"}); err == nil {
+		t.Errorf("Expected: %v, got: %v", "invalid strategy", err)
+	}
+
+	// Corrupted files are reported
+	bundle.Manifest.Profiles[0].SHA256 = "00"
+	results, _ = dst.Import(bundle, ImportOptions{})
+	if !errors.Is(results[0].Err, ErrInvalidBundle) || results[1].Status != ImportCreated {
+		t.Errorf("Expected: %v, got: %v, %v", ErrInvalidBundle, results[0].Err, results[1].Status)
+	}
+}
+
+func TestBundleLegacy(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range []string{"b.toml", "readme.txt", "a.ini"} {
+		fw, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name == "a.ini" {
+			fw.Write([]byte("[common]\nserver_addr = example.com\n"))
+		} else {
+			fw.Write([]byte("serverAddr = \"example.com\"\n"))
+		}
+	}
+	zw.Close()
+	repo := NewRepository(t.TempDir(), &config.App{})
+	results, err := repo.Import(openTestBundle(t, buf.Bytes()), ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := lo.Map(results, func(r ImportResult, i int) string { return r.Name }); !reflect.DeepEqual(names, []string{"b", "a"}) {
+		t.Errorf("Expected: %v, got: %v", []string{"b", "a"}, names)
+	}
+}
+
+func TestBundleSealed(t *testing.T) {
+	v, err := sec.NewVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := NewRepository(t.TempDir(), &config.App{})
+	src.SetVault(v)
+	conf := newTestConfig("a")
+	conf.Token = "token-secret"
+	p, err := src.Create(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, plain := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err = src.Export(&buf, []*Profile{p}, ExportOptions{Plain: plain}); err != nil {
+			t.Fatal(err)
+		}
+		bundle := openTestBundle(t, buf.Bytes())
+		if b, _ := bundle.read(bundle.Manifest.Profiles[0].File, ""); bytes.Contains(b, []byte("token-secret")) != plain || bundle.Manifest.Sealed == plain {
+			t.Errorf("Expected: %v, got: %s", plain, b)
+		}
+		dst := NewRepository(t.TempDir(), &config.App{})
+		results, _ := dst.Import(bundle, ImportOptions{})
+		if (results[0].Err == nil) != plain {
+			t.Errorf("Expected: %v, got: %v", plain, results[0].Err)
+		}
+		if plain && results[0].Profile.Data.Token != "token-secret" {
+			t.Errorf("Expected: %v, got: %v", "token-secret", results[0].Profile.Data.Token)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/koho/frpmgr/pkg/config"
+)
+
+// DefaultHistoryLimit is the number of revisions kept for each profile by default.
+const DefaultHistoryLimit = 20
+
+// historyIndex is the file that lists the revisions of a profile.
+const historyIndex = "revisions.json"
+
+// ErrRevisionNotFound is returned when a revision does not exist.
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// Revision is a saved version of a profile.
+type Revision struct {
+	// ID increases with each revision of a profile.
+	ID      int       `json:"id"`
+	Time    time.Time `json:"time"`
+	Summary string    `json:"summary"`
+}
+
+// Revisions returns the revisions of the profile with the given identifier, from oldest to newest.
+func (r *Repository) Revisions(id string) ([]Revision, error) {
+	if err := validateID(id); err != nil {
+		return nil, &Error{"list revisions of", id, err}
+	}
+	revs, err := r.revisions(id)
+	if err != nil {
+		return nil, &Error{"list revisions of", id, err}
+	}
+	return revs, nil
+}
+
+// Revision returns the config of a revision.
+func (r *Repository) Revision(id string, rev int) (*config.ClientConfig, error) {
+	if err := validateID(id); err != nil {
+		return nil, &Error{"get revision of", id, err}
+	}
+	data, err := config.UnmarshalClientConf(r.revisionPath(id, rev))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = ErrRevisionNotFound
+		}
+		return nil, &Error{"get revision of", id, err}
+	}
+	if err = r.open(data); err != nil {
+		return nil, &Error{"get revision of", id, err}
+	}
+	if data.Name() == "" {
+		data.ClientCommon.Name = id
+	}
+	return data, nil
+}
+
+// DiffRevisions compares two revisions of a profile.
+func (r *Repository) DiffRevisions(id string, from, to int) (*config.Diff, error) {
+	old, err := r.Revision(id, from)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := r.Revision(id, to)
+	if err != nil {
+		return nil, err
+	}
+	return config.DiffConfigs(old, cur), nil
+}
+
+// Restore replaces the config of the profile with a revision and saves it by Update.
+// The restored config is recorded as a new revision.
+func (r *Repository) Restore(p *Profile, rev int) error {
+	data, err := r.Revision(p.ID(), rev)
+	if err != nil {
+		return err
+	}
+	p.Data = data
+	return r.Update(p)
+}
+
+// historyDir returns the directory that contains the revisions of a profile.
+func (r *Repository) historyDir(id string) string {
+	return filepath.Join(r.root, HistoryDir, id)
+}
+
+func (r *Repository) revisionPath(id string, rev int) string {
+	return filepath.Join(r.historyDir(id), strconv.Itoa(rev)+Ext)
+}
+
+func (r *Repository) revisions(id string) ([]Revision, error) {
+	b, err := os.ReadFile(filepath.Join(r.historyDir(id), historyIndex))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []Revision{}, nil
+		}
+		return nil, err
+	}
+	var revs []Revision
+	if err = json.Unmarshal(b, &revs); err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
+// record adds the config file of the profile as a new revision, unless it's the same
+// as the latest revision. The oldest revisions are removed beyond the history limit.
+func (r *Repository) record(p *Profile) error {
+	id := p.ID()
+	b, err := os.ReadFile(p.Path)
+	if err != nil {
+		return err
+	}
+	revs, err := r.revisions(id)
+	if err != nil {
+		return err
+	}
+	rev := Revision{ID: 1, Time: time.Now(), Summary: "initial revision"}
+	if len(revs) > 0 {
+		last := revs[len(revs)-1]
+		prev, err := os.ReadFile(r.revisionPath(id, last.ID))
+		if err == nil && bytes.Equal(prev, b) {
+			return nil
+		}
+		rev.ID = last.ID + 1
+		if err == nil {
+			rev.Summary = summarize(prev, b)
+		} else {
+			rev.Summary = "previous revision is missing"
+		}
+	}
+	if err = os.MkdirAll(r.historyDir(id), os.ModePerm); err != nil {
+		return err
+	}
+	if err = os.WriteFile(r.revisionPath(id, rev.ID), b, 0666); err != nil {
+		return err
+	}
+	revs = append(revs, rev)
+	limit := r.app.HistoryLimit
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	for len(revs) > limit {
+		os.Remove(r.revisionPath(id, revs[0].ID))
+		revs = revs[1:]
+	}
+	if b, err = json.MarshalIndent(revs, "", "  "); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.historyDir(id), historyIndex), b, 0666)
+}
+
+// summarize describes the changes between two config files.
+func summarize(old, new []byte) string {
+	oldConf, err := config.UnmarshalClientConf(old)
+	if err != nil {
+		return fmt.Sprintf("previous revision is invalid: %v", err)
+	}
+	newConf, err := config.UnmarshalClientConf(new)
+	if err != nil {
+		return fmt.Sprintf("invalid config: %v", err)
+	}
+	if s := config.DiffConfigs(oldConf, newConf).Summary(); s != "" {
+		return s
+	}
+	return "formatting changed"
+}
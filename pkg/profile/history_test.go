@@ -0,0 +1,97 @@
+package profile
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/samber/lo"
+
+	"github.com/koho/frpmgr/pkg/config"
+)
+
+func TestHistory(t *testing.T) {
+	app := config.App{HistoryLimit: 3}
+	repo := NewRepository(t.TempDir(), &app)
+	p, err := repo.Create(newTestConfig("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Data.ServerPort = 7001
+	if err = repo.Update(p); err != nil {
+		t.Fatal(err)
+	}
+	// Saving without changes doesn't add a revision
+	if err = repo.Update(p); err != nil {
+		t.Fatal(err)
+	}
+	p.Data.AddProxy(&config.Proxy{BaseProxyConf: config.BaseProxyConf{Name: "ssh", Type: "tcp", LocalPort: "22"}, RemotePort: "6000"})
+	if err = repo.Update(p); err != nil {
+		t.Fatal(err)
+	}
+	revs, err := repo.Revisions(p.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"initial revision", "changed ServerPort", "added proxy ssh"}
+	if summary := lo.Map(revs, func(r Revision, i int) string { return r.Summary }); !reflect.DeepEqual(summary, expected) {
+		t.Errorf("Expected: %v, got: %v", expected, summary)
+	}
+
+	// Diff
+	diff, err := repo.DiffRevisions(p.ID(), 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Common) != 1 || diff.Common[0].Field != "ServerPort" || !reflect.DeepEqual(diff.Added, []string{"ssh"}) {
+		t.Errorf("Expected: %v, got: %v", "ServerPort and ssh", diff)
+	}
+	if _, err = repo.DiffRevisions(p.ID(), 1, 9); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("Expected: %v, got: %v", ErrRevisionNotFound, err)
+	}
+
+	// Restore and prune the oldest revision
+	if err = repo.Restore(p, 1); err != nil {
+		t.Fatal(err)
+	}
+	if p, err = repo.Get(p.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if p.Data.ServerPort != 7000 || len(p.Data.Proxies) != 0 {
+		t.Errorf("Expected: %v, got: %v", 7000, p.Data.ServerPort)
+	}
+	if revs, err = repo.Revisions(p.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if ids := lo.Map(revs, func(r Revision, i int) int { return r.ID }); !reflect.DeepEqual(ids, []int{2, 3, 4}) {
+		t.Errorf("Expected: %v, got: %v", []int{2, 3, 4}, ids)
+	}
+	if _, err = repo.Revision(p.ID(), 1); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("Expected: %v, got: %v", ErrRevisionNotFound, err)
+	}
+
+	// A file edited by hand is recorded before it's overwritten
+	if err = os.WriteFile(p.Path, []byte("serverAddr = \"example.org\"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	p.Data.ServerAddress = "example.net"
+	if err = repo.Update(p); err != nil {
+		t.Fatal(err)
+	}
+	hand, err := repo.Revision(p.ID(), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hand.ServerAddress != "example.org" {
+		t.Errorf("Expected: %v, got: %v", "example.org", hand.ServerAddress)
+	}
+
+	// History is removed with the profile
+	if err = repo.Delete(p); err != nil {
+		t.Fatal(err)
+	}
+	if revs, err = repo.Revisions(p.ID()); err != nil || len(revs) != 0 {
+		t.Errorf("Expected: %v, got: %v", 0, len(revs))
+	}
+}
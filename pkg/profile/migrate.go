@@ -0,0 +1,68 @@
+package profile
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// ErrRootNotEmpty is returned when profiles are moved to a data root that already contains profiles.
+var ErrRootNotEmpty = errors.New("data root already contains profiles")
+
+// Migrate copies the whole data root to a new data root and returns the repository of the new root.
+// The log and store paths of the profiles are moved to the new root by Relocate.
+// The data in the current root is kept, so it can be removed by Purge after the new root is in use.
+func (r *Repository) Migrate(root string) (*Repository, error) {
+	oldRoot, err := filepath.Abs(r.root)
+	if err != nil {
+		return nil, err
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return nil, err
+	}
+	if oldRoot == root {
+		return r, nil
+	}
+	dst := NewRepository(root, r.app)
+	if files, _ := filepath.Glob(filepath.Join(dst.Dir(), "*"+Ext)); len(files) > 0 {
+		return nil, ErrRootNotEmpty
+	}
+	// The application configuration in memory may be newer than the file
+	if err = r.app.Save(r.appFile); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = r.Backup(&buf); err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(dst.Dir(), os.ModePerm); err != nil {
+		return nil, err
+	}
+	// The vault is already open, so the master password is not needed
+	if _, err = dst.restore(zr, r.vault); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// Purge removes the profiles, logs, store files, history, subscriptions and the application
+// configuration from the data root. Files that can't be removed, such as the logs locked
+// by running services, are left in place.
+func (r *Repository) Purge() error {
+	var errs []error
+	for _, dir := range backupDirs {
+		if err := os.RemoveAll(filepath.Join(r.root, dir)); err != nil && dir != LogDir {
+			errs = append(errs, err)
+		}
+	}
+	if err := os.Remove(r.appFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
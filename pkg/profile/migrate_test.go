@@ -0,0 +1,56 @@
+package profile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/koho/frpmgr/pkg/config"
+)
+
+func TestMigrate(t *testing.T) {
+	app := config.App{Defaults: config.DefaultValue{User: "alice"}}
+	src := NewRepository(t.TempDir(), &app)
+	p, err := src.Create(newTestConfig("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Unsaved settings are moved too
+	app.Defaults.User = "bob"
+	root := filepath.Join(t.TempDir(), "data")
+	dst, err := src.Migrate(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst.Root() != root || dst.AppFile() != filepath.Join(root, config.DefaultAppFile) {
+		t.Errorf("Expected: %v, got: %v, %v", root, dst.Root(), dst.AppFile())
+	}
+	moved, err := dst.Get(p.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{moved.Data.LogFile, moved.Data.Store.Path} {
+		if !strings.HasPrefix(path, filepath.ToSlash(root)+"/") {
+			t.Errorf("Expected: %v, got: %v", "path in "+root, path)
+		}
+	}
+	var movedApp config.App
+	if _, err = config.UnmarshalAppConf(dst.AppFile(), &movedApp); err != nil || movedApp.Defaults.User != "bob" {
+		t.Errorf("Expected: %v, got: %v, %v", "bob", movedApp.Defaults.User, err)
+	}
+	if _, err = src.Migrate(root); !errors.Is(err, ErrRootNotEmpty) {
+		t.Errorf("Expected: %v, got: %v", ErrRootNotEmpty, err)
+	}
+
+	// The old data root is cleaned
+	if err = src.Purge(); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{src.Dir(), src.AppFile(), filepath.Join(src.Root(), HistoryDir)} {
+		if _, err = os.Stat(name); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected: %v, got: %v", os.ErrNotExist, err)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/sec"
+	"github.com/koho/frpmgr/pkg/util"
+)
+
+// Directories of the data root.
+const (
+	ProfileDir = "profiles"
+	LogDir     = "logs"
+	StoreDir   = "stores"
+	HistoryDir = "history"
+	// SubscriptionDir contains the source URLs of subscribed profiles.
+	SubscriptionDir = "subscriptions"
+)
+
+// Ext is the file extension of profiles.
+const Ext = ".conf"
+
+var (
+	// ErrNotFound is returned when a profile does not exist.
+	ErrNotFound = errors.New("profile not found")
+	// ErrInvalidID is returned when a profile identifier is not a valid file name.
+	ErrInvalidID = errors.New("invalid profile id")
+	// ErrExists is returned when a profile is moved to an identifier that is already used.
+	ErrExists = errors.New("profile already exists")
+)
+
+// Error records an error and the operation and profile that caused it.
+type Error struct {
+	Op  string
+	ID  string
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Op + " profile " + e.ID + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Profile is a client config stored in the repository.
+type Profile struct {
+	// Path of the config file.
+	Path string
+	Data *config.ClientConfig
+}
+
+// ID returns the identifier of this profile, which is the file name without extension.
+func (p *Profile) ID() string {
+	return util.FileNameWithoutExt(p.Path)
+}
+
+// Name of the config.
+func (p *Profile) Name() string {
+	return p.Data.Name()
+}
+
+// Repository manages the profiles in a data root directory.
+// The order of profiles is kept in the `Sort` field of the application configuration.
+type Repository struct {
+	root    string
+	app     *config.App
+	appFile string
+	vault   *sec.Vault
+}
+
+// NewRepository creates a repository for the given root directory.
+// The application configuration is shared with the caller and saved to the root
+// directory when the order of profiles changes.
+func NewRepository(root string, app *config.App) *Repository {
+	return &Repository{
+		root:    root,
+		app:     app,
+		appFile: filepath.Join(root, config.DefaultAppFile),
+	}
+}
+
+// Root returns the data root directory.
+func (r *Repository) Root() string {
+	return r.root
+}
+
+// AppFile returns the path of the application configuration in the data root.
+func (r *Repository) AppFile() string {
+	return r.appFile
+}
+
+// Dir returns the directory that contains all profiles.
+func (r *Repository) Dir() string {
+	return filepath.Join(r.root, ProfileDir)
+}
+
+// PathOf returns the file path of a profile with the given identifier.
+func (r *Repository) PathOf(id string) string {
+	return filepath.Join(r.Dir(), id+Ext)
+}
+
+// NewProfile creates a profile object. If path is empty, a free path is derived from the name
+// of the config by NewID. The profile is not written to disk until it's saved by Update.
+func (r *Repository) NewProfile(path string, data *config.ClientConfig) (*Profile, error) {
+	if path == "" {
+		id, err := r.NewID(data.Name())
+		if err != nil {
+			return nil, err
+		}
+		path = r.PathOf(id)
+	}
+	return &Profile{Path: path, Data: data}, nil
+}
+
+// List returns all profiles in the repository, sorted by the saved order.
+// Profiles that are not in the saved order are placed at the end.
+// Files that can't be parsed are skipped.
+func (r *Repository) List() ([]*Profile, error) {
+	files, err := filepath.Glob(filepath.Join(r.Dir(), "*"+Ext))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*Profile, 0)
+	for _, f := range files {
+		if p, err := r.load(f); err == nil {
+			list = append(list, p)
+		}
+	}
+	r.sort(list)
+	return list, nil
+}
+
+// Get returns the profile with the given identifier.
+func (r *Repository) Get(id string) (*Profile, error) {
+	if err := validateID(id); err != nil {
+		return nil, &Error{"get", id, err}
+	}
+	p, err := r.load(r.PathOf(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = ErrNotFound
+		}
+		return nil, &Error{"get", id, err}
+	}
+	return p, nil
+}
+
+// Create saves the given config as a new profile and appends it to the order list.
+func (r *Repository) Create(data *config.ClientConfig) (*Profile, error) {
+	p, err := r.NewProfile("", data)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.create(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *Repository) create(p *Profile) error {
+	if err := r.Update(p); err != nil {
+		return err
+	}
+	if !slices.Contains(r.app.Sort, p.ID()) {
+		if err := r.Reorder(append(slices.Clone(r.app.Sort), p.ID())); err != nil {
+			return &Error{"create", p.ID(), err}
+		}
+	}
+	return nil
+}
+
+// Update completes the config and writes it to disk.
+// Comments and unknown keys of an existing config file are kept.
+// The log file and store file are placed in the data root according to the profile identifier.
+// The saved file is recorded as a revision in the history of the profile. An existing file
+// that is not in the history yet, such as a file edited by hand, is recorded before it's overwritten.
+// Secrets are encrypted in the saved file if the repository has a vault.
+func (r *Repository) Update(p *Profile) error {
+	if err := validateID(p.ID()); err != nil {
+		return &Error{"update", p.ID(), err}
+	}
+	logPath, err := filepath.Abs(filepath.Join(r.root, LogDir, p.ID()+".log"))
+	if err != nil {
+		return &Error{"update", p.ID(), err}
+	}
+	p.Data.Complete(false)
+	p.Data.LogFile = filepath.ToSlash(logPath)
+	storePath, err := filepath.Abs(filepath.Join(r.root, StoreDir, p.ID()+".json"))
+	if err != nil {
+		return &Error{"update", p.ID(), err}
+	}
+	p.Data.Store.Path = filepath.ToSlash(storePath)
+	if err = os.MkdirAll(filepath.Dir(p.Path), os.ModePerm); err != nil {
+		return &Error{"update", p.ID(), err}
+	}
+	if _, err = os.Stat(p.Path); err == nil {
+		if err = r.record(p); err != nil {
+			return &Error{"update", p.ID(), err}
+		}
+	}
+	data, err := r.seal(p.Data)
+	if err != nil {
+		return &Error{"update", p.ID(), err}
+	}
+	if err = data.SavePreserve(p.Path); err != nil {
+		return &Error{"update", p.ID(), err}
+	}
+	if err = r.record(p); err != nil {
+		return &Error{"update", p.ID(), err}
+	}
+	return nil
+}
+
+// Delete removes the config file, logs, history and subscription of the given profile.
+// It's not an error if the config file does not exist.
+func (r *Repository) Delete(p *Profile) error {
+	if logs, _, err := util.FindLogFiles(p.Data.LogFile); err == nil {
+		util.DeleteFiles(logs)
+	}
+	os.RemoveAll(r.historyDir(p.ID()))
+	r.removeSubscription(p.ID())
+	if err := os.Remove(p.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return &Error{"delete", p.ID(), err}
+	}
+	return nil
+}
+
+// Reorder saves the given identifier list as the order of profiles.
+func (r *Repository) Reorder(ids []string) error {
+	r.app.Sort = ids
+	return r.app.Save(r.appFile)
+}
+
+// Conflicts returns the resources claimed by both the given profile and other profiles
+// in the repository. The given profile replaces the stored profile with the same identifier.
+func (r *Repository) Conflicts(p *Profile) ([]config.Conflict, error) {
+	list, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+	confs := make([]*config.ClientConfig, 0, len(list)+1)
+	confs = append(confs, p.Data)
+	for _, item := range list {
+		if item.ID() != p.ID() {
+			confs = append(confs, item.Data)
+		}
+	}
+	return slices.DeleteFunc(config.FindConflicts(confs), func(c config.Conflict) bool {
+		return !c.Involves(p.Data)
+	}), nil
+}
+
+func (r *Repository) load(path string) (*Profile, error) {
+	data, err := config.UnmarshalClientConf(path)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.open(data); err != nil {
+		return nil, err
+	}
+	p := &Profile{Path: path, Data: data}
+	if p.Name() == "" {
+		data.ClientCommon.Name = p.ID()
+	}
+	return p, nil
+}
+
+func (r *Repository) sort(list []*Profile) {
+	slices.SortStableFunc(list, func(a, b *Profile) int {
+		i := slices.Index(r.app.Sort, a.ID())
+		j := slices.Index(r.app.Sort, b.ID())
+		if i < 0 && j >= 0 {
+			return 1
+		} else if j < 0 && i >= 0 {
+			return -1
+		}
+		return i - j
+	})
+}
+
+func validateID(id string) error {
+	if id == "" || id == "." || id == ".." || filepath.Base(id) != id {
+		return fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package profile
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/samber/lo"
+
+	"github.com/koho/frpmgr/pkg/config"
+)
+
+func newTestConfig(name string) *config.ClientConfig {
+	conf := config.NewDefaultClientConfig()
+	conf.ClientCommon.Name = name
+	conf.ServerAddress = "example.com"
+	return conf
+}
+
+func TestRepository(t *testing.T) {
+	root := t.TempDir()
+	app := config.App{}
+	repo := NewRepository(root, &app)
+	var created []*Profile
+	for _, name := range []string{"a", "b", "c"} {
+		p, err := repo.Create(newTestConfig(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		created = append(created, p)
+	}
+	ids := lo.Map(created, func(p *Profile, i int) string { return p.ID() })
+	if !reflect.DeepEqual(app.Sort, ids) {
+		t.Errorf("Expected: %v, got: %v", ids, app.Sort)
+	}
+	expectedLog := filepath.ToSlash(filepath.Join(root, LogDir, ids[0]+".log"))
+	if created[0].Data.LogFile != expectedLog {
+		t.Errorf("Expected: %v, got: %v", expectedLog, created[0].Data.LogFile)
+	}
+	expectedStore := filepath.ToSlash(filepath.Join(root, StoreDir, ids[0]+".json"))
+	if created[0].Data.Store.Path != expectedStore {
+		t.Errorf("Expected: %v, got: %v", expectedStore, created[0].Data.Store.Path)
+	}
+
+	// Reorder
+	reordered := []string{ids[2], ids[0]}
+	if err := repo.Reorder(reordered); err != nil {
+		t.Fatal(err)
+	}
+	var saved config.App
+	if _, err := config.UnmarshalAppConf(filepath.Join(root, config.DefaultAppFile), &saved); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(saved.Sort, reordered) {
+		t.Errorf("Expected: %v, got: %v", reordered, saved.Sort)
+	}
+	list, err := repo.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedNames := []string{"c", "a", "b"}
+	if names := lo.Map(list, func(p *Profile, i int) string { return p.Name() }); !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("Expected: %v, got: %v", expectedNames, names)
+	}
+
+	// Get and update
+	p, err := repo.Get(ids[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Data.ServerPort = 7001
+	if err = repo.Update(p); err != nil {
+		t.Fatal(err)
+	}
+	if p, err = repo.Get(ids[1]); err != nil {
+		t.Fatal(err)
+	}
+	if p.Data.ServerPort != 7001 {
+		t.Errorf("Expected: %v, got: %v", 7001, p.Data.ServerPort)
+	}
+
+	// Delete
+	if err = repo.Delete(p); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = repo.Get(ids[1]); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected: %v, got: %v", ErrNotFound, err)
+	}
+	if _, err = repo.Get("../app"); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("Expected: %v, got: %v", ErrInvalidID, err)
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	repo := NewRepository(t.TempDir(), &config.App{})
+	a := newTestConfig("a")
+	a.AddProxy(&config.Proxy{BaseProxyConf: config.BaseProxyConf{Name: "ssh", Type: "tcp", LocalPort: "22"}, RemotePort: "6000"})
+	pa, err := repo.Create(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := newTestConfig("b")
+	b.AddProxy(&config.Proxy{BaseProxyConf: config.BaseProxyConf{Name: "ssh", Type: "tcp", LocalPort: "22"}, RemotePort: "6001"})
+	pb, err := repo.Create(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conflicts, err := repo.Conflicts(pb); err != nil || len(conflicts) != 0 {
+		t.Errorf("Expected: %v, got: %v", 0, conflicts)
+	}
+	// The unsaved change of a profile is checked against the stored profiles.
+	pb.Data.Proxies[0].RemotePort = "6000"
+	conflicts, err := repo.Conflicts(pb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{pb.Name(), pa.Name()}
+	if len(conflicts) != 1 || !reflect.DeepEqual(lo.Map(conflicts[0].Proxies, func(p config.ProxyRef, i int) string { return p.Config }), expected) {
+		t.Errorf("Expected: %v, got: %v", expected, conflicts)
+	}
+}
@@ -0,0 +1,198 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/util"
+)
+
+// maxIDLength is the maximum number of characters of an identifier derived from a name.
+const maxIDLength = 48
+
+// reservedNames are the device names that can't be used as file names on Windows.
+var reservedNames = []string{
+	"con", "prn", "aux", "nul",
+	"com1", "com2", "com3", "com4", "com5", "com6", "com7", "com8", "com9",
+	"lpt1", "lpt2", "lpt3", "lpt4", "lpt5", "lpt6", "lpt7", "lpt8", "lpt9",
+}
+
+// NewID returns a free identifier derived from the given name, such as "my-server" for "My Server".
+// A number is appended if the identifier is used by another profile, such as "my-server-2".
+// A random identifier is returned if the name has no letters or digits.
+func (r *Repository) NewID(name string) (string, error) {
+	base := util.Slug(name, maxIDLength)
+	if base == "" {
+		return util.RandToken(16)
+	}
+	for i := 1; ; i++ {
+		id := base
+		if i > 1 {
+			id += "-" + strconv.Itoa(i)
+		} else if slices.Contains(reservedNames, base) {
+			continue
+		}
+		if _, err := os.Stat(r.PathOf(id)); errors.Is(err, os.ErrNotExist) && !slices.Contains(r.app.Sort, id) {
+			return id, nil
+		}
+	}
+}
+
+// derivedID reports whether the identifier is derived from the given name by NewID.
+func derivedID(id, name string) bool {
+	base := util.Slug(name, maxIDLength)
+	if base == "" {
+		return false
+	}
+	if id == base {
+		return true
+	}
+	n, ok := strings.CutPrefix(id, base+"-")
+	_, err := strconv.Atoi(n)
+	return ok && err == nil
+}
+
+// RenameID returns the identifier of the profile after it's renamed to the given name.
+// It's the current identifier if the identifier is already derived from the name.
+func (r *Repository) RenameID(p *Profile, name string) (string, error) {
+	if derivedID(p.ID(), name) {
+		return p.ID(), nil
+	}
+	return r.NewID(name)
+}
+
+// Rename changes the name of the profile, and moves it to the identifier returned by RenameID.
+// The config file, log files, store file, history and subscription are moved together, and
+// the log and store paths in the config are changed. The files are moved back if any step fails.
+// The service of the profile must be stopped before the profile is moved, so the files are not locked.
+func (r *Repository) Rename(p *Profile, name string) error {
+	oldID := p.ID()
+	id, err := r.RenameID(p, name)
+	if err != nil {
+		return &Error{"rename", oldID, err}
+	}
+	if id != oldID {
+		if err = r.move(p, id); err != nil {
+			return &Error{"rename", oldID, err}
+		}
+	}
+	if name == p.Name() {
+		return nil
+	}
+	oldName := p.Data.Name()
+	p.Data.ClientCommon.Name = name
+	if err = r.Update(p); err != nil {
+		p.Data.ClientCommon.Name = oldName
+		if id != oldID {
+			r.move(p, oldID)
+		}
+		return err
+	}
+	return nil
+}
+
+// move moves the profile and its files to the given identifier.
+func (r *Repository) move(p *Profile, id string) (err error) {
+	if err = validateID(id); err != nil {
+		return err
+	}
+	path := r.PathOf(id)
+	if _, err = os.Stat(path); err == nil {
+		return fmt.Errorf("%w: %s", ErrExists, id)
+	}
+	oldID := p.ID()
+	oldSort := slices.Clone(r.app.Sort)
+	var moved [][2]string
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(moved) - 1; i >= 0; i-- {
+			os.Rename(moved[i][1], moved[i][0])
+		}
+		if !slices.Equal(r.app.Sort, oldSort) {
+			r.Reorder(oldSort)
+		}
+	}()
+	rename := func(from, to string) error {
+		if err := os.Rename(from, to); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		moved = append(moved, [2]string{from, to})
+		return nil
+	}
+	// The config file must exist
+	if err = os.Rename(p.Path, path); err != nil {
+		return err
+	}
+	moved = append(moved, [2]string{p.Path, path})
+	files := [][2]string{
+		{r.historyDir(oldID), r.historyDir(id)},
+		{r.subscriptionPath(oldID, ".json"), r.subscriptionPath(id, ".json")},
+		{r.subscriptionPath(oldID, Ext), r.subscriptionPath(id, Ext)},
+	}
+	// Only the log and store files in the data root are named by the identifier
+	logFile, storeFile := r.logPath(oldID), r.storePath(oldID)
+	if logs, _, err := util.FindLogFiles(filepath.FromSlash(p.Data.LogFile)); err == nil && sameFile(p.Data.LogFile, logFile) {
+		for _, name := range logs {
+			files = append(files, [2]string{name, filepath.Join(filepath.Dir(name), id+strings.TrimPrefix(filepath.Base(name), oldID))})
+		}
+	}
+	if sameFile(p.Data.Store.Path, storeFile) {
+		files = append(files, [2]string{storeFile, r.storePath(id)})
+	}
+	for _, f := range files {
+		if err = rename(f[0], f[1]); err != nil {
+			return err
+		}
+	}
+	if i := slices.Index(r.app.Sort, oldID); i >= 0 {
+		sort := slices.Clone(r.app.Sort)
+		sort[i] = id
+		if err = r.Reorder(sort); err != nil {
+			return err
+		}
+	}
+	// Secrets are not decrypted, so the files can be moved without the vault
+	data, err := config.UnmarshalClientConf(path)
+	if err != nil {
+		return err
+	}
+	if sameFile(data.LogFile, logFile) {
+		data.LogFile = filepath.ToSlash(r.logPath(id))
+	}
+	if sameFile(data.Store.Path, storeFile) {
+		data.Store.Path = filepath.ToSlash(r.storePath(id))
+	}
+	if err = data.SavePreserve(path); err != nil {
+		return err
+	}
+	p.Path, p.Data.LogFile, p.Data.Store.Path = path, data.LogFile, data.Store.Path
+	return nil
+}
+
+// logPath returns the absolute path of the log file of the given identifier in the data root.
+func (r *Repository) logPath(id string) string {
+	path, _ := filepath.Abs(filepath.Join(r.root, LogDir, id+".log"))
+	return path
+}
+
+// storePath returns the absolute path of the store file of the given identifier in the data root.
+func (r *Repository) storePath(id string) string {
+	path, _ := filepath.Abs(filepath.Join(r.root, StoreDir, id+".json"))
+	return path
+}
+
+// sameFile reports whether the two paths are the same, ignoring the case and the path separator.
+func sameFile(a, b string) bool {
+	return a != "" && strings.EqualFold(filepath.ToSlash(a), filepath.ToSlash(b))
+}
@@ -0,0 +1,120 @@
+package profile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/koho/frpmgr/pkg/config"
+)
+
+func TestNewID(t *testing.T) {
+	repo := NewRepository(t.TempDir(), &config.App{})
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"My Server", "my-server"},
+		{"my server", "my-server-2"},
+		{"My-Server!", "my-server-3"},
+		{"CON", "con-2"},
+	}
+	for i, test := range tests {
+		p, err := repo.Create(newTestConfig(test.name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.ID() != test.expected {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.expected, p.ID())
+		}
+	}
+	// Names without letters or digits get random identifiers
+	if p, err := repo.Create(newTestConfig("***")); err != nil || len(p.ID()) != 32 {
+		t.Errorf("Expected: %v, got: %v, %v", "random identifier", p, err)
+	}
+}
+
+func TestRename(t *testing.T) {
+	app := config.App{}
+	repo := NewRepository(t.TempDir(), &app)
+	// A profile named by an older version
+	legacy, err := repo.NewProfile(repo.PathOf("0123456789abcdef"), newTestConfig("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = repo.create(legacy); err != nil {
+		t.Fatal(err)
+	}
+	other, err := repo.Create(newTestConfig("b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldID := legacy.ID()
+	for _, name := range []string{
+		filepath.Join(LogDir, oldID+".log"),
+		filepath.Join(LogDir, oldID+".20250101-000000.log"),
+		filepath.Join(StoreDir, oldID+".json"),
+	} {
+		if err = os.MkdirAll(filepath.Join(repo.Root(), filepath.Dir(name)), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err = os.WriteFile(filepath.Join(repo.Root(), name), []byte(name), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = repo.Subscribe(oldID, Subscription{URL: "https://example.com/a.toml"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = repo.Rename(legacy, "Home Server"); err != nil {
+		t.Fatal(err)
+	}
+	if legacy.ID() != "home-server" {
+		t.Errorf("Expected: %v, got: %v", "home-server", legacy.ID())
+	}
+	renamed, err := repo.Get("home-server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renamed.Name() != "Home Server" {
+		t.Errorf("Expected: %v, got: %v", "Home Server", renamed.Name())
+	}
+	if renamed.Data.LogFile != filepath.ToSlash(repo.logPath("home-server")) || renamed.Data.Store.Path != filepath.ToSlash(repo.storePath("home-server")) {
+		t.Errorf("Expected: %v, got: %v, %v", "moved paths", renamed.Data.LogFile, renamed.Data.Store.Path)
+	}
+	for _, name := range []string{
+		filepath.Join(LogDir, "home-server.log"),
+		filepath.Join(LogDir, "home-server.20250101-000000.log"),
+		filepath.Join(StoreDir, "home-server.json"),
+		filepath.Join(HistoryDir, "home-server"),
+		filepath.Join(SubscriptionDir, "home-server.json"),
+	} {
+		if _, err = os.Stat(filepath.Join(repo.Root(), name)); err != nil {
+			t.Errorf("Expected: %v, got: %v", name, err)
+		}
+	}
+	if _, err = repo.Get(oldID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected: %v, got: %v", ErrNotFound, err)
+	}
+	if !reflect.DeepEqual(app.Sort, []string{"home-server", other.ID()}) {
+		t.Errorf("Expected: %v, got: %v", []string{"home-server", other.ID()}, app.Sort)
+	}
+	// Created, moved and renamed
+	if revs, _ := repo.Revisions("home-server"); len(revs) != 3 {
+		t.Errorf("Expected: %v, got: %v", 3, len(revs))
+	}
+
+	// The identifier is kept if it's derived from the new name
+	if err = repo.Rename(legacy, "home server"); err != nil || legacy.ID() != "home-server" {
+		t.Errorf("Expected: %v, got: %v, %v", "home-server", legacy.ID(), err)
+	}
+	// A collision gets a number
+	if err = repo.Rename(other, "Home Server"); err != nil || other.ID() != "home-server-2" {
+		t.Errorf("Expected: %v, got: %v, %v", "home-server-2", other.ID(), err)
+	}
+	if err = repo.move(other, "home-server"); !errors.Is(err, ErrExists) {
+		t.Errorf("Expected: %v, got: %v", ErrExists, err)
+	}
+}
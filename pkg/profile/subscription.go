@@ -0,0 +1,357 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/util"
+)
+
+// DefaultRefreshInterval is the interval between refreshes of a subscription by default.
+const DefaultRefreshInterval = time.Hour
+
+// MinRefreshInterval is the shortest interval between scheduled refreshes.
+const MinRefreshInterval = time.Minute
+
+var (
+	// ErrNotSubscribed is returned when a profile has no source URL.
+	ErrNotSubscribed = errors.New("profile is not subscribed")
+	// ErrInvalidSubscription is returned when a subscription has an invalid URL, interval or policy.
+	ErrInvalidSubscription = errors.New("invalid subscription")
+)
+
+// MergePolicy defines how local edits are handled when a subscribed profile is refreshed.
+type MergePolicy string
+
+const (
+	// PolicyMerge keeps the local edits made since the last refresh. When the source changes
+	// the same field or proxy, the source wins and the conflict is reported in the history.
+	PolicyMerge MergePolicy = "merge"
+	// PolicyOverride replaces the profile with the config of the source.
+	PolicyOverride MergePolicy = "override"
+)
+
+// RefreshStatus is the result of a refresh.
+type RefreshStatus string
+
+const (
+	// RefreshUpdated means the profile is changed by the refresh.
+	RefreshUpdated RefreshStatus = "updated"
+	// RefreshUnchanged means the source is downloaded, but the profile is not changed.
+	RefreshUnchanged RefreshStatus = "unchanged"
+	// RefreshNotModified means the server reports that the source is not modified.
+	RefreshNotModified RefreshStatus = "not-modified"
+	RefreshFailed      RefreshStatus = "failed"
+)
+
+// RefreshEvent is a record in the refresh history of a subscription.
+type RefreshEvent struct {
+	Time   time.Time     `json:"time"`
+	Status RefreshStatus `json:"status"`
+	// Message describes the changes, the conflicts or the error.
+	Message string `json:"message,omitempty"`
+}
+
+// Subscription is the source URL of a profile and the state of its refreshes.
+type Subscription struct {
+	URL string `json:"url"`
+	// Interval between scheduled refreshes, e.g. "30m". An empty value means DefaultRefreshInterval.
+	Interval string      `json:"interval,omitempty"`
+	Policy   MergePolicy `json:"policy,omitempty"`
+	// ETag and LastModified are the validators of the last download,
+	// which are sent back in a conditional request.
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	// History of refreshes, from oldest to newest.
+	History []RefreshEvent `json:"history,omitempty"`
+}
+
+// RefreshInterval returns the interval between scheduled refreshes.
+func (s *Subscription) RefreshInterval() time.Duration {
+	if d, err := time.ParseDuration(s.Interval); err == nil && d > 0 {
+		return max(d, MinRefreshInterval)
+	}
+	return DefaultRefreshInterval
+}
+
+// LastRefresh returns the latest refresh, or nil if the subscription is never refreshed.
+func (s *Subscription) LastRefresh() *RefreshEvent {
+	if len(s.History) == 0 {
+		return nil
+	}
+	return &s.History[len(s.History)-1]
+}
+
+// Due reports whether the subscription should be refreshed at the given time.
+func (s *Subscription) Due(now time.Time) bool {
+	last := s.LastRefresh()
+	return last == nil || !now.Before(last.Time.Add(s.RefreshInterval()))
+}
+
+func (s *Subscription) validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url %q", ErrInvalidSubscription, s.URL)
+	}
+	if s.Interval != "" {
+		if d, err := time.ParseDuration(s.Interval); err != nil || d <= 0 {
+			return fmt.Errorf("%w: interval %q", ErrInvalidSubscription, s.Interval)
+		}
+	}
+	switch s.Policy {
+	case "", PolicyMerge, PolicyOverride:
+	default:
+		return fmt.Errorf("%w: policy %q", ErrInvalidSubscription, s.Policy)
+	}
+	return nil
+}
+
+// Subscribe remembers the source URL of a profile, so it can be refreshed by Refresh.
+// The current config of the profile is taken as the config of the source, and local edits
+// made afterwards are handled according to the merge policy. Subscribing a subscribed profile
+// to the same URL only changes the interval and policy, and keeps the history.
+func (r *Repository) Subscribe(id string, sub Subscription) error {
+	if err := sub.validate(); err != nil {
+		return &Error{"subscribe", id, err}
+	}
+	p, err := r.Get(id)
+	if err != nil {
+		return err
+	}
+	if old, err := r.Subscription(id); err == nil && old.URL == sub.URL {
+		old.Interval, old.Policy = sub.Interval, sub.Policy
+		sub = *old
+	} else {
+		sub.ETag, sub.LastModified, sub.History = "", "", nil
+		if err = r.saveBase(id, p.Data); err != nil {
+			return &Error{"subscribe", id, err}
+		}
+	}
+	if err = r.saveSubscription(id, &sub); err != nil {
+		return &Error{"subscribe", id, err}
+	}
+	return nil
+}
+
+// Unsubscribe forgets the source URL of a profile. The config of the profile is not changed.
+func (r *Repository) Unsubscribe(id string) error {
+	if _, err := r.Subscription(id); err != nil {
+		return err
+	}
+	if err := r.removeSubscription(id); err != nil {
+		return &Error{"unsubscribe", id, err}
+	}
+	return nil
+}
+
+// Subscription returns the subscription of a profile.
+func (r *Repository) Subscription(id string) (*Subscription, error) {
+	if err := validateID(id); err != nil {
+		return nil, &Error{"get subscription of", id, err}
+	}
+	b, err := os.ReadFile(r.subscriptionPath(id, ".json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = ErrNotSubscribed
+		}
+		return nil, &Error{"get subscription of", id, err}
+	}
+	var sub Subscription
+	if err = json.Unmarshal(b, &sub); err != nil {
+		return nil, &Error{"get subscription of", id, err}
+	}
+	return &sub, nil
+}
+
+// Subscriptions returns the subscriptions of all profiles by their identifiers.
+func (r *Repository) Subscriptions() (map[string]*Subscription, error) {
+	files, err := filepath.Glob(filepath.Join(r.root, SubscriptionDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	subs := make(map[string]*Subscription)
+	for _, f := range files {
+		id := strings.TrimSuffix(filepath.Base(f), ".json")
+		if sub, err := r.Subscription(id); err == nil {
+			subs[id] = sub
+		}
+	}
+	return subs, nil
+}
+
+// Refresh downloads the config of a subscribed profile from its source URL and applies it
+// to the profile according to the merge policy. The profile is saved by Update only when its
+// config is changed, which is reported by the status RefreshUpdated. The result is recorded
+// in the history of the subscription, including failures.
+func (r *Repository) Refresh(ctx context.Context, id string) (RefreshEvent, error) {
+	sub, err := r.Subscription(id)
+	if err != nil {
+		return RefreshEvent{}, err
+	}
+	ev, err := r.refresh(ctx, id, sub)
+	ev.Time = time.Now()
+	if err != nil {
+		ev.Status, ev.Message = RefreshFailed, err.Error()
+	}
+	sub.History = append(sub.History, ev)
+	limit := r.app.HistoryLimit
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	if n := len(sub.History); n > limit {
+		sub.History = sub.History[n-limit:]
+	}
+	if saveErr := r.saveSubscription(id, sub); err == nil {
+		err = saveErr
+	}
+	if err != nil {
+		return ev, &Error{"refresh", id, err}
+	}
+	return ev, nil
+}
+
+// RefreshDue refreshes all subscriptions that are due at the given time.
+// The updated function is called with each profile changed by a refresh.
+// Failures are only recorded in the history of subscriptions.
+func (r *Repository) RefreshDue(ctx context.Context, now time.Time, updated func(p *Profile)) {
+	subs, err := r.Subscriptions()
+	if err != nil {
+		return
+	}
+	for id, sub := range subs {
+		if ctx.Err() != nil {
+			return
+		}
+		if !sub.Due(now) {
+			continue
+		}
+		if ev, err := r.Refresh(ctx, id); err == nil && ev.Status == RefreshUpdated && updated != nil {
+			if p, err := r.Get(id); err == nil {
+				updated(p)
+			}
+		}
+	}
+}
+
+func (r *Repository) refresh(ctx context.Context, id string, sub *Subscription) (RefreshEvent, error) {
+	p, err := r.Get(id)
+	if err != nil {
+		return RefreshEvent{}, err
+	}
+	// The source is downloaded with a conditional request
+	opts, err := r.app.Download.Options(sub.URL, r.vault)
+	if err != nil {
+		return RefreshEvent{}, err
+	}
+	if opts.Header == nil {
+		opts.Header = make(http.Header)
+	}
+	if sub.ETag != "" {
+		opts.Header.Set("If-None-Match", sub.ETag)
+	}
+	if sub.LastModified != "" {
+		opts.Header.Set("If-Modified-Since", sub.LastModified)
+	}
+	result, err := util.Download(ctx, sub.URL, opts)
+	if err != nil {
+		return RefreshEvent{}, err
+	}
+	if result.NotModified {
+		return RefreshEvent{Status: RefreshNotModified}, nil
+	}
+	remote, err := config.UnmarshalClientConf(result.Data)
+	if err != nil {
+		return RefreshEvent{}, err
+	}
+	base, err := r.loadBase(id)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return RefreshEvent{}, err
+	}
+	if remote.Name() == "" {
+		remote.ClientCommon.Name = p.Name()
+		if base != nil {
+			remote.ClientCommon.Name = base.Name()
+		}
+	}
+	merged, conflicts := remote.Clone(), []string(nil)
+	// Without a base config, local edits can't be told apart, so the source wins
+	if sub.Policy != PolicyOverride && base != nil {
+		merged, conflicts = config.Merge(base, p.Data, remote)
+	}
+	ev := RefreshEvent{Status: RefreshUnchanged}
+	if diff := config.DiffConfigs(p.Data, merged); !diff.Empty() {
+		p.Data = merged
+		if err = r.Update(p); err != nil {
+			return RefreshEvent{}, err
+		}
+		ev = RefreshEvent{Status: RefreshUpdated, Message: diff.Summary()}
+	}
+	if len(conflicts) > 0 {
+		ev.Message = strings.TrimPrefix(ev.Message+"; conflicts: "+strings.Join(conflicts, ", "), "; ")
+	}
+	if err = r.saveBase(id, remote); err != nil {
+		return RefreshEvent{}, err
+	}
+	// The validators are saved last, so a failed refresh downloads the source again
+	sub.ETag, sub.LastModified = result.Header.Get("ETag"), result.Header.Get("Last-Modified")
+	return ev, nil
+}
+
+// subscriptionPath returns the path of a subscription file with the given extension.
+// The ".json" file is the subscription, and the config file is the last downloaded config.
+func (r *Repository) subscriptionPath(id, ext string) string {
+	return filepath.Join(r.root, SubscriptionDir, id+ext)
+}
+
+func (r *Repository) saveSubscription(id string, sub *Subscription) error {
+	b, err := json.MarshalIndent(sub, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Join(r.root, SubscriptionDir), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(r.subscriptionPath(id, ".json"), b, 0666)
+}
+
+func (r *Repository) removeSubscription(id string) error {
+	err := os.Remove(r.subscriptionPath(id, ".json"))
+	os.Remove(r.subscriptionPath(id, Ext))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// saveBase saves the config of the source, which is the base of merging local edits.
+// Secrets are encrypted in the same way as profiles.
+func (r *Repository) saveBase(id string, data *config.ClientConfig) error {
+	sealed, err := r.seal(data)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Join(r.root, SubscriptionDir), os.ModePerm); err != nil {
+		return err
+	}
+	return sealed.Save(r.subscriptionPath(id, Ext))
+}
+
+func (r *Repository) loadBase(id string) (*config.ClientConfig, error) {
+	data, err := config.UnmarshalClientConf(r.subscriptionPath(id, Ext))
+	if err != nil {
+		return nil, err
+	}
+	if err = r.open(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,181 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/koho/frpmgr/pkg/config"
+)
+
+// testSource serves a config with an ETag, like a static file server.
+type testSource struct {
+	mu       sync.Mutex
+	content  string
+	etag     string
+	requests int
+}
+
+func (s *testSource) set(content, etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.content, s.etag = content, etag
+}
+
+func (s *testSource) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	if s.content == "" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("ETag", s.etag)
+	if r.Header.Get("If-None-Match") == s.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Write([]byte(s.content))
+}
+
+const testSourceConfig = `
+serverAddr = "example.com"
+serverPort = 7000
+
+[[proxies]]
+name = "ssh"
+type = "tcp"
+localPort = 22
+remotePort = 6000
+`
+
+func TestSubscription(t *testing.T) {
+	source := &testSource{}
+	source.set(testSourceConfig, `"v1"`)
+	ts := httptest.NewServer(source)
+	defer ts.Close()
+
+	repo := NewRepository(t.TempDir(), &config.App{})
+	data, err := config.UnmarshalClientConf([]byte(testSourceConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data.ClientCommon.Name = "a"
+	p, err := repo.Create(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = repo.Refresh(context.Background(), p.ID()); !errors.Is(err, ErrNotSubscribed) {
+		t.Errorf("Expected: %v, got: %v", ErrNotSubscribed, err)
+	}
+	if err = repo.Subscribe(p.ID(), Subscription{URL: "ftp://example.com/a.toml"}); !errors.Is(err, ErrInvalidSubscription) {
+		t.Errorf("Expected: %v, got: %v", ErrInvalidSubscription, err)
+	}
+	if err = repo.Subscribe(p.ID(), Subscription{URL: ts.URL, Interval: "10m"}); err != nil {
+		t.Fatal(err)
+	}
+	if !repo.Scheduled() {
+		t.Errorf("Expected: %v, got: %v", true, false)
+	}
+
+	refresh := func(expected RefreshStatus) RefreshEvent {
+		t.Helper()
+		ev, err := repo.Refresh(context.Background(), p.ID())
+		if err != nil && expected != RefreshFailed {
+			t.Fatal(err)
+		}
+		if ev.Status != expected {
+			t.Errorf("Expected: %v, got: %v (%s)", expected, ev.Status, ev.Message)
+		}
+		return ev
+	}
+	// The source is the same as the imported config
+	refresh(RefreshUnchanged)
+	refresh(RefreshNotModified)
+
+	// Local edits are kept by merging
+	p.Data.Proxies[0].LocalIP = "10.0.0.2"
+	if err = repo.Update(p); err != nil {
+		t.Fatal(err)
+	}
+	source.set(testSourceConfig+`
+[[proxies]]
+name = "web"
+type = "http"
+localPort = 80
+subdomain = "web"
+`, `"v2"`)
+	if ev := refresh(RefreshUpdated); ev.Message != "added proxy web" {
+		t.Errorf("Expected: %v, got: %v", "added proxy web", ev.Message)
+	}
+	if p, err = repo.Get(p.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if names := lo.Map(p.Data.Proxies, func(item *config.Proxy, i int) string { return item.Name }); !reflect.DeepEqual(names, []string{"ssh", "web"}) {
+		t.Errorf("Expected: %v, got: %v", []string{"ssh", "web"}, names)
+	}
+	if p.Name() != "a" || p.Data.Proxies[0].LocalIP != "10.0.0.2" {
+		t.Errorf("Expected: %v, got: %v, %v", "a with local edits", p.Name(), p.Data.Proxies[0].LocalIP)
+	}
+	if revs, _ := repo.Revisions(p.ID()); len(revs) != 3 {
+		t.Errorf("Expected: %v, got: %v", 3, len(revs))
+	}
+
+	// Local edits are discarded by overriding
+	if err = repo.Subscribe(p.ID(), Subscription{URL: ts.URL, Policy: PolicyOverride}); err != nil {
+		t.Fatal(err)
+	}
+	source.set(testSourceConfig, `"v3"`)
+	refresh(RefreshUpdated)
+	if p, err = repo.Get(p.ID()); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Data.Proxies) != 1 || p.Data.Proxies[0].LocalIP != "" {
+		t.Errorf("Expected: %v, got: %v", "the source config", p.Data.Proxies)
+	}
+
+	// Failures are recorded
+	source.set("", "")
+	refresh(RefreshFailed)
+	sub, err := repo.Subscription(p.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := lo.Map(sub.History, func(ev RefreshEvent, i int) RefreshStatus { return ev.Status })
+	expected := []RefreshStatus{RefreshUnchanged, RefreshNotModified, RefreshUpdated, RefreshUpdated, RefreshFailed}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("Expected: %v, got: %v", expected, statuses)
+	}
+	if sub.ETag != `"v3"` || sub.RefreshInterval() != DefaultRefreshInterval {
+		t.Errorf("Expected: %v, got: %v, %v", `"v3" and default interval`, sub.ETag, sub.RefreshInterval())
+	}
+
+	// Scheduled refreshes
+	source.set(testSourceConfig, `"v3"`)
+	requests := source.requests
+	var updated []string
+	repo.RefreshDue(context.Background(), time.Now(), func(p *Profile) { updated = append(updated, p.ID()) })
+	if source.requests != requests || len(updated) > 0 {
+		t.Errorf("Expected: %v, got: %v", "no refresh", source.requests-requests)
+	}
+	source.set(testSourceConfig+"user = \"bob\"\n", `"v4"`)
+	repo.RefreshDue(context.Background(), time.Now().Add(time.Hour), func(p *Profile) { updated = append(updated, p.ID()) })
+	if !reflect.DeepEqual(updated, []string{p.ID()}) {
+		t.Errorf("Expected: %v, got: %v", []string{p.ID()}, updated)
+	}
+
+	// Deleting the profile removes the subscription
+	if err = repo.Delete(p); err != nil {
+		t.Fatal(err)
+	}
+	if subs, _ := repo.Subscriptions(); len(subs) > 0 {
+		t.Errorf("Expected: %v, got: %v", 0, len(subs))
+	}
+}
@@ -0,0 +1,63 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/sec"
+)
+
+// SetVault sets the vault that encrypts the secrets of profiles at rest.
+// Secrets are sealed when profiles are saved and opened when they are loaded,
+// so the loaded configs always hold plain secrets. A nil vault disables the encryption,
+// and profiles saved afterwards hold plain secrets again.
+func (r *Repository) SetVault(v *sec.Vault) {
+	r.vault = v
+}
+
+// Vault returns the vault of the repository, or nil if secrets are not encrypted.
+func (r *Repository) Vault() *sec.Vault {
+	return r.vault
+}
+
+// ExportPlain writes the config file of the profile to the given directory with plain secrets,
+// so the file can be used by frp directly. Comments and unknown keys of the file are kept.
+// It returns the path of the written file.
+func (r *Repository) ExportPlain(p *Profile, dir string) (string, error) {
+	path := filepath.Join(dir, filepath.Base(p.Path))
+	b, err := os.ReadFile(p.Path)
+	if err != nil {
+		return "", &Error{"export", p.ID(), err}
+	}
+	if err = os.WriteFile(path, b, 0666); err != nil {
+		return "", &Error{"export", p.ID(), err}
+	}
+	if r.vault == nil {
+		return path, nil
+	}
+	if err = p.Data.SavePreserve(path); err != nil {
+		return "", &Error{"export", p.ID(), err}
+	}
+	return path, nil
+}
+
+// seal returns a copy of the config with encrypted secrets.
+func (r *Repository) seal(data *config.ClientConfig) (*config.ClientConfig, error) {
+	if r.vault == nil {
+		return data, nil
+	}
+	sealed := data.Clone()
+	if err := sealed.MapSecrets(r.vault.Seal); err != nil {
+		return nil, err
+	}
+	return sealed, nil
+}
+
+// open decrypts the secrets of the config in place.
+func (r *Repository) open(data *config.ClientConfig) error {
+	if r.vault == nil {
+		return nil
+	}
+	return data.MapSecrets(r.vault.Open)
+}
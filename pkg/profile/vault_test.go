@@ -0,0 +1,74 @@
+package profile
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/sec"
+)
+
+func TestVault(t *testing.T) {
+	v, err := sec.NewVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := NewRepository(t.TempDir(), &config.App{})
+	repo.SetVault(v)
+	conf := newTestConfig("a")
+	conf.Token = "token-secret"
+	conf.AdminPort = 7400
+	conf.AdminPwd = "admin-secret"
+	conf.Proxies = append(conf.Proxies, &config.Proxy{BaseProxyConf: config.BaseProxyConf{Name: "ssh", Type: "stcp", LocalPort: "22"}, SK: "sk-secret"})
+	p, err := repo.Create(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Data.Token != "token-secret" {
+		t.Errorf("Expected: %v, got: %v", "token-secret", p.Data.Token)
+	}
+	b, err := os.ReadFile(p.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"token-secret", "admin-secret", "sk-secret"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("Unexpected plain secret %s in: %s", secret, b)
+		}
+	}
+	// Saving again doesn't change the file
+	if err = repo.Update(p); err != nil {
+		t.Fatal(err)
+	}
+	if revs, _ := repo.Revisions(p.ID()); len(revs) != 1 {
+		t.Errorf("Expected: %v, got: %v", 1, len(revs))
+	}
+	loaded, err := repo.Get(p.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Data.Token != "token-secret" || loaded.Data.AdminPwd != "admin-secret" || loaded.Data.Proxies[0].SK != "sk-secret" {
+		t.Errorf("Unexpected secrets: %v, %v, %v", loaded.Data.Token, loaded.Data.AdminPwd, loaded.Data.Proxies[0].SK)
+	}
+	path, err := repo.ExportPlain(loaded, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, err := config.UnmarshalClientConf(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exported.Token != "token-secret" || exported.Proxies[0].SK != "sk-secret" {
+		t.Errorf("Unexpected secrets: %v, %v", exported.Token, exported.Proxies[0].SK)
+	}
+	// Another vault can't open the secrets
+	other, err := sec.NewVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.SetVault(other)
+	if _, err = repo.Get(p.ID()); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
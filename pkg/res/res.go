@@ -16,10 +16,9 @@ import (
 
 // Links
 const (
-	ProjectURL      = "https://github.com/koho/frpmgr"
-	FRPProjectURL   = "https://github.com/fatedier/frp"
-	UpdateURL       = "https://api.github.com/repos/koho/frpmgr/releases/latest"
-	ShareLinkScheme = "frp://"
+	ProjectURL    = "https://github.com/koho/frpmgr"
+	FRPProjectURL = "https://github.com/fatedier/frp"
+	UpdateURL     = "https://api.github.com/repos/koho/frpmgr/releases/latest"
 )
 
 type Icon struct {
@@ -1,12 +1,91 @@
 package sec
 
 import (
+	"crypto/pbkdf2"
+	"crypto/rand"
 	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 )
 
+// Algorithm is the name of the key derivation function recorded in password hashes.
+const Algorithm = "pbkdf2-sha256"
+
+// Iterations is the number of PBKDF2 iterations used for new password hashes.
+// Hashes with fewer iterations are upgraded on the next successful check.
+var Iterations = 600000
+
+const (
+	saltSize = 16
+	keySize  = 32
+)
+
+var errInvalidHash = errors.New("invalid password hash")
+
 // EncryptPassword returns a Base64-encoded string of the hashed password.
+//
+// Deprecated: The hash is unsalted. Use HashPassword instead. It's only kept
+// for verifying the hashes saved by previous versions.
 func EncryptPassword(password string) string {
 	hashed := sha1.Sum([]byte(password))
 	return base64.StdEncoding.EncodeToString(hashed[:])
 }
+
+// HashPassword returns the encoded hash of the password with a random salt, in the format of
+// "$pbkdf2-sha256$i=<iterations>$<salt>$<hash>". The salt and hash are Base64-encoded without padding.
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := pbkdf2.Key(sha256.New, password, salt, Iterations, keySize)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("$%s$i=%d$%s$%s", Algorithm, Iterations,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// VerifyPassword reports whether the password matches the encoded hash in constant time.
+// Legacy SHA-1 hashes are also accepted. If the password matches but the hash is legacy
+// or weaker than the current parameters, upgrade is true and the hash should be replaced
+// by the result of HashPassword.
+func VerifyPassword(password, encoded string) (ok, upgrade bool) {
+	if !strings.HasPrefix(encoded, "$") {
+		ok = subtle.ConstantTimeCompare([]byte(EncryptPassword(password)), []byte(encoded)) == 1
+		return ok, ok
+	}
+	iter, salt, key, err := decodeHash(encoded)
+	if err != nil {
+		return false, false
+	}
+	derived, err := pbkdf2.Key(sha256.New, password, salt, iter, len(key))
+	if err != nil {
+		return false, false
+	}
+	ok = subtle.ConstantTimeCompare(derived, key) == 1
+	return ok, ok && iter < Iterations
+}
+
+// decodeHash parses the parameters of an encoded hash.
+func decodeHash(encoded string) (iter int, salt, key []byte, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 || parts[1] != Algorithm || !strings.HasPrefix(parts[2], "i=") {
+		return 0, nil, nil, errInvalidHash
+	}
+	if iter, err = strconv.Atoi(strings.TrimPrefix(parts[2], "i=")); err != nil || iter <= 0 {
+		return 0, nil, nil, errInvalidHash
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[3]); err != nil {
+		return 0, nil, nil, errInvalidHash
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil || len(key) == 0 {
+		return 0, nil, nil, errInvalidHash
+	}
+	return iter, salt, key, nil
+}
@@ -1,6 +1,9 @@
 package sec
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestEncryptPassword(t *testing.T) {
 	output := EncryptPassword("123456")
@@ -9,3 +12,43 @@ func TestEncryptPassword(t *testing.T) {
 		t.Errorf("Expected: %v, got: %v", expected, output)
 	}
 }
+
+func TestVerifyPassword(t *testing.T) {
+	defer func(n int) { Iterations = n }(Iterations)
+	Iterations = 1000
+	hashed, err := HashPassword("123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hashed, "$pbkdf2-sha256$i=1000$") {
+		t.Errorf("Unexpected hash: %v", hashed)
+	}
+	if other, _ := HashPassword("123456"); other == hashed {
+		t.Error("Expected different salts")
+	}
+	tests := []struct {
+		password string
+		encoded  string
+		ok       bool
+		upgrade  bool
+	}{
+		{"123456", hashed, true, false},
+		{"1234567", hashed, false, false},
+		{"123456", "fEqNCco3Yq9h5ZUglD3CZJT4lBs=", true, true},
+		{"1234567", "fEqNCco3Yq9h5ZUglD3CZJT4lBs=", false, false},
+		{"123456", "$pbkdf2-sha256$i=0$AAAA$AAAA", false, false},
+		{"123456", "$scrypt$ln=15$AAAA$AAAA", false, false},
+		{"123456", "", false, false},
+	}
+	for i, test := range tests {
+		ok, upgrade := VerifyPassword(test.password, test.encoded)
+		if ok != test.ok || upgrade != test.upgrade {
+			t.Errorf("Test %d: Expected: %v, %v, got: %v, %v", i, test.ok, test.upgrade, ok, upgrade)
+		}
+	}
+	// Hashes with fewer iterations are upgraded
+	Iterations = 2000
+	if ok, upgrade := VerifyPassword("123456", hashed); !ok || !upgrade {
+		t.Errorf("Expected: true, true, got: %v, %v", ok, upgrade)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package sec
+
+import "errors"
+
+// protect is only supported on Windows.
+func protect(data []byte) ([]byte, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func unprotect(data []byte) ([]byte, error) {
+	return nil, errors.ErrUnsupported
+}
@@ -0,0 +1,37 @@
+package sec
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// dpapiFlags protects data in the machine scope, as the services run under a different
+// account from the user. Any account of this machine can decrypt the data, so it only
+// protects the data copied to another machine.
+const dpapiFlags = windows.CRYPTPROTECT_LOCAL_MACHINE | windows.CRYPTPROTECT_UI_FORBIDDEN
+
+func protect(data []byte) ([]byte, error) {
+	return cryptData(data, func(in, out *windows.DataBlob) error {
+		return windows.CryptProtectData(in, nil, nil, 0, nil, dpapiFlags, out)
+	})
+}
+
+func unprotect(data []byte) ([]byte, error) {
+	return cryptData(data, func(in, out *windows.DataBlob) error {
+		return windows.CryptUnprotectData(in, nil, nil, 0, nil, dpapiFlags, out)
+	})
+}
+
+func cryptData(data []byte, fn func(in, out *windows.DataBlob) error) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	in := windows.DataBlob{Size: uint32(len(data)), Data: &data[0]}
+	var out windows.DataBlob
+	if err := fn(&in, &out); err != nil {
+		return nil, err
+	}
+	defer windows.LocalFree(windows.Handle(unsafe.Pointer(out.Data)))
+	return append([]byte(nil), unsafe.Slice(out.Data, out.Size)...), nil
+}
@@ -0,0 +1,232 @@
+package sec
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SecretPrefix marks a string as a secret sealed by a vault.
+const SecretPrefix = "vault:"
+
+var (
+	// ErrInvalidSecret is returned when a sealed secret can't be decrypted.
+	ErrInvalidSecret = errors.New("invalid secret")
+	// ErrWrongPassword is returned when the vault key can't be decrypted with the password.
+	ErrWrongPassword = errors.New("wrong password")
+)
+
+// Vault encrypts secrets with AES-256-GCM.
+//
+// The nonce of a secret is derived from its plaintext, so sealing the same secret
+// twice gives the same result, and saving an unchanged config doesn't change the file.
+type Vault struct {
+	key      []byte
+	nonceKey []byte
+	aead     cipher.AEAD
+}
+
+// NewVault creates a vault with a random key.
+func NewVault() (*Vault, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return newVault(key)
+}
+
+func newVault(key []byte) (*Vault, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonceKey, err := hkdf.Key(sha256.New, key, nil, "frpmgr vault nonce", keySize)
+	if err != nil {
+		return nil, err
+	}
+	return &Vault{key: key, nonceKey: nonceKey, aead: aead}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// IsSealed reports whether the string is a secret sealed by a vault.
+func IsSealed(s string) bool {
+	return strings.HasPrefix(s, SecretPrefix)
+}
+
+// Seal encrypts the secret and returns a string prefixed by SecretPrefix.
+// Empty and sealed strings are returned unchanged.
+func (v *Vault) Seal(s string) (string, error) {
+	if s == "" || IsSealed(s) {
+		return s, nil
+	}
+	mac := hmac.New(sha256.New, v.nonceKey)
+	mac.Write([]byte(s))
+	nonce := mac.Sum(nil)[:v.aead.NonceSize()]
+	return SecretPrefix + base64.RawStdEncoding.EncodeToString(v.aead.Seal(nonce, nonce, []byte(s), nil)), nil
+}
+
+// Open decrypts a sealed secret. Strings that are not sealed are returned unchanged.
+func (v *Vault) Open(s string) (string, error) {
+	if !IsSealed(s) {
+		return s, nil
+	}
+	b, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(s, SecretPrefix))
+	if err != nil || len(b) < v.aead.NonceSize() {
+		return "", ErrInvalidSecret
+	}
+	n := v.aead.NonceSize()
+	plaintext, err := v.aead.Open(nil, b[:n], b[n:], nil)
+	if err != nil {
+		return "", ErrInvalidSecret
+	}
+	return string(plaintext), nil
+}
+
+// OpenAll decrypts all sealed strings reachable from the given pointer in place,
+// including the fields of structs and the elements of slices, maps and interfaces.
+func (v *Vault) OpenAll(ptr any) error {
+	return v.openValue(reflect.ValueOf(ptr))
+}
+
+func (v *Vault) openValue(rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		if rv.Kind() == reflect.Interface && rv.Elem().Kind() == reflect.String {
+			if s := rv.Elem().String(); IsSealed(s) && rv.CanSet() {
+				plain, err := v.Open(s)
+				if err != nil {
+					return err
+				}
+				rv.Set(reflect.ValueOf(plain))
+			}
+			return nil
+		}
+		return v.openValue(rv.Elem())
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if rv.Type().Field(i).IsExported() {
+				if err := v.openValue(rv.Field(i)); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := v.openValue(rv.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := rv.MapRange()
+		for iter.Next() {
+			// Map elements are not addressable, so they are copied and set back.
+			elem := reflect.New(iter.Value().Type()).Elem()
+			elem.Set(iter.Value())
+			if err := v.openValue(elem); err != nil {
+				return err
+			}
+			rv.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.String:
+		if s := rv.String(); IsSealed(s) && rv.CanSet() {
+			plain, err := v.Open(s)
+			if err != nil {
+				return err
+			}
+			rv.SetString(plain)
+		}
+	}
+	return nil
+}
+
+// Wrap encrypts the vault key with a key derived from the password, in the same format
+// as HashPassword except that the last part is the encrypted vault key.
+func (v *Vault) Wrap(password string) (string, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	kek, err := pbkdf2.Key(sha256.New, password, salt, Iterations, keySize)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("$%s$i=%d$%s$%s", Algorithm, Iterations,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(aead.Seal(nonce, nonce, v.key, nil))), nil
+}
+
+// UnwrapVault decrypts a vault key encrypted by Wrap with the password.
+func UnwrapVault(wrapped, password string) (*Vault, error) {
+	iter, salt, sealed, err := decodeHash(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	kek, err := pbkdf2.Key(sha256.New, password, salt, iter, keySize)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	n := aead.NonceSize()
+	if len(sealed) < n {
+		return nil, errInvalidHash
+	}
+	key, err := aead.Open(nil, sealed[:n], sealed[n:], nil)
+	if err != nil {
+		return nil, ErrWrongPassword
+	}
+	return newVault(key)
+}
+
+// Protect encrypts the vault key with the data protection of the system,
+// so it can be decrypted by any process on this machine without a password.
+// It's meant for services only, and must not be used to open the vault for users.
+func (v *Vault) Protect() (string, error) {
+	b, err := protect(v.key)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// UnprotectVault decrypts a vault key encrypted by Protect.
+func UnprotectVault(protected string) (*Vault, error) {
+	b, err := base64.StdEncoding.DecodeString(protected)
+	if err != nil {
+		return nil, err
+	}
+	key, err := unprotect(b)
+	if err != nil {
+		return nil, err
+	}
+	return newVault(key)
+}
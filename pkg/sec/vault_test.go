@@ -0,0 +1,93 @@
+package sec
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestVault(t *testing.T) {
+	v, err := NewVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := v.Seal("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsSealed(sealed) {
+		t.Errorf("Expected sealed secret, got: %v", sealed)
+	}
+	if again, _ := v.Seal("secret"); again != sealed {
+		t.Errorf("Expected: %v, got: %v", sealed, again)
+	}
+	if again, _ := v.Seal(sealed); again != sealed {
+		t.Errorf("Expected: %v, got: %v", sealed, again)
+	}
+	if plain, err := v.Open(sealed); err != nil || plain != "secret" {
+		t.Errorf("Expected: %v, got: %v, %v", "secret", plain, err)
+	}
+	if plain, err := v.Open("plain"); err != nil || plain != "plain" {
+		t.Errorf("Expected: %v, got: %v, %v", "plain", plain, err)
+	}
+	other, err := NewVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = other.Open(sealed); !errors.Is(err, ErrInvalidSecret) {
+		t.Errorf("Expected: %v, got: %v", ErrInvalidSecret, err)
+	}
+
+	// Open nested values
+	type inner struct {
+		Password string
+	}
+	type outer struct {
+		Token   string
+		Inner   *inner
+		List    []any
+		Headers map[string]string
+	}
+	input := outer{
+		Token:   sealed,
+		Inner:   &inner{Password: sealed},
+		List:    []any{&inner{Password: sealed}, sealed},
+		Headers: map[string]string{"Authorization": sealed},
+	}
+	expected := outer{
+		Token:   "secret",
+		Inner:   &inner{Password: "secret"},
+		List:    []any{&inner{Password: "secret"}, "secret"},
+		Headers: map[string]string{"Authorization": "secret"},
+	}
+	if err = v.OpenAll(&input); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(input, expected) {
+		t.Errorf("Expected: %v, got: %v", expected, input)
+	}
+}
+
+func TestWrapVault(t *testing.T) {
+	defer func(n int) { Iterations = n }(Iterations)
+	Iterations = 1000
+	v, err := NewVault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapped, err := v.Wrap("123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = UnwrapVault(wrapped, "1234567"); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("Expected: %v, got: %v", ErrWrongPassword, err)
+	}
+	unwrapped, err := UnwrapVault(wrapped, "123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, _ := v.Seal("secret")
+	if plain, err := unwrapped.Open(sealed); err != nil || plain != "secret" {
+		t.Errorf("Expected: %v, got: %v, %v", "secret", plain, err)
+	}
+}
@@ -0,0 +1,233 @@
+// Package sharelink encodes configs into share links and decodes them.
+//
+// A version 1 link is the scheme followed by the standard Base64 encoding of the config file.
+// A version 2 link is the scheme followed by the unpadded URL-safe Base64 encoding of:
+//
+//	version (1 byte, 2) | flags (1 byte) | expiry (8 bytes, Unix seconds, 0 for none) |
+//	[salt (16 bytes) | iterations (4 bytes) | nonce (12 bytes)] | body |
+//	[public key (32 bytes) | signature (64 bytes)]
+//
+// The body is the config file compressed by DEFLATE. If the link is encrypted, the body is
+// sealed by AES-256-GCM with a key derived from the passphrase by PBKDF2-SHA256, and the
+// preceding bytes are authenticated as additional data. If the link is signed, the Ed25519
+// signature covers all preceding bytes. All integers are big-endian.
+package sharelink
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/koho/frpmgr/pkg/sec"
+)
+
+// Scheme is the prefix of share links.
+const Scheme = "frp://"
+
+// Version is the latest version of share links.
+const Version = 2
+
+// Flags of version 2 links.
+const (
+	flagEncrypted = 1 << iota
+	flagSigned
+)
+
+const (
+	headerSize = 10
+	saltSize   = 16
+	keySize    = 32
+	// maxSize limits the size of the decompressed config.
+	maxSize = 4 << 20
+)
+
+var (
+	ErrInvalidLink        = errors.New("invalid share link")
+	ErrUnsupportedVersion = errors.New("unsupported share link version")
+	// ErrPassphraseRequired is returned when decoding an encrypted link without a passphrase.
+	ErrPassphraseRequired = errors.New("share link is encrypted with a passphrase")
+	ErrWrongPassphrase    = errors.New("wrong passphrase")
+	ErrBadSignature       = errors.New("share link signature is invalid")
+	ErrExpired            = errors.New("share link has expired")
+)
+
+// Options configures the encoding of a share link.
+type Options struct {
+	// Passphrase encrypts the link if it's not empty.
+	Passphrase string
+	// SigningKey signs the link if it's not nil.
+	SigningKey ed25519.PrivateKey
+	// Expires is the time after which the link and the imported config are no longer valid.
+	// A zero time means no expiry. The importer should limit the expiry of the config
+	// by ClientConfig.LimitExpiry.
+	Expires time.Time
+}
+
+// Link is a decoded share link.
+type Link struct {
+	Version int
+	// Content is the config file.
+	Content   []byte
+	Encrypted bool
+	// Signer is the public key of a valid signature, or nil if the link is not signed.
+	Signer  ed25519.PublicKey
+	Expires time.Time
+}
+
+// Encode returns a version 2 share link of the config file.
+func Encode(content []byte, opts Options) (string, error) {
+	var body bytes.Buffer
+	w, err := flate.NewWriter(&body, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err = w.Write(content); err != nil {
+		return "", err
+	}
+	if err = w.Close(); err != nil {
+		return "", err
+	}
+	b := make([]byte, headerSize, headerSize+body.Len())
+	b[0] = Version
+	// Flags are set first, as they are authenticated by the encryption
+	if opts.Passphrase != "" {
+		b[1] |= flagEncrypted
+	}
+	if opts.SigningKey != nil {
+		b[1] |= flagSigned
+	}
+	if !opts.Expires.IsZero() {
+		binary.BigEndian.PutUint64(b[2:], uint64(opts.Expires.Unix()))
+	}
+	if opts.Passphrase != "" {
+		salt := make([]byte, saltSize)
+		if _, err = rand.Read(salt); err != nil {
+			return "", err
+		}
+		b = append(b, salt...)
+		b = binary.BigEndian.AppendUint32(b, uint32(sec.Iterations))
+		aead, err := newAEAD(opts.Passphrase, salt, sec.Iterations)
+		if err != nil {
+			return "", err
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err = rand.Read(nonce); err != nil {
+			return "", err
+		}
+		b = append(b, nonce...)
+		b = aead.Seal(b, nonce, body.Bytes(), b)
+	} else {
+		b = append(b, body.Bytes()...)
+	}
+	if opts.SigningKey != nil {
+		b = append(b, opts.SigningKey.Public().(ed25519.PublicKey)...)
+		b = append(b, ed25519.Sign(opts.SigningKey, b)...)
+	}
+	return Scheme + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// IsLink reports whether the text is a share link.
+func IsLink(text string) bool {
+	return strings.HasPrefix(text, Scheme)
+}
+
+// Decode parses a share link of any version. The passphrase is only used for encrypted links.
+// The signature of a signed link is always verified, and an expired link is rejected.
+func Decode(link, passphrase string) (*Link, error) {
+	if !IsLink(link) {
+		return nil, ErrInvalidLink
+	}
+	text := strings.TrimPrefix(link, Scheme)
+	b, err := base64.RawURLEncoding.DecodeString(text)
+	if err != nil || len(b) == 0 || b[0] != Version {
+		// A version 1 link always starts with the text of a config file
+		content, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return nil, ErrInvalidLink
+		}
+		return &Link{Version: 1, Content: content}, nil
+	}
+	if len(b) < headerSize {
+		return nil, ErrInvalidLink
+	}
+	flags := b[1]
+	if flags&^(flagEncrypted|flagSigned) != 0 {
+		return nil, ErrUnsupportedVersion
+	}
+	l := &Link{Version: Version, Encrypted: flags&flagEncrypted != 0}
+	if flags&flagSigned != 0 {
+		n := len(b) - ed25519.PublicKeySize - ed25519.SignatureSize
+		if n < headerSize {
+			return nil, ErrInvalidLink
+		}
+		key := ed25519.PublicKey(b[n : n+ed25519.PublicKeySize])
+		if !ed25519.Verify(key, b[:n+ed25519.PublicKeySize], b[n+ed25519.PublicKeySize:]) {
+			return nil, ErrBadSignature
+		}
+		l.Signer = bytes.Clone(key)
+		b = b[:n]
+	}
+	if expiry := binary.BigEndian.Uint64(b[2:headerSize]); expiry != 0 {
+		l.Expires = time.Unix(int64(expiry), 0)
+		if time.Now().After(l.Expires) {
+			return nil, ErrExpired
+		}
+	}
+	body := b[headerSize:]
+	if l.Encrypted {
+		if passphrase == "" {
+			return nil, ErrPassphraseRequired
+		}
+		if len(body) < saltSize+4 {
+			return nil, ErrInvalidLink
+		}
+		salt := body[:saltSize]
+		// The iteration count is untrusted, so bound it to keep a crafted link
+		// from stalling the key derivation
+		iter := int(binary.BigEndian.Uint32(body[saltSize:]))
+		if iter < 1 || iter > 4*sec.Iterations {
+			return nil, ErrInvalidLink
+		}
+		aead, err := newAEAD(passphrase, salt, iter)
+		if err != nil {
+			return nil, ErrInvalidLink
+		}
+		n := headerSize + saltSize + 4 + aead.NonceSize()
+		if len(b) < n {
+			return nil, ErrInvalidLink
+		}
+		if body, err = aead.Open(nil, b[n-aead.NonceSize():n], b[n:], b[:n]); err != nil {
+			return nil, ErrWrongPassphrase
+		}
+	}
+	if l.Content, err = io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(body)), maxSize+1)); err != nil {
+		return nil, ErrInvalidLink
+	}
+	if len(l.Content) > maxSize {
+		return nil, ErrInvalidLink
+	}
+	return l, nil
+}
+
+func newAEAD(passphrase string, salt []byte, iter int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iter, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,132 @@
+package sharelink
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/koho/frpmgr/pkg/sec"
+)
+
+const testConfig = `serverAddr = "example.com"
+serverPort = 7000
+auth.token = "123456"
+
+[[proxies]]
+name = "ssh"
+type = "tcp"
+localPort = 22
+remotePort = 6000
+`
+
+func TestDecodeV1(t *testing.T) {
+	link, err := Decode(Scheme+base64.StdEncoding.EncodeToString([]byte(testConfig)), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Version != 1 || string(link.Content) != testConfig {
+		t.Errorf("Expected: %v, got: %v", testConfig, string(link.Content))
+	}
+}
+
+func TestEncode(t *testing.T) {
+	defer func(n int) { sec.Iterations = n }(sec.Iterations)
+	sec.Iterations = 1000
+	_, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	tests := []struct {
+		opts       Options
+		passphrase string
+		err        error
+	}{
+		{Options{}, "", nil},
+		{Options{Expires: expires}, "", nil},
+		{Options{Passphrase: "secret"}, "secret", nil},
+		{Options{Passphrase: "secret"}, "", ErrPassphraseRequired},
+		{Options{Passphrase: "secret"}, "wrong", ErrWrongPassphrase},
+		{Options{SigningKey: key}, "", nil},
+		{Options{Passphrase: "secret", SigningKey: key, Expires: expires}, "secret", nil},
+		{Options{Expires: time.Now().Add(-time.Hour)}, "", ErrExpired},
+	}
+	for i, test := range tests {
+		s, err := Encode([]byte(testConfig), test.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		link, err := Decode(s, test.passphrase)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if link.Version != Version || string(link.Content) != testConfig {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, testConfig, string(link.Content))
+		}
+		if !link.Expires.Equal(test.opts.Expires) {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.opts.Expires, link.Expires)
+		}
+		if test.opts.SigningKey != nil && !bytes.Equal(link.Signer, test.opts.SigningKey.Public().(ed25519.PublicKey)) {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.opts.SigningKey.Public(), link.Signer)
+		}
+		if link.Encrypted != (test.opts.Passphrase != "") {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.opts.Passphrase != "", link.Encrypted)
+		}
+	}
+}
+
+func TestSignature(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Encode([]byte(testConfig), Options{SigningKey: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s[len(Scheme):])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Tamper the expiry
+	b[headerSize-1] ^= 1
+	if _, err = Decode(Scheme+base64.RawURLEncoding.EncodeToString(b), ""); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("Expected: %v, got: %v", ErrBadSignature, err)
+	}
+}
+
+func TestIterations(t *testing.T) {
+	defer func(n int) { sec.Iterations = n }(sec.Iterations)
+	sec.Iterations = 1000
+	s, err := Encode([]byte(testConfig), Options{Passphrase: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s[len(Scheme):])
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		iter uint32
+		err  error
+	}{
+		{0, ErrInvalidLink},
+		{4000, ErrWrongPassphrase},
+		{4001, ErrInvalidLink},
+		{1<<32 - 1, ErrInvalidLink},
+	}
+	for i, test := range tests {
+		binary.BigEndian.PutUint32(b[headerSize+saltSize:], test.iter)
+		if _, err = Decode(Scheme+base64.RawURLEncoding.EncodeToString(b), "secret"); !errors.Is(err, test.err) {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.err, err)
+		}
+	}
+}
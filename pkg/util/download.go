@@ -0,0 +1,250 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultDownloadTimeout limits the time of each download attempt by default.
+	DefaultDownloadTimeout = 30 * time.Second
+	// DefaultMaxDownloadSize limits the size of a downloaded file by default.
+	DefaultMaxDownloadSize = 10 << 20
+	// ProxyDirect disables the proxy of downloads.
+	ProxyDirect = "direct"
+)
+
+// retryDelay is the delay before the first retry, which doubles after each retry.
+var retryDelay = time.Second
+
+// NetworkError is returned when a download fails before a response is received.
+type NetworkError struct {
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return "network error: " + e.Err.Error()
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// StatusError is returned when the server responds with an unexpected status.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return "bad status: " + e.Status
+}
+
+// SizeError is returned when the downloaded file is larger than the limit.
+type SizeError struct {
+	Limit int64
+}
+
+func (e *SizeError) Error() string {
+	return fmt.Sprintf("file is larger than %d bytes", e.Limit)
+}
+
+// ChecksumError is returned when the SHA-256 checksum of the downloaded file is not the expected one.
+type ChecksumError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("sha256 checksum mismatch: expected %s, got %s", e.Expected, e.Actual)
+}
+
+// DownloadOptions configures a download.
+type DownloadOptions struct {
+	// Header is added to the request.
+	Header http.Header
+	// Token is sent as a bearer token if it's not empty.
+	Token string
+	// Username and Password are sent by basic authentication if Username is not empty.
+	// The user information of the URL is used otherwise.
+	Username string
+	Password string
+	// MaxSize limits the size of the file. Zero means DefaultMaxDownloadSize.
+	MaxSize int64
+	// SHA256 is the expected hex checksum of the file. The fragment "sha256=<hex>"
+	// of the URL is used if it's empty.
+	SHA256 string
+	// Proxy is the URL of the proxy server, or ProxyDirect to connect directly.
+	// The proxy of the system is used if it's empty.
+	Proxy string
+	// Retries is the number of retries after a network error or a server error.
+	Retries int
+	// Timeout limits the time of each attempt. Zero means DefaultDownloadTimeout.
+	Timeout time.Duration
+}
+
+// DownloadResult is a downloaded file.
+type DownloadResult struct {
+	// Filename is from the Content-Disposition header, or the base name of the URL path.
+	Filename  string
+	MediaType string
+	Data      []byte
+	// Header of the response.
+	Header http.Header
+	// NotModified reports whether the server responds that the file is not modified
+	// since a conditional request, and there's no data.
+	NotModified bool
+}
+
+// Download downloads a file from the given url. The errors of network, status, size and
+// checksum are returned as NetworkError, StatusError, SizeError and ChecksumError.
+func Download(ctx context.Context, rawURL string, opts DownloadOptions) (*DownloadResult, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	checksum := opts.SHA256
+	if checksum == "" {
+		if values, err := url.ParseQuery(u.Fragment); err == nil {
+			checksum = values.Get("sha256")
+		}
+	}
+	checksum = strings.ToLower(checksum)
+	u.Fragment, u.RawFragment = "", ""
+	proxy, err := proxyFunc(opts.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{
+		Timeout:   opts.Timeout,
+		Transport: &http.Transport{Proxy: proxy},
+	}
+	if client.Timeout <= 0 {
+		client.Timeout = DefaultDownloadTimeout
+	}
+	delay := retryDelay
+	for attempt := 0; ; attempt++ {
+		result, err := download(ctx, client, u.String(), &opts)
+		if err == nil || attempt >= opts.Retries || !retryable(err) {
+			if err == nil && checksum != "" && !result.NotModified {
+				sum := sha256.Sum256(result.Data)
+				if actual := hex.EncodeToString(sum[:]); actual != checksum {
+					return nil, &ChecksumError{Expected: checksum, Actual: actual}
+				}
+			}
+			return result, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func download(ctx context.Context, client *http.Client, url string, opts *DownloadOptions) (*DownloadResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, values := range opts.Header {
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
+	if opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.Token)
+	} else if opts.Username != "" {
+		req.SetBasicAuth(opts.Username, opts.Password)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, &NetworkError{err}
+	}
+	defer resp.Body.Close()
+
+	result := &DownloadResult{Header: resp.Header}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		result.NotModified = true
+		return result, nil
+	default:
+		return nil, &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	limit := opts.MaxSize
+	if limit <= 0 {
+		limit = DefaultMaxDownloadSize
+	}
+	if resp.ContentLength > limit {
+		return nil, &SizeError{limit}
+	}
+	var buf bytes.Buffer
+	if _, err = io.Copy(&buf, io.LimitReader(resp.Body, limit+1)); err != nil {
+		return nil, &NetworkError{err}
+	}
+	if int64(buf.Len()) > limit {
+		return nil, &SizeError{limit}
+	}
+	result.Data = buf.Bytes()
+	// Use the filename in header
+	if cd := resp.Header.Get("Content-Disposition"); cd != "" {
+		if _, params, err := mime.ParseMediaType(cd); err == nil {
+			result.Filename = params["filename"]
+		}
+	}
+	// Use the base filename part of the URL
+	if result.Filename == "" {
+		result.Filename = path.Base(resp.Request.URL.Path)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		if result.MediaType, _, err = mime.ParseMediaType(ct); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// retryable reports whether the download may succeed by trying again.
+func retryable(err error) bool {
+	var netErr *NetworkError
+	var statusErr *StatusError
+	switch {
+	case errors.As(err, &netErr):
+		return true
+	case errors.As(err, &statusErr):
+		return statusErr.StatusCode >= 500 || statusErr.StatusCode == http.StatusTooManyRequests
+	default:
+		return false
+	}
+}
+
+// proxyFunc returns the proxy function of the transport for the proxy option.
+func proxyFunc(proxy string) (func(*http.Request) (*url.URL, error), error) {
+	switch proxy {
+	case "":
+		return systemProxy, nil
+	case ProxyDirect:
+		return nil, nil
+	}
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy %q", proxy)
+	}
+	return http.ProxyURL(u), nil
+}
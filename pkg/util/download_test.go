@@ -0,0 +1,88 @@
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDownload(t *testing.T) {
+	defer func(d time.Duration) { retryDelay = d }(retryDelay)
+	retryDelay = time.Millisecond
+	content := []byte("serverAddr = \"example.com\"\n")
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+	failures := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth.toml":
+			if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("X-Team") != "ops" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		case "/flaky.toml":
+			if failures < 2 {
+				failures++
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case "/cached.toml":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/toml")
+		w.Write(content)
+	}))
+	defer ts.Close()
+
+	var statusErr *StatusError
+	var sizeErr *SizeError
+	var checksumErr *ChecksumError
+	var netErr *NetworkError
+	tests := []struct {
+		path        string
+		opts        DownloadOptions
+		err         any
+		notModified bool
+	}{
+		{"/a.toml", DownloadOptions{}, nil, false},
+		{"/a.toml#sha256=" + strings.ToUpper(checksum), DownloadOptions{}, nil, false},
+		{"/a.toml#sha256=" + checksum, DownloadOptions{}, nil, false},
+		{"/a.toml#sha256=" + checksum[1:] + "0", DownloadOptions{}, &checksumErr, false},
+		{"/a.toml", DownloadOptions{SHA256: "00"}, &checksumErr, false},
+		{"/a.toml", DownloadOptions{MaxSize: 10}, &sizeErr, false},
+		{"/auth.toml", DownloadOptions{}, &statusErr, false},
+		{"/auth.toml", DownloadOptions{Token: "token", Header: http.Header{"x-team": {"ops"}}}, nil, false},
+		{"/flaky.toml", DownloadOptions{Retries: 1}, &statusErr, false},
+		{"/flaky.toml", DownloadOptions{Retries: 1}, nil, false},
+		{"/cached.toml", DownloadOptions{Header: http.Header{"If-None-Match": {`"v1"`}}}, nil, true},
+	}
+	for i, test := range tests {
+		result, err := Download(context.Background(), ts.URL+test.path, test.opts)
+		if test.err == nil {
+			if err != nil {
+				t.Errorf("Test %d: Expected: %v, got: %v", i, nil, err)
+			} else if result.NotModified != test.notModified {
+				t.Errorf("Test %d: Expected: %v, got: %v", i, test.notModified, result.NotModified)
+			} else if !result.NotModified && string(result.Data) != string(content) {
+				t.Errorf("Test %d: Expected: %v, got: %v", i, string(content), result)
+			}
+		} else if !errors.As(err, test.err) {
+			t.Errorf("Test %d: Expected: %T, got: %v", i, test.err, err)
+		}
+	}
+	if _, err := Download(context.Background(), "http://127.0.0.1:1/a.toml", DownloadOptions{Proxy: ProxyDirect}); !errors.As(err, &netErr) {
+		t.Errorf("Expected: %v, got: %v", "network error", err)
+	}
+	if _, err := Download(context.Background(), ts.URL, DownloadOptions{Proxy: "127.0.0.1"}); err == nil {
+		t.Errorf("Expected: %v, got: %v", "invalid proxy", err)
+	}
+}
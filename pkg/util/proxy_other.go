@@ -0,0 +1,13 @@
+//go:build !windows
+
+package util
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// systemProxy returns the proxy given by the environment variables.
+func systemProxy(req *http.Request) (*url.URL, error) {
+	return http.ProxyFromEnvironment(req)
+}
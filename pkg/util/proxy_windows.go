@@ -0,0 +1,73 @@
+package util
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// systemProxy returns the proxy given by the environment variables,
+// or the proxy in the Internet Settings of the current user.
+func systemProxy(req *http.Request) (*url.URL, error) {
+	if u, err := http.ProxyFromEnvironment(req); u != nil || err != nil {
+		return u, err
+	}
+	k, err := registry.OpenKey(registry.CURRENT_USER, `Software\Microsoft\Windows\CurrentVersion\Internet Settings`, registry.QUERY_VALUE)
+	if err != nil {
+		return nil, nil
+	}
+	defer k.Close()
+	if enabled, _, err := k.GetIntegerValue("ProxyEnable"); err != nil || enabled == 0 {
+		return nil, nil
+	}
+	server, _, err := k.GetStringValue("ProxyServer")
+	if err != nil || server == "" {
+		return nil, nil
+	}
+	if override, _, err := k.GetStringValue("ProxyOverride"); err == nil && bypassProxy(req.URL.Hostname(), override) {
+		return nil, nil
+	}
+	return parseProxyServer(server, req.URL.Scheme)
+}
+
+// parseProxyServer returns the proxy of the given scheme in the format of the "ProxyServer"
+// setting, which is either "host:port" for all schemes or "http=host:port;https=host:port".
+func parseProxyServer(server, scheme string) (*url.URL, error) {
+	proxy := server
+	if strings.Contains(server, "=") {
+		proxy = ""
+		for _, item := range strings.Split(server, ";") {
+			if k, v, ok := strings.Cut(strings.TrimSpace(item), "="); ok && strings.EqualFold(k, scheme) {
+				proxy = v
+				break
+			}
+		}
+		if proxy == "" {
+			return nil, nil
+		}
+	}
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	return url.Parse(proxy)
+}
+
+// bypassProxy reports whether the host matches the "ProxyOverride" setting, which is a list of
+// wildcard patterns separated by semicolons. The pattern "<local>" matches hosts without dots.
+func bypassProxy(host, override string) bool {
+	for _, pattern := range strings.Split(override, ";") {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "<local>" {
+			if !strings.Contains(host, ".") && net.ParseIP(host) == nil {
+				return true
+			}
+		} else if ok, _ := path.Match(pattern, strings.ToLower(host)); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
@@ -34,3 +35,26 @@ func RandToken(n int) (string, error) {
 	}
 	return hex.EncodeToString(bytes), nil
 }
+
+// Slug returns a lowercase file name derived from the given string, which is at most n characters long.
+// Letters and digits are kept, and other characters are replaced by single hyphens.
+func Slug(s string, n int) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(s) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			hyphen = true
+			continue
+		}
+		if hyphen && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		hyphen = false
+		b.WriteRune(r)
+	}
+	slug := []rune(b.String())
+	if len(slug) > n {
+		slug = slug[:n]
+	}
+	return strings.TrimRight(string(slug), "-")
+}
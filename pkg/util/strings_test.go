@@ -31,3 +31,22 @@ func TestRuneSizeInString(t *testing.T) {
 		t.Errorf("Expected: %v, got: %v", expected, output)
 	}
 }
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "My Server", expected: "my-server"},
+		{input: "  frp.example.com:7000 ", expected: "frp-example-com-7000"},
+		{input: "Home_Office (2)", expected: "home-office-2"},
+		{input: "家里 NAS", expected: "家里-nas"},
+		{input: "Two hundred thousand miles", expected: "two-hundred-thousand"},
+		{input: "***", expected: ""},
+	}
+	for i, test := range tests {
+		if output := Slug(test.input, 20); output != test.expected {
+			t.Errorf("Test %d: expected: %v, got: %v", i, test.expected, output)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fatedier/frp/pkg/util/log"
+
+	"github.com/koho/frpmgr/pkg/profile"
+)
+
+// backupCheckInterval is the interval to check whether a scheduled backup is due.
+const backupCheckInterval = 10 * time.Minute
+
+// ScheduleBackups writes the scheduled backups of the data root until the context is canceled.
+func ScheduleBackups(ctx context.Context, repo *profile.Repository) {
+	ticker := time.NewTicker(backupCheckInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := repo.BackupDue(time.Now()); err != nil {
+			log.Warnf("scheduled backup error: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// InstallRestoredServices registers the services of profiles restored to the given data root again.
+// Only the profiles that start at boot are started, the others are left stopped.
+func InstallRestoredServices(root string, list []*profile.Profile) error {
+	var errs []error
+	for _, p := range list {
+		if !p.Data.AutoStart() {
+			continue
+		}
+		err := VerifyClientConfig(p.Path)
+		if logFile := p.Data.LogFile; err == nil && logFile != "" && logFile != "console" {
+			err = os.MkdirAll(filepath.Dir(logFile), os.ModePerm)
+		}
+		if err == nil {
+			err = InstallService(p.Name(), root, p.Path, false)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
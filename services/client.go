@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fatedier/frp/client"
@@ -18,8 +20,12 @@ import (
 	glog "github.com/fatedier/golib/log"
 
 	"github.com/koho/frpmgr/pkg/consts"
+	"github.com/koho/frpmgr/pkg/ipc"
 )
 
+// sampleInterval is the interval at which the phase of proxies is recorded.
+const sampleInterval = time.Second
+
 type FrpClientService struct {
 	svr            *client.Service
 	file           string
@@ -27,13 +33,25 @@ type FrpClientService struct {
 	done           chan struct{}
 	statusExporter client.StatusExporter
 	logger         *glog.RotateFileWriter
+	stats          *proxyStats
+	changes        ipc.Notifier
+	started        time.Time
+	reloads        atomic.Int64
+
+	mu    sync.RWMutex
+	names []string
 }
 
+// NewFrpClientService creates a frp client service for the given config file.
+// Secrets sealed by the vault of the application are decrypted before the config is used.
 func NewFrpClientService(cfgFile string) (*FrpClientService, error) {
 	result, err := config.LoadClientConfigResult(cfgFile, false)
 	if err != nil {
 		return nil, err
 	}
+	if err = openSecrets(result); err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets: %w", err)
+	}
 	configSource := source.NewConfigSource()
 	if err := configSource.ReplaceAll(result.Proxies, result.Visitors); err != nil {
 		return nil, fmt.Errorf("failed to set config source: %w", err)
@@ -75,10 +93,13 @@ func NewFrpClientService(cfgFile string) (*FrpClientService, error) {
 		return nil, err
 	}
 
+	stats := newProxyStats()
 	svr, err := client.NewService(client.ServiceOptions{
 		Common:                 result.Common,
 		ConfigSourceAggregator: aggregator,
 		ConfigFilePath:         cfgFile,
+		ConnectorCreator:       stats.wrapConnector,
+		HandleWorkConnCb:       stats.handleWorkConn,
 	})
 	if err != nil {
 		return nil, err
@@ -91,6 +112,8 @@ func NewFrpClientService(cfgFile string) (*FrpClientService, error) {
 		done:           make(chan struct{}),
 		statusExporter: svr.StatusExporter(),
 		logger:         logger,
+		stats:          stats,
+		names:          proxyNames(proxyCfgs),
 	}, nil
 }
 
@@ -102,6 +125,8 @@ func (s *FrpClientService) Run() {
 		defer log.Infof("frpc service for config file [%s] stopped", s.file)
 	}
 
+	s.started = time.Now()
+	go s.sample()
 	// There's no guarantee that this function will return after a close call.
 	// So we can't wait for the Run function to finish.
 	if err := s.svr.Run(context.Background()); err != nil {
@@ -125,6 +150,9 @@ func (s *FrpClientService) Reload() error {
 	if err != nil {
 		return fmt.Errorf("%w: %v", configmgmt.ErrInvalidArgument, err)
 	}
+	if err = openSecrets(result); err != nil {
+		return fmt.Errorf("%w: failed to decrypt secrets: %v", configmgmt.ErrInvalidArgument, err)
+	}
 
 	proxyCfgsForValidation, visitorCfgsForValidation := config.FilterClientConfigurers(
 		result.Common,
@@ -141,6 +169,11 @@ func (s *FrpClientService) Reload() error {
 	if err := s.svr.UpdateConfigSource(result.Common, result.Proxies, result.Visitors); err != nil {
 		return fmt.Errorf("%w: %v", configmgmt.ErrApplyConfig, err)
 	}
+	s.mu.Lock()
+	s.names = proxyNames(proxyCfgsForValidation)
+	s.mu.Unlock()
+	s.reloads.Add(1)
+	s.changes.Notify()
 	return nil
 }
 
@@ -152,6 +185,9 @@ func (s *FrpClientService) GetProxyStatus(name string) (status *proxy.WorkingSta
 	status, ok = s.statusExporter.GetProxyStatus(name)
 	if ok {
 		status.Name = name
+		if s.stats.observe(status) {
+			s.changes.Notify()
+		}
 		if status.Err == "" {
 			if status.Type == consts.ProxyTypeTCP || status.Type == consts.ProxyTypeUDP {
 				status.RemoteAddr = s.cfg.ServerAddr + status.RemoteAddr
@@ -163,6 +199,56 @@ func (s *FrpClientService) GetProxyStatus(name string) (status *proxy.WorkingSta
 	return
 }
 
+// GetProxyStats returns the traffic counters and phase history of a proxy.
+func (s *FrpClientService) GetProxyStats(name string) (ipc.ProxyStats, bool) {
+	return s.stats.get(name)
+}
+
+// GetServiceStats returns the start time and reload count of the service.
+func (s *FrpClientService) GetServiceStats() ipc.ServiceStats {
+	return ipc.ServiceStats{StartTime: s.started, Reloads: int(s.reloads.Load())}
+}
+
+// Subscribe notifies the phase changes of proxies found by sample, and the reloads of the service.
+func (s *FrpClientService) Subscribe() (<-chan struct{}, func()) {
+	return s.changes.Subscribe()
+}
+
+// sample records the phase of proxies periodically, so the history is kept
+// even if no one is querying the status. Subscribers are notified of phase changes.
+func (s *FrpClientService) sample() {
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.mu.RLock()
+			names := s.names
+			s.mu.RUnlock()
+			changed := false
+			for _, name := range names {
+				if status, ok := s.statusExporter.GetProxyStatus(name); ok {
+					status.Name = name
+					changed = s.stats.observe(status) || changed
+				}
+			}
+			if changed {
+				s.changes.Notify()
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func proxyNames(cfgs []v1.ProxyConfigurer) []string {
+	names := make([]string, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		names = append(names, cfg.GetBaseConfig().Name)
+	}
+	return names
+}
+
 func initLogger(logPath string, levelStr string, maxDays int) *glog.RotateFileWriter {
 	var options []glog.Option
 	writer := glog.NewRotateFileWriter(glog.RotateFileConfig{
@@ -24,18 +24,42 @@ func serviceManager() (*mgr.Mgr, error) {
 	return cachedServiceManager, nil
 }
 
-// InstallService runs the program as Windows service
-func InstallService(name string, configPath string, manual bool) error {
-	m, err := serviceManager()
+// InstallService runs the program as Windows service.
+// The data root is passed to the service, so it doesn't depend on the location of the program.
+func InstallService(name string, root string, configPath string, manual bool) error {
+	service, err := createService(name, root, configPath, manual)
 	if err != nil {
 		return err
 	}
-	path, err := os.Executable()
+	err = service.Start()
+	service.Close()
+	return err
+}
+
+// registerService registers the service like InstallService without starting it.
+func registerService(name string, root string, configPath string, manual bool) error {
+	service, err := createService(name, root, configPath, manual)
 	if err != nil {
 		return err
 	}
+	return service.Close()
+}
+
+// createService creates the service of a config, replacing the existing one.
+func createService(name string, root string, configPath string, manual bool) (*mgr.Service, error) {
+	m, err := serviceManager()
+	if err != nil {
+		return nil, err
+	}
+	path, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
 	if configPath, err = filepath.Abs(configPath); err != nil {
-		return err
+		return nil, err
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return nil, err
 	}
 	serviceName := ServiceNameOfClient(configPath)
 	service, err := m.OpenService(serviceName)
@@ -43,12 +67,12 @@ func InstallService(name string, configPath string, manual bool) error {
 		_, err = service.Query()
 		if err != nil && err != windows.ERROR_SERVICE_MARKED_FOR_DELETE {
 			service.Close()
-			return err
+			return nil, err
 		}
 		err = service.Delete()
 		service.Close()
 		if err != nil && err != windows.ERROR_SERVICE_MARKED_FOR_DELETE {
-			return err
+			return nil, err
 		}
 		for i := 0; i < 2; i++ {
 			service, err = m.OpenService(serviceName)
@@ -73,14 +97,7 @@ func InstallService(name string, configPath string, manual bool) error {
 	if manual {
 		conf.StartType = mgr.StartManual
 	}
-	service, err = m.CreateService(serviceName, path, conf, "-c", configPath)
-	if err != nil {
-		return err
-	}
-
-	err = service.Start()
-	service.Close()
-	return err
+	return m.CreateService(serviceName, path, conf, "-root", root, "-c", configPath)
 }
 
 // UninstallService stops and removes the given service
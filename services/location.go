@@ -0,0 +1,151 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/samber/lo"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/profile"
+)
+
+// installedService is a service of a profile which is registered again after the data root is moved.
+type installedService struct {
+	id      string
+	name    string
+	manual  bool
+	running bool
+}
+
+// MoveDataRoot moves the data of the repository to the given data location, and selects the location
+// next to the program. The services of profiles are stopped during the move, and registered again
+// with the new paths of configs. Only the services that were running are started again, and the management
+// service is started again with the new data root.
+// The repository of the new data root is returned. The data is left in place if the move fails.
+func MoveDataRoot(repo *profile.Repository, loc config.DataLocation) (*profile.Repository, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	exeDir := filepath.Dir(path)
+	root, err := loc.Root(exeDir)
+	if err != nil {
+		return nil, err
+	}
+	list, err := repo.List()
+	if err != nil {
+		return nil, err
+	}
+	// Stop the services, so the logs are not locked
+	var installed []installedService
+	for _, p := range list {
+		startType, pid, err := QueryStartInfo(p.Path)
+		if err != nil {
+			continue
+		}
+		if err = UninstallService(p.Path, true); err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name(), err)
+		}
+		installed = append(installed, installedService{p.ID(), p.Name(), startType == mgr.StartManual, pid > 0})
+	}
+	manager, err := stopManagerService()
+	if err != nil {
+		return nil, err
+	}
+	start := func(repo *profile.Repository) error {
+		var errs []error
+		for _, s := range installed {
+			install := registerService
+			if s.running {
+				install = InstallService
+			}
+			if err := install(s.name, repo.Root(), repo.PathOf(s.id), s.manual); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+			}
+		}
+		if manager {
+			errs = append(errs, startManagerService(repo.Root()))
+		}
+		return errors.Join(errs...)
+	}
+	dst, err := repo.Migrate(root)
+	if err == nil {
+		if err = config.SaveLocation(exeDir, loc); err != nil && dst != repo {
+			dst.Purge()
+		}
+	}
+	if err != nil {
+		return nil, errors.Join(err, start(repo))
+	}
+	if dst != repo {
+		// Logs of the stopped services may be locked for a while
+		repo.Purge()
+	}
+	return dst, start(dst)
+}
+
+// stopManagerService stops the management service and waits until it exits.
+// It returns false if the service is not installed.
+func stopManagerService() (bool, error) {
+	m, err := serviceManager()
+	if err != nil {
+		return false, err
+	}
+	service, err := m.OpenService(ManagerServiceName)
+	if err != nil {
+		if errors.Is(err, windows.ERROR_SERVICE_DOES_NOT_EXIST) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer service.Close()
+	service.Control(svc.Stop)
+	for try := 0; try < 10; try++ {
+		status, err := service.Query()
+		if err != nil {
+			return true, err
+		}
+		if status.State == svc.Stopped {
+			break
+		}
+		time.Sleep(time.Second / 3)
+	}
+	return true, nil
+}
+
+// startManagerService starts the management service with the given data root.
+func startManagerService(root string) error {
+	m, err := serviceManager()
+	if err != nil {
+		return err
+	}
+	path, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	service, err := m.OpenService(ManagerServiceName)
+	if err != nil {
+		return err
+	}
+	defer service.Close()
+	cfg, err := service.Config()
+	if err != nil {
+		return err
+	}
+	cfg.BinaryPathName = strings.Join(lo.Map([]string{path, "-api", "-root", root}, func(arg string, i int) string {
+		return syscall.EscapeArg(arg)
+	}), " ")
+	if err = service.UpdateConfig(cfg); err != nil {
+		return err
+	}
+	return service.Start()
+}
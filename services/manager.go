@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fatedier/frp/pkg/util/log"
+	"github.com/samber/lo"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+
+	"github.com/koho/frpmgr/pkg/api"
+	"github.com/koho/frpmgr/pkg/config"
+	"github.com/koho/frpmgr/pkg/ipc"
+	"github.com/koho/frpmgr/pkg/metrics"
+	"github.com/koho/frpmgr/pkg/profile"
+)
+
+// ManagerServiceName is the name of the service which serves the management API,
+// refreshes subscribed configs and writes scheduled backups. It runs whenever the API
+// is enabled or schedules are configured, and the GUI runs the schedules only without it.
+const ManagerServiceName = "frpmgr"
+
+// serviceController controls the frp services of profiles through the service manager.
+type serviceController struct{}
+
+func (serviceController) Running(p *profile.Profile) bool {
+	_, pid, err := QueryStartInfo(p.Path)
+	return err == nil && pid > 0
+}
+
+func (serviceController) Status(ctx context.Context, p *profile.Profile, names []string) ([]ipc.ProxyMessage, error) {
+	delta, err := ipc.Query(ctx, ipc.NewTransport(ServiceNameOfClient(p.Path)), names)
+	if err != nil {
+		return nil, err
+	}
+	return delta.Updated, nil
+}
+
+func (serviceController) Reload(p *profile.Profile) error {
+	if err := VerifyClientConfig(p.Path); err != nil {
+		return err
+	}
+	return ReloadService(p.Path)
+}
+
+func (serviceController) Stop(p *profile.Profile) error {
+	return UninstallService(p.Path, true)
+}
+
+type managerService struct {
+	// root is the data root. The directory of the program is used if it's empty.
+	root string
+}
+
+func (service *managerService) Execute(args []string, r <-chan svc.ChangeRequest, changes chan<- svc.Status) (svcSpecificEC bool, exitCode uint32) {
+	path, err := os.Executable()
+	if err != nil {
+		return
+	}
+	root := lo.Ternary(service.root != "", service.root, filepath.Dir(path))
+	if err = os.Chdir(root); err != nil {
+		return
+	}
+	changes <- svc.Status{State: svc.StartPending}
+
+	defer func() {
+		changes <- svc.Status{State: svc.StopPending}
+	}()
+
+	var app config.App
+	repo := profile.NewRepository(root, &app)
+	if _, err = config.UnmarshalAppConf(repo.AppFile(), &app); err != nil {
+		return
+	}
+	// The service also runs without the API to refresh subscribed configs and write scheduled backups
+	if !app.API.Enabled && !repo.Scheduled() {
+		return
+	}
+	vault, err := app.OpenServiceVault()
+	if err != nil {
+		return
+	}
+	repo.SetVault(vault)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// The channel is never closed without the API, so the service runs until it's stopped
+	var done chan struct{}
+	if app.API.Enabled {
+		s, err := api.NewServer(repo, serviceController{}, app.API.Token)
+		if err != nil {
+			return
+		}
+		if app.API.Metrics {
+			s.Handle("GET /metrics", metrics.Handler(func(ctx context.Context) []metrics.Target {
+				return gatherTargets(ctx, repo)
+			}))
+		}
+		done = make(chan struct{})
+		go func() {
+			defer close(done)
+			if err := s.Serve(ctx, app.API.Addr); err != nil {
+				log.Errorf("serve management api error: %v", err)
+			}
+		}()
+	}
+	go RefreshSubscriptions(ctx, repo, nil)
+	go ScheduleBackups(ctx, repo)
+
+	changes <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+
+	for {
+		select {
+		case c := <-r:
+			switch c.Cmd {
+			case svc.Stop, svc.Shutdown:
+				return
+			case svc.Interrogate:
+				changes <- c.CurrentStatus
+			default:
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// gatherTargets collects the status of all profiles for metrics.
+func gatherTargets(ctx context.Context, repo *profile.Repository) []metrics.Target {
+	list, err := repo.List()
+	if err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	targets := make([]metrics.Target, len(list))
+	var wg sync.WaitGroup
+	for i, p := range list {
+		t := &targets[i]
+		t.ID = p.ID()
+		t.Name = p.Name()
+		t.Proxies = lo.FlatMap(p.Data.Proxies, func(item *config.Proxy, i int) []string {
+			return item.GetAlias()
+		})
+		if d, err := config.Expiry(p.Path, p.Data.AutoDelete); err == nil {
+			t.Expiry = &d
+		}
+		if !(serviceController{}).Running(p) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if delta, err := ipc.Query(ctx, ipc.NewTransport(ServiceNameOfClient(p.Path)), t.Proxies); err == nil {
+				t.Exporter = delta
+			}
+		}()
+	}
+	wg.Wait()
+	return targets
+}
+
+// RunManager executes the management service in background service process.
+func RunManager(root string) error {
+	return svc.Run(ManagerServiceName, &managerService{root})
+}
+
+// InstallManagerService registers and starts the management service of the given data root.
+// The service is restarted if it's already installed, so the changes of the application
+// configuration are applied.
+func InstallManagerService(root string) error {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	installed, err := stopManagerService()
+	if err != nil {
+		return err
+	}
+	if installed {
+		return startManagerService(root)
+	}
+	m, err := serviceManager()
+	if err != nil {
+		return err
+	}
+	path, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	service, err := m.CreateService(ManagerServiceName, path, mgr.Config{
+		ServiceType:  windows.SERVICE_WIN32_OWN_PROCESS,
+		StartType:    mgr.StartAutomatic,
+		ErrorControl: mgr.ErrorNormal,
+		DisplayName:  DisplayNameOfClient("Management API"),
+		Description:  "Local management API for FRP Manager.",
+		SidType:      windows.SERVICE_SID_TYPE_UNRESTRICTED,
+	}, "-api", "-root", root)
+	if err != nil {
+		return err
+	}
+	err = service.Start()
+	service.Close()
+	return err
+}
+
+// UpdateManagerService applies the changed backup schedule to the management service.
+// The service is installed if the repository has schedules, or restarted if it's installed.
+// Without schedules and the API, the restarted service exits.
+func UpdateManagerService(repo *profile.Repository) error {
+	if !repo.Scheduled() {
+		if _, err := queryManagerService(); err != nil {
+			if errors.Is(err, windows.ERROR_SERVICE_DOES_NOT_EXIST) {
+				return nil
+			}
+			return err
+		}
+	}
+	return InstallManagerService(repo.Root())
+}
+
+// EnsureManagerService installs or starts the management service of the given data root
+// if it's not running. New subscriptions are picked up by a running service without a restart.
+func EnsureManagerService(root string) error {
+	if ManagerServiceRunning() {
+		return nil
+	}
+	return InstallManagerService(root)
+}
+
+// ManagerServiceRunning reports whether the management service is running, in which case
+// subscribed configs are refreshed and backups are written by the service.
+func ManagerServiceRunning() bool {
+	status, err := queryManagerService()
+	return err == nil && status.State == svc.Running
+}
+
+func queryManagerService() (svc.Status, error) {
+	m, err := serviceManager()
+	if err != nil {
+		return svc.Status{}, err
+	}
+	service, err := m.OpenService(ManagerServiceName)
+	if err != nil {
+		return svc.Status{}, err
+	}
+	defer service.Close()
+	return service.Query()
+}
+
+// UninstallManagerService stops and removes the management service.
+func UninstallManagerService() error {
+	m, err := serviceManager()
+	if err != nil {
+		return err
+	}
+	service, err := m.OpenService(ManagerServiceName)
+	if err != nil {
+		return err
+	}
+	service.Control(svc.Stop)
+	err = service.Delete()
+	err2 := service.Close()
+	if err != nil && err != windows.ERROR_SERVICE_MARKED_FOR_DELETE {
+		return err
+	}
+	return err2
+}
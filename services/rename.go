@@ -0,0 +1,38 @@
+package services
+
+import (
+	"errors"
+
+	"golang.org/x/sys/windows/svc/mgr"
+
+	"github.com/koho/frpmgr/pkg/profile"
+)
+
+// RenameProfile renames the profile by Repository.Rename. If the profile is moved to another
+// identifier, its service is stopped while the files are moved, and registered again with the
+// new config path, as the service name is derived from the identifier. A stopped service
+// is not started.
+func RenameProfile(repo *profile.Repository, p *profile.Profile, name string) error {
+	id, err := repo.RenameID(p, name)
+	if err != nil {
+		return err
+	}
+	startType, pid, err := QueryStartInfo(p.Path)
+	installed := err == nil && id != p.ID()
+	if installed {
+		if err = UninstallService(p.Path, true); err != nil {
+			return err
+		}
+	}
+	err = repo.Rename(p, name)
+	if installed {
+		// The service is registered again at the old path if the profile is not moved,
+		// and it's only started if it was running
+		install := registerService
+		if pid > 0 {
+			install = InstallService
+		}
+		return errors.Join(err, install(p.Name(), repo.Root(), p.Path, startType == mgr.StartManual))
+	}
+	return err
+}
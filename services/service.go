@@ -2,6 +2,7 @@ package services
 
 import (
 	"crypto/md5"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/Microsoft/go-winio"
 	"github.com/fatedier/frp/pkg/util/log"
+	"github.com/samber/lo"
 	"golang.org/x/sys/windows"
 	"golang.org/x/sys/windows/svc"
 
@@ -27,6 +29,9 @@ func DisplayNameOfClient(name string) string {
 }
 
 type frpService struct {
+	// root is the data root. Services installed by older versions don't have it,
+	// and use the directory of the program instead.
+	root       string
 	configPath string
 }
 
@@ -35,7 +40,8 @@ func (service *frpService) Execute(args []string, r <-chan svc.ChangeRequest, ch
 	if err != nil {
 		return
 	}
-	if err = os.Chdir(filepath.Dir(path)); err != nil {
+	// Relative paths, including the application configuration, are resolved against the data root.
+	if err = os.Chdir(lo.Ternary(service.root != "", service.root, filepath.Dir(path))); err != nil {
 		return
 	}
 	changes <- svc.Status{State: svc.StartPending}
@@ -68,7 +74,7 @@ func (service *frpService) Execute(args []string, r <-chan svc.ChangeRequest, ch
 		return
 	}
 
-	is, err := ipc.NewServer(args[0], svr)
+	is, err := ipc.NewServer(ipc.NewTransport(args[0]), svr)
 	if err != nil {
 		return
 	}
@@ -109,10 +115,27 @@ func (service *frpService) Execute(args []string, r <-chan svc.ChangeRequest, ch
 	}
 }
 
+// openSecrets decrypts the sealed secrets reachable from the given pointer
+// with the vault of the application. It does nothing if the vault is not enabled.
+func openSecrets(v any) error {
+	var app config.App
+	if _, err := config.UnmarshalAppConf(config.DefaultAppFile, &app); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	vault, err := app.OpenServiceVault()
+	if err != nil || vault == nil {
+		return err
+	}
+	return vault.OpenAll(v)
+}
+
 // Run executes frp service in background service process.
-func Run(configPath string) error {
+func Run(root string, configPath string) error {
 	serviceName := ServiceNameOfClient(configPath)
-	return svc.Run(serviceName, &frpService{configPath})
+	return svc.Run(serviceName, &frpService{root, configPath})
 }
 
 // ReloadService sends a reload event to the frp service
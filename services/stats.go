@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fatedier/frp/client"
+	"github.com/fatedier/frp/client/proxy"
+	"github.com/fatedier/frp/pkg/config/v1"
+	"github.com/fatedier/frp/pkg/msg"
+
+	"github.com/koho/frpmgr/pkg/consts"
+	"github.com/koho/frpmgr/pkg/ipc"
+)
+
+// proxyCounter holds the statistics of a single proxy.
+type proxyCounter struct {
+	bytesIn    atomic.Int64
+	bytesOut   atomic.Int64
+	curConns   atomic.Int64
+	totalConns atomic.Int64
+
+	// The following fields are protected by the mutex of proxyStats.
+	typ       string
+	phase     string
+	lastStart time.Time
+	lastError time.Time
+	retries   int
+	errors    int
+}
+
+// proxyStats collects traffic counters and phase history of proxies.
+type proxyStats struct {
+	mu       sync.Mutex
+	counters map[string]*proxyCounter
+}
+
+func newProxyStats() *proxyStats {
+	return &proxyStats{counters: make(map[string]*proxyCounter)}
+}
+
+func (ps *proxyStats) counter(name string) *proxyCounter {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	c, ok := ps.counters[name]
+	if !ok {
+		c = new(proxyCounter)
+		ps.counters[name] = c
+	}
+	return c
+}
+
+// observe records the phase change of a proxy, and reports whether the phase has changed.
+func (ps *proxyStats) observe(status *proxy.WorkingStatus) bool {
+	c := ps.counter(status.Name)
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	c.typ = status.Type
+	if c.phase == status.Phase {
+		return false
+	}
+	switch status.Phase {
+	case proxy.ProxyPhaseRunning:
+		c.lastStart = time.Now()
+	case proxy.ProxyPhaseStartErr, proxy.ProxyPhaseCheckFailed:
+		c.lastError = time.Now()
+		c.errors++
+	case proxy.ProxyPhaseWaitStart:
+		// The proxy is registered again after it has been started or failed.
+		if c.phase != "" && c.phase != proxy.ProxyPhaseNew {
+			c.retries++
+		}
+	}
+	c.phase = status.Phase
+	return true
+}
+
+// get returns the statistics of a proxy.
+func (ps *proxyStats) get(name string) (stats ipc.ProxyStats, ok bool) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	c, ok := ps.counters[name]
+	if !ok {
+		return
+	}
+	return ipc.ProxyStats{
+		BytesIn:    c.bytesIn.Load(),
+		BytesOut:   c.bytesOut.Load(),
+		CurConns:   c.curConns.Load(),
+		TotalConns: c.totalConns.Load(),
+		LastStart:  c.lastStart,
+		LastError:  c.lastError,
+		Retries:    c.retries,
+		Errors:     c.errors,
+		NoTraffic:  !trafficSupported(c.typ),
+	}, true
+}
+
+// trafficSupported reports whether the traffic of a proxy type is counted. UDP, SUDP and
+// XTCP proxies handle work connections by themselves without calling HandleWorkConnCb,
+// so their connections are never attached to a counter.
+func trafficSupported(typ string) bool {
+	switch typ {
+	case consts.ProxyTypeUDP, consts.ProxyTypeSUDP, consts.ProxyTypeXTCP:
+		return false
+	}
+	return true
+}
+
+// handleWorkConn attaches the counter of the proxy to a new work connection.
+// The connection is always passed on to the frp client.
+func (ps *proxyStats) handleWorkConn(cfg *v1.ProxyBaseConfig, conn net.Conn, _ *msg.StartWorkConn) bool {
+	if mc, ok := conn.(*msg.Conn); ok {
+		conn = mc.Conn
+	}
+	if tc, ok := conn.(*trafficConn); ok {
+		tc.attach(ps.counter(cfg.Name))
+	}
+	return true
+}
+
+// wrapConnector returns a connector creator whose connections report traffic to the counters.
+func (ps *proxyStats) wrapConnector(ctx context.Context, cfg *v1.ClientCommonConfig) client.Connector {
+	return &trafficConnector{client.NewConnector(ctx, cfg)}
+}
+
+type trafficConnector struct {
+	client.Connector
+}
+
+func (c *trafficConnector) Connect() (net.Conn, error) {
+	conn, err := c.Connector.Connect()
+	if err != nil {
+		return nil, err
+	}
+	return &trafficConn{Conn: conn}, nil
+}
+
+// trafficConn counts the bytes transferred after it's attached to a proxy.
+// Control messages exchanged before that are not counted.
+type trafficConn struct {
+	net.Conn
+	counter   atomic.Pointer[proxyCounter]
+	closeOnce sync.Once
+}
+
+func (c *trafficConn) attach(counter *proxyCounter) {
+	if c.counter.CompareAndSwap(nil, counter) {
+		counter.curConns.Add(1)
+		counter.totalConns.Add(1)
+	}
+}
+
+func (c *trafficConn) Read(b []byte) (n int, err error) {
+	n, err = c.Conn.Read(b)
+	if counter := c.counter.Load(); counter != nil {
+		counter.bytesIn.Add(int64(n))
+	}
+	return
+}
+
+func (c *trafficConn) Write(b []byte) (n int, err error) {
+	n, err = c.Conn.Write(b)
+	if counter := c.counter.Load(); counter != nil {
+		counter.bytesOut.Add(int64(n))
+	}
+	return
+}
+
+func (c *trafficConn) Close() error {
+	c.closeOnce.Do(func() {
+		if counter := c.counter.Load(); counter != nil {
+			counter.curConns.Add(-1)
+		}
+	})
+	return c.Conn.Close()
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	"github.com/fatedier/frp/client/proxy"
+	"github.com/fatedier/frp/pkg/config/v1"
+	"github.com/fatedier/frp/pkg/msg"
+)
+
+type pipeConnector struct {
+	conn net.Conn
+}
+
+func (c *pipeConnector) Open() error {
+	return nil
+}
+
+func (c *pipeConnector) Connect() (net.Conn, error) {
+	return c.conn, nil
+}
+
+func (c *pipeConnector) Close() error {
+	return nil
+}
+
+func TestTrafficConnector(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+	go io.Copy(remote, remote)
+
+	ps := newProxyStats()
+	connector := &trafficConnector{&pipeConnector{local}}
+	conn, err := connector.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	transfer := func(b []byte) {
+		if _, err := conn.Write(b); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.ReadFull(conn, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Control messages before the work connection starts are not counted
+	transfer([]byte("login"))
+	cfg := &v1.ProxyBaseConfig{Name: "ssh"}
+	for i := 0; i < 2; i++ {
+		if !ps.handleWorkConn(cfg, msg.NewConn(conn, nil), &msg.StartWorkConn{}) {
+			t.Fatal("Expected the work connection to be passed on")
+		}
+	}
+	transfer([]byte("hello"))
+	stats, _ := ps.get("ssh")
+	if stats.BytesIn != 5 || stats.BytesOut != 5 || stats.CurConns != 1 || stats.TotalConns != 1 {
+		t.Errorf("Expected: %v, got: %v", "5 bytes in one connection", stats)
+	}
+	conn.Close()
+	conn.Close()
+	if stats, _ = ps.get("ssh"); stats.CurConns != 0 || stats.TotalConns != 1 {
+		t.Errorf("Expected: %v, got: %v", "no open connection", stats)
+	}
+}
+
+func TestObserve(t *testing.T) {
+	ps := newProxyStats()
+	tests := []struct {
+		phase   string
+		changed bool
+		retries int
+		errors  int
+	}{
+		{proxy.ProxyPhaseNew, true, 0, 0},
+		{proxy.ProxyPhaseWaitStart, true, 0, 0},
+		{proxy.ProxyPhaseRunning, true, 0, 0},
+		{proxy.ProxyPhaseRunning, false, 0, 0},
+		{proxy.ProxyPhaseCheckFailed, true, 0, 1},
+		{proxy.ProxyPhaseWaitStart, true, 1, 1},
+		{proxy.ProxyPhaseStartErr, true, 1, 2},
+		{proxy.ProxyPhaseWaitStart, true, 2, 2},
+	}
+	for i, test := range tests {
+		if changed := ps.observe(&proxy.WorkingStatus{Name: "ssh", Type: "tcp", Phase: test.phase}); changed != test.changed {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, test.changed, changed)
+		}
+		stats, ok := ps.get("ssh")
+		if !ok || stats.Retries != test.retries || stats.Errors != test.errors {
+			t.Errorf("Test %d: Expected: %v, got: %v", i, []int{test.retries, test.errors}, stats)
+		}
+	}
+	if stats, _ := ps.get("ssh"); stats.LastStart.IsZero() || stats.LastError.IsZero() || stats.NoTraffic {
+		t.Errorf("Expected: %v, got: %v", "start and error time", stats)
+	}
+	// Work connections of these proxies are not passed to the callback
+	for _, typ := range []string{"udp", "sudp", "xtcp"} {
+		ps.observe(&proxy.WorkingStatus{Name: typ, Type: typ, Phase: proxy.ProxyPhaseRunning})
+		if stats, _ := ps.get(typ); !stats.NoTraffic {
+			t.Errorf("Expected: %v, got: %v", true, stats.NoTraffic)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/fatedier/frp/pkg/util/log"
+
+	"github.com/koho/frpmgr/pkg/profile"
+)
+
+// subscriptionCheckInterval is the interval to check for subscriptions that are due.
+const subscriptionCheckInterval = time.Minute
+
+// RefreshSubscriptions refreshes the subscribed profiles on their schedules until the context
+// is canceled. The running service of a profile is hot-reloaded only when its config is changed
+// by a refresh, then the updated function is called if it's not nil.
+func RefreshSubscriptions(ctx context.Context, repo *profile.Repository, updated func(p *profile.Profile)) {
+	ticker := time.NewTicker(subscriptionCheckInterval)
+	defer ticker.Stop()
+	for {
+		repo.RefreshDue(ctx, time.Now(), func(p *profile.Profile) {
+			if _, pid, err := QueryStartInfo(p.Path); err == nil && pid > 0 {
+				if err = VerifyClientConfig(p.Path); err == nil {
+					err = ReloadService(p.Path)
+				}
+				if err != nil {
+					log.Warnf("reload refreshed config %s error: %v", p.ID(), err)
+				}
+			}
+			if updated != nil {
+				updated(p)
+			}
+		})
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
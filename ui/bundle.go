@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+
+	"github.com/koho/frpmgr/i18n"
+	"github.com/koho/frpmgr/pkg/profile"
+	"github.com/koho/frpmgr/pkg/res"
+)
+
+// BundleExportDialog selects the content of an exported ZIP file.
+type BundleExportDialog struct {
+	*walk.Dialog
+
+	// Options of the export
+	Options profile.ExportOptions
+}
+
+func NewBundleExportDialog() *BundleExportDialog {
+	return new(BundleExportDialog)
+}
+
+func (bd *BundleExportDialog) Run(owner walk.Form) (int, error) {
+	return NewBasicDialog(&bd.Dialog, i18n.Sprintf("Export All Configs to ZIP"), loadIcon(res.IconExport, 32),
+		DataBinder{DataSource: &bd.Options}, nil,
+		CheckBox{Text: i18n.Sprintf("Include the default values of new configs"), Checked: Bind("App")},
+		CheckBox{Text: i18n.Sprintf("Include the store files of frp"), Checked: Bind("Stores")},
+		CheckBox{
+			Visible: profiles.Vault() != nil,
+			Text:    i18n.Sprintf("Decrypt secrets, so the configs can be used on other computers"),
+			Checked: Bind("Plain"),
+		},
+		VSpacer{Size: 4},
+	).Run(owner)
+}
+
+// BundleImportDialog selects how to import a ZIP file with a manifest.
+type BundleImportDialog struct {
+	*walk.Dialog
+
+	viewModel bundleImportViewModel
+	bundle    *profile.Bundle
+	// conflicts is the number of configs with the same names as existing configs
+	conflicts int
+}
+
+type bundleImportViewModel struct {
+	Strategy string
+	App      bool
+	Stores   bool
+}
+
+func NewBundleImportDialog(bundle *profile.Bundle, conflicts int) *BundleImportDialog {
+	return &BundleImportDialog{
+		viewModel: bundleImportViewModel{Strategy: string(profile.ImportDuplicate)},
+		bundle:    bundle,
+		conflicts: conflicts,
+	}
+}
+
+// Options returns the selected options of the import.
+func (bd *BundleImportDialog) Options() profile.ImportOptions {
+	return profile.ImportOptions{
+		Strategy: profile.ImportStrategy(bd.viewModel.Strategy),
+		App:      bd.viewModel.App,
+		Stores:   bd.viewModel.Stores,
+	}
+}
+
+func (bd *BundleImportDialog) Run(owner walk.Form) (int, error) {
+	hasStores := false
+	for _, e := range bd.bundle.Manifest.Profiles {
+		hasStores = hasStores || e.Store != ""
+	}
+	return NewBasicDialog(&bd.Dialog, i18n.Sprintf("Import Config"), loadIcon(res.IconFileImport, 32),
+		DataBinder{DataSource: &bd.viewModel}, nil,
+		Label{
+			Visible: bd.conflicts > 0,
+			Text:    i18n.Sprintf("%d configs have the same names as existing configs.", bd.conflicts),
+		},
+		NewRadioButtonGroup("Strategy", nil, bd.conflicts > 0, []RadioButton{
+			{Text: i18n.Sprintf("Import as copies"), Value: string(profile.ImportDuplicate)},
+			{Text: i18n.Sprintf("Skip"), Value: string(profile.ImportSkip)},
+			{Text: i18n.Sprintf("Overwrite"), Value: string(profile.ImportOverwrite)},
+		}),
+		CheckBox{
+			Visible: bd.bundle.Manifest.App != "",
+			Text:    i18n.Sprintf("Use the default values of new configs in the file"),
+			Checked: Bind("App"),
+		},
+		CheckBox{
+			Visible: hasStores,
+			Text:    i18n.Sprintf("Restore the store files of frp"),
+			Checked: Bind("Stores"),
+		},
+		VSpacer{Size: 4},
+	).Run(owner)
+}
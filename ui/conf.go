@@ -1,7 +1,6 @@
 package ui
 
 import (
-	"errors"
 	"os"
 	"path/filepath"
 	"slices"
@@ -12,7 +11,8 @@ import (
 	"github.com/koho/frpmgr/i18n"
 	"github.com/koho/frpmgr/pkg/config"
 	"github.com/koho/frpmgr/pkg/consts"
-	"github.com/koho/frpmgr/pkg/util"
+	"github.com/koho/frpmgr/pkg/profile"
+	"github.com/koho/frpmgr/pkg/sec"
 	"github.com/koho/frpmgr/services"
 )
 
@@ -27,37 +27,27 @@ const (
 
 // Conf contains all data of a config
 type Conf struct {
-	// Path of the config file
-	Path string
+	*profile.Profile
 	// State of service
 	State consts.ConfigState
-	Data  *config.ClientConfig
 }
 
 // PathOfConf returns the file path of a config with given base file name
 func PathOfConf(base string) string {
-	return filepath.Join("profiles", base)
+	return filepath.Join(profiles.Dir(), base)
 }
 
 func NewConf(path string, data *config.ClientConfig) *Conf {
-	if path == "" {
-		filename, err := util.RandToken(16)
-		if err != nil {
-			panic(err)
-		}
-		path = PathOfConf(filename + ".conf")
+	p, err := profiles.NewProfile(path, data)
+	if err != nil {
+		panic(err)
 	}
 	return &Conf{
-		Path:  path,
-		State: consts.ConfigStateStopped,
-		Data:  data,
+		Profile: p,
+		State:   consts.ConfigStateStopped,
 	}
 }
 
-func (conf *Conf) Name() string {
-	return conf.Data.Name()
-}
-
 // Delete config will remove service, logs, config file in disk
 func (conf *Conf) Delete() error {
 	// Delete service
@@ -65,31 +55,12 @@ func (conf *Conf) Delete() error {
 	if err := services.UninstallService(conf.Path, true); err != nil && running {
 		return err
 	}
-	// Delete logs
-	if logs, _, err := util.FindLogFiles(conf.Data.LogFile); err == nil {
-		util.DeleteFiles(logs)
-	}
-	// Delete config file
-	if err := os.Remove(conf.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
-		return err
-	}
-	return nil
+	return profiles.Delete(conf.Profile)
 }
 
 // Save config to the disk. The config will be completed before saving
 func (conf *Conf) Save() error {
-	logPath, err := filepath.Abs(filepath.Join("logs", util.FileNameWithoutExt(conf.Path)+".log"))
-	if err != nil {
-		return err
-	}
-	conf.Data.Complete(false)
-	conf.Data.LogFile = filepath.ToSlash(logPath)
-	storePath, err := filepath.Abs(filepath.Join("stores", util.FileNameWithoutExt(conf.Path)+".json"))
-	if err != nil {
-		return err
-	}
-	conf.Data.Store.Path = filepath.ToSlash(storePath)
-	return conf.Data.Save(conf.Path)
+	return profiles.Update(conf.Profile)
 }
 
 var (
@@ -103,11 +74,26 @@ var (
 		},
 	}
 	confDB *walk.DataBinder
+	// profiles is the repository of the data root, which is opened when the UI starts.
+	profiles *profile.Repository
 )
 
-func loadAllConfs() ([]*Conf, error) {
-	// Load and migrate application configuration.
-	if lang, _ := config.UnmarshalAppConf(config.DefaultAppFile, &appConf); lang != nil {
+// openDataRoot opens the repository of the given data root and makes sure its directories exist.
+// Relative paths in configs are resolved against the data root, as in services.
+func openDataRoot(root string) error {
+	profiles = profile.NewRepository(root, &appConf)
+	if err := os.MkdirAll(profiles.Dir(), os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(root, profile.StoreDir), os.ModePerm); err != nil {
+		return err
+	}
+	return os.Chdir(root)
+}
+
+// loadAppConf loads and migrates the application configuration.
+func loadAppConf() {
+	if lang, _ := config.UnmarshalAppConf(profiles.AppFile(), &appConf); lang != nil {
 		if _, ok := i18n.IDToName[*lang]; ok {
 			appConf.Lang = *lang
 			if saveAppConfig() == nil {
@@ -117,32 +103,30 @@ func loadAllConfs() ([]*Conf, error) {
 			os.Remove(config.LangFile)
 		}
 	}
-	// Find all config files in `profiles` directory.
-	files, err := filepath.Glob(PathOfConf("*.conf"))
+}
+
+// loadAllConfs opens the vault with the master password and loads all configs.
+func loadAllConfs(password string) ([]*Conf, error) {
+	vault, err := appConf.OpenVault(password)
 	if err != nil {
 		return nil, err
 	}
-	cfgList := make([]*Conf, 0)
-	for _, f := range files {
-		if conf, err := config.UnmarshalClientConf(f); err == nil {
-			c := NewConf(f, conf)
-			if c.Name() == "" {
-				conf.ClientCommon.Name = util.FileNameWithoutExt(f)
-			}
-			cfgList = append(cfgList, c)
+	// Credentials written to the application configuration by hand are sealed
+	if sealed, err := appConf.SealSecrets(vault); err != nil {
+		return nil, err
+	} else if sealed {
+		if err = saveAppConfig(); err != nil {
+			return nil, err
 		}
 	}
-	slices.SortStableFunc(cfgList, func(a, b *Conf) int {
-		i := slices.Index(appConf.Sort, util.FileNameWithoutExt(a.Path))
-		j := slices.Index(appConf.Sort, util.FileNameWithoutExt(b.Path))
-		if i < 0 && j >= 0 {
-			return 1
-		} else if j < 0 && i >= 0 {
-			return -1
-		}
-		return i - j
-	})
-	return cfgList, nil
+	profiles.SetVault(vault)
+	list, err := profiles.List()
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(list, func(p *profile.Profile, i int) *Conf {
+		return &Conf{Profile: p, State: consts.ConfigStateStopped}
+	}), nil
 }
 
 // ConfBinder is the view model of configs
@@ -212,12 +196,78 @@ func newDefaultClientConfig() *config.ClientConfig {
 }
 
 func saveAppConfig() error {
-	return appConf.Save(config.DefaultAppFile)
+	return appConf.Save(profiles.AppFile())
+}
+
+// enableVault creates a vault and encrypts the secrets of all configs.
+// The vault key is saved before the configs, so running services can always decrypt them.
+func enableVault(password string) error {
+	vault, err := sec.NewVault()
+	if err != nil {
+		return err
+	}
+	key, err := vault.Wrap(password)
+	if err != nil {
+		return err
+	}
+	machineKey, err := vault.Protect()
+	if err != nil {
+		return err
+	}
+	download := appConf.Download
+	download.Sites = slices.Clone(download.Sites)
+	if err = download.MapSecrets(vault.Seal); err != nil {
+		return err
+	}
+	oldDownload := appConf.Download
+	appConf.Vault = &config.VaultConfig{Key: key, MachineKey: machineKey}
+	appConf.Download = download
+	if err = saveAppConfig(); err != nil {
+		appConf.Vault, appConf.Download = nil, oldDownload
+		return err
+	}
+	profiles.SetVault(vault)
+	return saveAllConfs()
+}
+
+// disableVault decrypts the secrets of all configs and removes the vault.
+func disableVault() error {
+	vault := profiles.Vault()
+	download := appConf.Download
+	download.Sites = slices.Clone(download.Sites)
+	if err := download.MapSecrets(vault.Open); err != nil {
+		return err
+	}
+	profiles.SetVault(nil)
+	if err := saveAllConfs(); err != nil {
+		profiles.SetVault(vault)
+		return err
+	}
+	oldVault, oldDownload := appConf.Vault, appConf.Download
+	appConf.Vault, appConf.Download = nil, download
+	if err := saveAppConfig(); err != nil {
+		appConf.Vault, appConf.Download = oldVault, oldDownload
+		return err
+	}
+	return nil
+}
+
+// saveAllConfs writes all configs to disk again.
+func saveAllConfs() error {
+	list, err := profiles.List()
+	if err != nil {
+		return err
+	}
+	for _, p := range list {
+		if err = profiles.Update(p); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func setConfOrder(cfgList []*Conf) {
-	appConf.Sort = lo.Map(cfgList, func(item *Conf, index int) string {
-		return util.FileNameWithoutExt(item.Path)
-	})
-	saveAppConfig()
+	profiles.Reorder(lo.Map(cfgList, func(item *Conf, index int) string {
+		return item.ID()
+	}))
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/koho/frpmgr/i18n"
+	"github.com/koho/frpmgr/pkg/config"
 	"github.com/koho/frpmgr/pkg/consts"
 	"github.com/koho/frpmgr/services"
 )
@@ -46,6 +47,13 @@ func (cp *ConfPage) Page() TabPage {
 							showError(err, cp.Form())
 							return
 						}
+						if conflicts, err := profiles.Conflicts(conf.Profile); err == nil && len(conflicts) > 0 {
+							showWarningMessage(cp.Form(), i18n.Sprintf("Conflict"),
+								i18n.Sprintf("Some ports or domains are also used by other configs:\n\n%s",
+									strings.Join(lo.Map(conflicts, func(c config.Conflict, i int) string {
+										return c.String()
+									}), "\n")))
+						}
 						if flag == runFlagForceStart {
 							// The service of config is stopped by other code, but it should be restarted
 						} else if conf.State == consts.ConfigStateStarted {
@@ -151,6 +159,7 @@ func (cp *ConfPage) OnCreate() {
 		cp.detailView.panelView.Invalidate(false)
 	})
 	cp.addVisibleChangedListener()
+	cp.confView.runSchedules(false)
 	cleanup, err := services.WatchConfigServices(func() []string {
 		return lo.Map(getConfList(), func(item *Conf, index int) string {
 			return item.Path
@@ -205,6 +214,7 @@ func (cp *ConfPage) addVisibleChangedListener() {
 }
 
 func (cp *ConfPage) Close() error {
+	cp.confView.stopSchedules()
 	if cp.svcCleanup != nil {
 		return cp.svcCleanup()
 	}
@@ -3,12 +3,15 @@ package ui
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/base64"
-	"io"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/lxn/walk"
 	. "github.com/lxn/walk/declarative"
@@ -18,8 +21,12 @@ import (
 	"github.com/koho/frpmgr/pkg/config"
 	"github.com/koho/frpmgr/pkg/consts"
 	"github.com/koho/frpmgr/pkg/layout"
+	"github.com/koho/frpmgr/pkg/profile"
 	"github.com/koho/frpmgr/pkg/res"
+	"github.com/koho/frpmgr/pkg/sharelink"
 	"github.com/koho/frpmgr/pkg/util"
+	"github.com/koho/frpmgr/pkg/validators"
+	"github.com/koho/frpmgr/services"
 )
 
 type ConfView struct {
@@ -35,6 +42,9 @@ type ConfView struct {
 	tbAddAction    *walk.Action
 	tbDeleteAction *walk.Action
 	tbExportAction *walk.Action
+
+	// cancelSchedules stops the schedules running in the GUI
+	cancelSchedules context.CancelFunc
 }
 
 var cachedListViewIconsForWidthAndState = make(map[widthAndConfigState]*walk.Bitmap)
@@ -128,6 +138,15 @@ func (cv *ConfView) View() Widget {
 							Action{Text: i18n.Sprintf("Import from Clipboard"), OnTriggered: cv.onClipboardImport},
 						},
 					},
+					Menu{
+						Text:    i18n.Sprintf("Subscription"),
+						Enabled: Bind("confView.SelectedCount == 1"),
+						Items: []MenuItem{
+							Action{Text: i18n.Sprintf("Refresh Now"), OnTriggered: cv.onRefresh},
+							Action{Text: i18n.Sprintf("Refresh History"), OnTriggered: cv.onRefreshHistory},
+							Action{Text: i18n.Sprintf("Unsubscribe"), OnTriggered: cv.onUnsubscribe},
+						},
+					},
 					Separator{},
 					Action{
 						Text:        i18n.Sprintf("NAT Discovery"),
@@ -137,7 +156,12 @@ func (cv *ConfView) View() Widget {
 					Action{
 						Text:        i18n.Sprintf("Copy Share Link"),
 						Enabled:     Bind("confView.SelectedCount == 1"),
-						OnTriggered: cv.onCopyShareLink,
+						OnTriggered: func() { cv.onCopyShareLink(false) },
+					},
+					Action{
+						Text:        i18n.SprintfEllipsis("Copy Encrypted Share Link"),
+						Enabled:     Bind("confView.SelectedCount == 1"),
+						OnTriggered: func() { cv.onCopyShareLink(true) },
 					},
 					Action{
 						Text:        i18n.Sprintf("Export All Configs to ZIP"),
@@ -307,12 +331,26 @@ func (cv *ConfView) fixWidthToToolbarWidth() {
 }
 
 func (cv *ConfView) onEditConf(conf *Conf, create bool) {
+	oldName := conf.Name()
 	dlg := NewEditClientDialog(conf.Data, create)
 	if result, _ := dlg.Run(cv.Form()); result == walk.DlgCmdOK {
 		if create {
+			// The file name is derived from the name given in the dialog
+			p, err := profiles.NewProfile("", conf.Data)
+			if err != nil {
+				showError(err, cv.Form())
+				return
+			}
+			conf.Profile = p
 			cv.model.Add(conf)
 			cv.listView.SetCurrentIndex(cv.model.RowCount() - 1)
 		} else {
+			// Move the files and service of the config to the file name of the new name
+			if conf.Name() != oldName {
+				if err := services.RenameProfile(profiles, conf.Profile, conf.Name()); err != nil {
+					showError(err, cv.Form())
+				}
+			}
 			if i := cv.listView.CurrentIndex(); i >= 0 {
 				cv.model.PublishRowChanged(i)
 				cv.model.PublishRowEdited(i)
@@ -329,13 +367,15 @@ func (cv *ConfView) onURLImport() {
 		return
 	}
 	var cfgList []*Conf
-	cv.importConfig(func() (total, imported int) {
+	var subscribed bool
+	cv.importConfig(func() (total, imported int, report []string) {
 		for _, item := range dlg.Items {
 			if item.Zip {
-				subList, subTotal, subImported := cv.importZip(item.Filename, item.Data)
+				subList, subTotal, subImported, subReport := cv.importZip(item.Filename, item.Data)
 				total += subTotal
 				imported += subImported
 				cfgList = append(cfgList, subList...)
+				report = append(report, subReport...)
 			} else {
 				total++
 				conf, err := config.UnmarshalClientConf(item.Data)
@@ -351,6 +391,13 @@ func (cv *ConfView) onURLImport() {
 					showError(err, cv.Form())
 					continue
 				}
+				if dlg.viewModel.Subscribe {
+					if err = profiles.Subscribe(cfg.ID(), profile.Subscription{URL: item.URL}); err != nil {
+						showError(err, cv.Form())
+					} else {
+						subscribed = true
+					}
+				}
 				cfgList = append(cfgList, cfg)
 				imported++
 			}
@@ -358,6 +405,9 @@ func (cv *ConfView) onURLImport() {
 		return
 	})
 	cv.model.Add(cfgList...)
+	if subscribed {
+		cv.runSchedules(false)
+	}
 }
 
 func (cv *ConfView) onFileImport() {
@@ -372,27 +422,32 @@ func (cv *ConfView) onFileImport() {
 	cv.ImportFiles(dlg.FilePaths)
 }
 
-func (cv *ConfView) importConfig(f func() (int, int)) {
-	if total, imported := f(); imported > 0 {
-		showInfoMessage(cv.Form(),
-			i18n.Sprintf("Import Config"),
-			i18n.Sprintf("Imported %d of %d configs.", imported, total))
+// importConfig runs the import function and shows the number of imported configs,
+// followed by the results of files that are not imported as new configs.
+func (cv *ConfView) importConfig(f func() (int, int, []string)) {
+	if total, imported, report := f(); imported > 0 || len(report) > 0 {
+		message := i18n.Sprintf("Imported %d of %d configs.", imported, total)
+		if len(report) > 0 {
+			message += "\n\n" + strings.Join(report, "\n")
+		}
+		showInfoMessage(cv.Form(), i18n.Sprintf("Import Config"), message)
 	}
 }
 
 func (cv *ConfView) ImportFiles(files []string) {
 	var cfgList []*Conf
-	cv.importConfig(func() (total, imported int) {
+	cv.importConfig(func() (total, imported int, report []string) {
 		for _, path := range files {
 			if dir, err := util.IsDirectory(path); err != nil || dir {
 				continue
 			}
 			ext := strings.ToLower(filepath.Ext(path))
 			if ext == ".zip" {
-				subList, subTotal, subImported := cv.importZip(path, nil)
+				subList, subTotal, subImported, subReport := cv.importZip(path, nil)
 				total += subTotal
 				imported += subImported
 				cfgList = append(cfgList, subList...)
+				report = append(report, subReport...)
 			} else if slices.Contains(res.SupportedConfigFormats, ext) {
 				total++
 				conf, err := config.UnmarshalClientConf(path)
@@ -417,30 +472,10 @@ func (cv *ConfView) ImportFiles(files []string) {
 	cv.model.Add(cfgList...)
 }
 
-func (cv *ConfView) importZip(path string, data []byte) (cfgList []*Conf, total, imported int) {
-	importFile := func(file *zip.File) (*Conf, error) {
-		fr, err := file.Open()
-		if err != nil {
-			return nil, err
-		}
-		defer fr.Close()
-		src, err := io.ReadAll(fr)
-		if err != nil {
-			return nil, err
-		}
-		conf, err := config.UnmarshalClientConf(src)
-		if err != nil {
-			return nil, err
-		}
-		if conf.Name() == "" {
-			conf.ClientCommon.Name = util.FileNameWithoutExt(file.Name)
-		}
-		cfg := NewConf("", conf)
-		if err = cfg.Save(); err != nil {
-			return nil, err
-		}
-		return cfg, nil
-	}
+// importZip imports the configs in a ZIP file. The user chooses how to handle configs with
+// the same names as existing configs, and whether to restore the defaults and store files.
+// Files that are not imported as new configs are described in the report.
+func (cv *ConfView) importZip(path string, data []byte) (cfgList []*Conf, total, imported int, report []string) {
 	var zr *zip.Reader
 	var err error
 	if data == nil {
@@ -458,22 +493,65 @@ func (cv *ConfView) importZip(path string, data []byte) (cfgList []*Conf, total,
 		showErrorMessage(cv.Form(), "", i18n.Sprintf("The file \"%s\" is not a valid ZIP file.", path))
 		return
 	}
-	for _, file := range zr.File {
-		if file.FileInfo().IsDir() {
-			continue
-		}
-		if !slices.Contains(res.SupportedConfigFormats, strings.ToLower(filepath.Ext(file.Name))) {
-			continue
+	bundle, err := profile.OpenBundle(zr)
+	if err != nil {
+		showError(err, cv.Form())
+		return
+	}
+	conflicts := lo.CountBy(bundle.Manifest.Profiles, func(e profile.BundleEntry) bool {
+		return slices.ContainsFunc(cv.model.items, func(conf *Conf) bool { return conf.Name() == e.Name })
+	})
+	hasStores := slices.ContainsFunc(bundle.Manifest.Profiles, func(e profile.BundleEntry) bool { return e.Store != "" })
+	var opts profile.ImportOptions
+	if conflicts > 0 || bundle.Manifest.App != "" || hasStores {
+		dlg := NewBundleImportDialog(bundle, conflicts)
+		if result, _ := dlg.Run(cv.Form()); result != walk.DlgCmdOK {
+			return
 		}
+		opts = dlg.Options()
+	}
+	results, err := profiles.Import(bundle, opts)
+	if err != nil {
+		showError(err, cv.Form())
+	}
+	for _, result := range results {
 		total++
-		if cfg, err := importFile(file); err == nil {
+		switch result.Status {
+		case profile.ImportCreated:
+			imported++
+			cfgList = append(cfgList, NewConf(result.Profile.Path, result.Profile.Data))
+		case profile.ImportOverwritten:
 			imported++
-			cfgList = append(cfgList, cfg)
+			cv.reloadImported(result.Profile)
+			report = append(report, i18n.Sprintf("%s: overwritten config \"%s\"", result.File, result.Name))
+		case profile.ImportSkipped:
+			report = append(report, i18n.Sprintf("%s: skipped, config \"%s\" exists", result.File, result.Name))
+		default:
+			report = append(report, fmt.Sprintf("%s: %v", result.File, result.Err))
 		}
 	}
 	return
 }
 
+// reloadImported reloads an existing config overwritten by an import,
+// and hot-reloads its service if it's running.
+func (cv *ConfView) reloadImported(p *profile.Profile) {
+	i := slices.IndexFunc(cv.model.items, func(conf *Conf) bool { return conf.ID() == p.ID() })
+	if i < 0 {
+		return
+	}
+	cv.reloadConf(p.ID())
+	if conf := cv.model.items[i]; conf.State == consts.ConfigStateStarted {
+		err := services.VerifyClientConfig(conf.Path)
+		if err == nil {
+			err = services.ReloadService(conf.Path)
+		}
+		if err != nil {
+			showError(err, cv.Form())
+		}
+	}
+}
+
 func (cv *ConfView) onClipboardImport() {
 	text, err := walk.Clipboard().Text()
 	if err != nil {
@@ -483,32 +561,113 @@ func (cv *ConfView) onClipboardImport() {
 		return
 	}
 	// Check for a share link
-	if strings.HasPrefix(text, res.ShareLinkScheme) {
-		text = strings.TrimPrefix(text, res.ShareLinkScheme)
-		content, err := base64.StdEncoding.DecodeString(text)
+	var link *sharelink.Link
+	if sharelink.IsLink(text) {
+		var passphrase string
+		for {
+			link, err = sharelink.Decode(text, passphrase)
+			if !errors.Is(err, sharelink.ErrPassphraseRequired) && !errors.Is(err, sharelink.ErrWrongPassphrase) {
+				break
+			}
+			if passphrase != "" {
+				showErrorMessage(cv.Form(), "", i18n.Sprintf("The passphrase is incorrect. Re-enter passphrase."))
+			}
+			if passphrase = askPassphrase(cv.Form(), false); passphrase == "" {
+				return
+			}
+		}
 		if err != nil {
 			showError(err, cv.Form())
 			return
 		}
-		text = string(content)
+		// The signature only proves the link is intact, so the key must be recognized by the user
+		if link.Signer != nil && walk.MsgBox(cv.Form(), i18n.Sprintf("Signed Share Link"),
+			i18n.Sprintf("The share link is signed by the following public key:\n\n%s\n\n"+
+				"Make sure the key belongs to a trusted sender. Do you want to import the config?",
+				base64.StdEncoding.EncodeToString(link.Signer)),
+			walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+			return
+		}
+		text = string(link.Content)
 	}
 	conf, err := config.UnmarshalClientConf([]byte(text))
 	if err != nil {
 		showError(err, cv.Form())
 		return
 	}
+	if link != nil {
+		conf.LimitExpiry(link.Expires)
+	}
 	cv.onEditConf(NewConf("", conf), true)
 }
 
-func (cv *ConfView) onCopyShareLink() {
-	if conf := getCurrentConf(); conf != nil {
-		content, err := os.ReadFile(conf.Path)
-		if err != nil {
-			showError(err, cv.Form())
+// onCopyShareLink copies a share link of the current config with plain secrets.
+// If "encrypt" is true, the link is encrypted with a passphrase.
+func (cv *ConfView) onCopyShareLink(encrypt bool) {
+	conf := getCurrentConf()
+	if conf == nil {
+		return
+	}
+	var opts sharelink.Options
+	if encrypt {
+		if opts.Passphrase = askPassphrase(cv.Form(), true); opts.Passphrase == "" {
 			return
 		}
-		walk.Clipboard().SetText(res.ShareLinkScheme + base64.StdEncoding.EncodeToString(content))
 	}
+	if conf.Data.DeleteMethod == consts.DeleteAbsolute {
+		opts.Expires = conf.Data.DeleteAfterDate
+	}
+	tempDir, err := os.MkdirTemp("", "frpmgr")
+	if err != nil {
+		showError(err, cv.Form())
+		return
+	}
+	defer os.RemoveAll(tempDir)
+	path, err := profiles.ExportPlain(conf.Profile, tempDir)
+	if err != nil {
+		showError(err, cv.Form())
+		return
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		showError(err, cv.Form())
+		return
+	}
+	link, err := sharelink.Encode(content, opts)
+	if err != nil {
+		showError(err, cv.Form())
+		return
+	}
+	walk.Clipboard().SetText(link)
+}
+
+// askPassphrase asks for the passphrase of a share link. If "confirm" is true, the passphrase
+// must be entered twice. It returns an empty string if canceled.
+func askPassphrase(owner walk.Form, confirm bool) string {
+	var pwdEdit *walk.LineEdit
+	var vm struct {
+		Passphrase string
+	}
+	widgets := []Widget{
+		Label{Text: i18n.SprintfColon("Passphrase")},
+		LineEdit{AssignTo: &pwdEdit, Text: Bind("Passphrase", res.ValidateNonEmpty), PasswordMode: true},
+	}
+	if confirm {
+		widgets = append(widgets,
+			Label{Text: i18n.SprintfColon("Re-enter passphrase")},
+			LineEdit{Text: Bind("", validators.ConfirmPassword{Password: &pwdEdit}), PasswordMode: true},
+		)
+	}
+	NewBasicDialog(nil, i18n.Sprintf("Share Link"), loadIcon(res.IconKey, 32),
+		DataBinder{
+			DataSource:     &vm,
+			ErrorPresenter: validators.SilentToolTipErrorPresenter{},
+		}, nil, Composite{
+			Layout:   VBox{MarginsZero: true},
+			MinSize:  Size{Width: 280},
+			Children: widgets,
+		}, VSpacer{}).Run(owner)
+	return vm.Passphrase
 }
 
 func (cv *ConfView) onOpen(folder bool) {
@@ -581,6 +740,10 @@ func (cv *ConfView) onDelete() {
 }
 
 func (cv *ConfView) onExport() {
+	bd := NewBundleExportDialog()
+	if result, _ := bd.Run(cv.Form()); result != walk.DlgCmdOK {
+		return
+	}
 	dlg := walk.FileDialog{
 		Filter: res.FilterZip,
 		Title:  i18n.Sprintf("Export All Configs to ZIP"),
@@ -593,10 +756,18 @@ func (cv *ConfView) onExport() {
 	if !strings.HasSuffix(dlg.FilePath, ".zip") {
 		dlg.FilePath += ".zip"
 	}
-	files := lo.SliceToMap(cv.model.List(), func(conf *Conf) (string, string) {
-		return conf.Path, conf.Name() + conf.Data.Ext()
-	})
-	if err := util.ZipFiles(dlg.FilePath, files); err != nil {
+	f, err := os.Create(dlg.FilePath)
+	if err != nil {
+		showError(err, cv.Form())
+		return
+	}
+	err = profiles.Export(f, lo.Map(cv.model.List(), func(conf *Conf, i int) *profile.Profile {
+		return conf.Profile
+	}), bd.Options)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
 		showError(err, cv.Form())
 	}
 }
@@ -619,6 +790,169 @@ func (cv *ConfView) onNATDiscovery() {
 	}
 }
 
+// currentSubscription returns the current config and its subscription.
+// A message is shown if the config is not subscribed.
+func (cv *ConfView) currentSubscription() (*Conf, *profile.Subscription) {
+	conf := getCurrentConf()
+	if conf == nil {
+		return nil, nil
+	}
+	sub, err := profiles.Subscription(conf.ID())
+	if errors.Is(err, profile.ErrNotSubscribed) {
+		showInfoMessage(cv.Form(), i18n.Sprintf("Subscription"),
+			i18n.Sprintf("The config \"%s\" is not imported from a URL with updates.", conf.Name()))
+		return nil, nil
+	} else if err != nil {
+		showError(err, cv.Form())
+		return nil, nil
+	}
+	return conf, sub
+}
+
+func (cv *ConfView) onRefresh() {
+	conf, _ := cv.currentSubscription()
+	if conf == nil {
+		return
+	}
+	running := conf.State == consts.ConfigStateStarted
+	go func() {
+		ev, err := profiles.Refresh(context.Background(), conf.ID())
+		// The service is only reloaded if the config is changed
+		if err == nil && ev.Status == profile.RefreshUpdated && running {
+			if err = services.VerifyClientConfig(conf.Path); err == nil {
+				err = services.ReloadService(conf.Path)
+			}
+		}
+		cv.Synchronize(func() {
+			if ev.Status == profile.RefreshUpdated {
+				cv.reloadConf(conf.ID())
+			}
+			if err != nil {
+				showError(err, cv.Form())
+				return
+			}
+			showInfoMessage(cv.Form(), i18n.Sprintf("Subscription"), refreshText(ev))
+		})
+	}()
+}
+
+func (cv *ConfView) onRefreshHistory() {
+	conf, sub := cv.currentSubscription()
+	if conf == nil {
+		return
+	}
+	lines := []string{i18n.SprintfColon("URL") + " " + sub.URL, ""}
+	for _, ev := range slices.Backward(sub.History) {
+		lines = append(lines, ev.Time.Format(time.DateTime)+"  "+refreshText(ev))
+	}
+	if len(sub.History) == 0 {
+		lines = append(lines, i18n.Sprintf("Not refreshed yet."))
+	}
+	showInfoMessage(cv.Form(), i18n.Sprintf("Refresh History"), strings.Join(lines, "\n"))
+}
+
+func (cv *ConfView) onUnsubscribe() {
+	conf, _ := cv.currentSubscription()
+	if conf == nil {
+		return
+	}
+	if err := profiles.Unsubscribe(conf.ID()); err != nil {
+		showError(err, cv.Form())
+	}
+}
+
+// runSchedules lets the management service refresh subscribed configs and write scheduled backups.
+// The service is restarted to apply a changed backup schedule if update is true. The schedules
+// only run in the GUI while the service is not running, such as when it fails to start.
+func (cv *ConfView) runSchedules(update bool) {
+	if update {
+		services.UpdateManagerService(profiles)
+	} else if profiles.Scheduled() {
+		services.EnsureManagerService(profiles.Root())
+	}
+	if services.ManagerServiceRunning() {
+		cv.stopSchedules()
+		return
+	}
+	if cv.cancelSchedules != nil {
+		return
+	}
+	var ctx context.Context
+	ctx, cv.cancelSchedules = context.WithCancel(context.Background())
+	go services.RefreshSubscriptions(ctx, profiles, func(p *profile.Profile) {
+		cv.Synchronize(func() {
+			cv.reloadConf(p.ID())
+		})
+	})
+	go services.ScheduleBackups(ctx, profiles)
+}
+
+// stopSchedules stops the schedules running in the GUI.
+func (cv *ConfView) stopSchedules() {
+	if cv.cancelSchedules != nil {
+		cv.cancelSchedules()
+		cv.cancelSchedules = nil
+	}
+}
+
+// reloadConf loads the config with the given identifier from disk again, after it's changed by a refresh.
+func (cv *ConfView) reloadConf(id string) {
+	i := slices.IndexFunc(cv.model.items, func(conf *Conf) bool { return conf.ID() == id })
+	if i < 0 {
+		return
+	}
+	p, err := profiles.Get(id)
+	if err != nil {
+		return
+	}
+	conf := cv.model.items[i]
+	conf.Data = p.Data
+	cv.model.PublishRowChanged(i)
+	cv.model.PublishRowEdited(i)
+	if getCurrentConf() == conf {
+		setCurrentConf(conf)
+	}
+}
+
+// Reload loads all configs from disk again, such as after restoring a backup.
+// The states of existing configs are kept, and new configs are updated by the service watcher.
+func (cv *ConfView) Reload() error {
+	list, err := profiles.List()
+	if err != nil {
+		return err
+	}
+	states := lo.SliceToMap(cv.model.items, func(conf *Conf) (string, consts.ConfigState) {
+		return conf.Path, conf.State
+	})
+	cv.model.Reset(lo.Map(list, func(p *profile.Profile, i int) *Conf {
+		state, ok := states[p.Path]
+		return &Conf{Profile: p, State: lo.Ternary(ok, state, consts.ConfigStateStopped)}
+	}))
+	if cv.model.RowCount() > 0 {
+		cv.listView.SetCurrentIndex(0)
+	} else {
+		setCurrentConf(nil)
+	}
+	return nil
+}
+
+// refreshText describes the result of a refresh.
+func refreshText(ev profile.RefreshEvent) string {
+	var text string
+	switch ev.Status {
+	case profile.RefreshUpdated:
+		text = i18n.Sprintf("Updated")
+	case profile.RefreshUnchanged, profile.RefreshNotModified:
+		text = i18n.Sprintf("Up to date")
+	default:
+		text = i18n.Sprintf("Failed")
+	}
+	if ev.Message != "" {
+		text += ": " + ev.Message
+	}
+	return text
+}
+
 func (cv *ConfView) onMove(delta int) {
 	curIdx := cv.listView.CurrentIndex()
 	if curIdx < 0 || curIdx >= len(cv.model.items) {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/lxn/walk"
@@ -48,6 +49,9 @@ func NewEditClientDialog(conf *config.ClientConfig, create bool) *EditClientDial
 		Name:         v.data.Name(),
 		ClientCommon: v.data.ClientCommon,
 	}
+	if v.binder.Format == "" {
+		v.binder.Format = consts.FormatTOML
+	}
 	if v.binder.DeleteAfterDate.IsZero() {
 		v.binder.DeleteAfterDate = time.Now().AddDate(0, 0, 1)
 	}
@@ -378,6 +382,14 @@ func (cd *EditClientDialog) advancedConfPage() TabPage {
 			LineEdit{Text: Bind("DNSServer")},
 			Label{Text: i18n.SprintfColon("Source Address")},
 			LineEdit{Text: Bind("ConnectServerLocalIP")},
+			Label{Text: i18n.SprintfColon("File Format")},
+			ComboBox{
+				Enabled:       Bind("!legacyFormat.Checked"),
+				Value:         Bind("Format"),
+				Model:         NewListModel(consts.Formats, "TOML", "YAML", "JSON"),
+				BindingMember: "Value",
+				DisplayMember: "Title",
+			},
 			Composite{
 				Layout: VBox{MarginsZero: true, SpacingZero: true},
 				Children: []Widget{
@@ -416,6 +428,8 @@ func (cd *EditClientDialog) advancedConfPage() TabPage {
 						OnCheckedChanged: func() {
 							if !legacy.Checked() && !cd.canUpgradeFormat() {
 								legacy.SetChecked(true)
+							} else if legacy.Checked() && !cd.canDowngradeFormat() {
+								legacy.SetChecked(false)
 							}
 						},
 					},
@@ -541,8 +555,15 @@ func (cd *EditClientDialog) onSave() {
 		showErrorMessage(cd.Form(), "", i18n.Sprintf("Token file is required."))
 		return
 	}
-	cd.data.ClientCommon = newConf.ClientCommon
-	cd.data.ClientCommon.Name = newConf.Name
+	conf := *cd.data
+	conf.ClientCommon = newConf.ClientCommon
+	conf.ClientCommon.Name = newConf.Name
+	if !confirmDiagnostics(cd.Form(), lo.Filter(config.Lint(&conf), func(d config.Diagnostic, i int) bool {
+		return d.Proxy == ""
+	})) {
+		return
+	}
+	cd.data.ClientCommon = conf.ClientCommon
 	cd.Accept()
 }
 
@@ -570,3 +591,20 @@ func (cd *EditClientDialog) canUpgradeFormat() bool {
 	}
 	return true
 }
+
+// canDowngradeFormat asks the user whether to continue if some options are lost in the legacy format.
+func (cd *EditClientDialog) canDowngradeFormat() bool {
+	conf := *cd.data
+	conf.LegacyFormat = true
+	issues := conf.ConversionReport()
+	if len(issues) == 0 {
+		return true
+	}
+	fields := lo.Map(issues, func(item config.ConversionIssue, index int) string {
+		return item.String()
+	})
+	return walk.MsgBox(cd.Form(), i18n.Sprintf("Use legacy file format"),
+		i18n.Sprintf("The following options are not supported by the legacy file format and will be lost:\n\n%s\n\n"+
+			"Are you sure you would like to continue?", strings.Join(fields, "\n")),
+		walk.MsgBoxYesNo|walk.MsgBoxIconWarning) == walk.DlgCmdYes
+}
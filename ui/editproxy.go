@@ -2,6 +2,7 @@ package ui
 
 import (
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -21,7 +22,9 @@ import (
 type EditProxyDialog struct {
 	*walk.Dialog
 
-	Proxy        *config.Proxy
+	Proxy *config.Proxy
+	// conf is the config of the proxy, which is checked together with the proxy before saving
+	conf         *config.ClientConfig
 	visitors     []string
 	create       bool
 	legacyFormat bool
@@ -76,9 +79,10 @@ type editProxyBinder struct {
 	BandwidthUnit string
 }
 
-func NewEditProxyDialog(proxy *config.Proxy, visitors []string, create, legacyFormat bool, nameChecker func(string) bool) *EditProxyDialog {
+func NewEditProxyDialog(proxy *config.Proxy, conf *config.ClientConfig, visitors []string, create, legacyFormat bool, nameChecker func(string) bool) *EditProxyDialog {
 	v := &EditProxyDialog{
 		Proxy:        proxy,
+		conf:         conf,
 		visitors:     visitors,
 		create:       create,
 		legacyFormat: legacyFormat,
@@ -373,6 +377,11 @@ func (pd *EditProxyDialog) advancedProxyPage() TabPage {
 }
 
 func (pd *EditProxyDialog) pluginProxyPage() TabPage {
+	plugins := consts.PluginTypes
+	if pd.legacyFormat {
+		// The INI format has no virtual network plugin
+		plugins = lo.Without(plugins, consts.PluginVirtualNet)
+	}
 	return AlignGrid(TabPage{
 		Title:  i18n.Sprintf("Plugin"),
 		Layout: Grid{Columns: 2},
@@ -381,7 +390,7 @@ func (pd *EditProxyDialog) pluginProxyPage() TabPage {
 			ComboBox{
 				AssignTo:              &pd.pluginView,
 				Enabled:               Bind("vm.PluginEnable"),
-				Model:                 NewListModel(append([]string{""}, consts.PluginTypes...), i18n.Sprintf("None")),
+				Model:                 NewListModel(append([]string{""}, plugins...), i18n.Sprintf("None")),
 				Value:                 Bind("Plugin"),
 				BindingMember:         "Value",
 				DisplayMember:         "Title",
@@ -533,11 +542,30 @@ func (pd *EditProxyDialog) onSave() {
 	if ok := pd.validateProxy(pd.binder.Proxy); !ok {
 		return
 	}
-	*pd.Proxy = pd.binder.Proxy
-	pd.Proxy.Complete()
+	proxy := pd.binder.Proxy
+	proxy.Complete()
+	if !confirmDiagnostics(pd.Form(), pd.lintProxy(&proxy)) {
+		return
+	}
+	*pd.Proxy = proxy
 	pd.Accept()
 }
 
+// lintProxy returns the problems of the proxy within its config. The proxy is checked
+// after the other proxies, so the conflicts with them are reported on this proxy.
+func (pd *EditProxyDialog) lintProxy(proxy *config.Proxy) []config.Diagnostic {
+	if pd.conf == nil {
+		return nil
+	}
+	conf := *pd.conf
+	conf.Proxies = append(slices.DeleteFunc(slices.Clone(conf.Proxies), func(p *config.Proxy) bool {
+		return !pd.create && p == pd.Proxy
+	}), proxy)
+	return lo.Filter(config.Lint(&conf), func(d config.Diagnostic, i int) bool {
+		return d.Proxy == proxy.Name
+	})
+}
+
 func (pd *EditProxyDialog) hasProxy(name string) bool {
 	if pd.nameChecker(name) {
 		showWarningMessage(pd.Form(), i18n.Sprintf("Proxy already exists"), i18n.Sprintf("The proxy name \"%s\" already exists.", name))
@@ -82,6 +82,14 @@ func (m *ConfListModel) Add(item ...*Conf) {
 	setConfOrder(m.items)
 }
 
+// Reset replaces all items of the model.
+func (m *ConfListModel) Reset(items []*Conf) {
+	m.Lock()
+	defer m.Unlock()
+	m.items = items
+	m.PublishRowsReset()
+}
+
 func (m *ConfListModel) Remove(index ...int) {
 	if len(index) == 0 {
 		return
@@ -185,7 +185,7 @@ func (pv *PanelView) StartService(conf *Conf) error {
 	setConfState(conf, consts.ConfigStateStarting)
 	pv.setState(consts.ConfigStateStarting)
 	go func() {
-		if err := services.InstallService(conf.Name(), conf.Path, !conf.Data.AutoStart()); err != nil {
+		if err := services.InstallService(conf.Name(), profiles.Root(), conf.Path, !conf.Data.AutoStart()); err != nil {
 			pv.Synchronize(func() {
 				showErrorMessage(pv.Form(), i18n.Sprintf("Start config \"%s\"", conf.Name()), err.Error())
 				if conf.State == consts.ConfigStateStarting {
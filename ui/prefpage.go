@@ -1,8 +1,13 @@
 package ui
 
 import (
+	"archive/zip"
 	"math"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/lxn/walk"
 	. "github.com/lxn/walk/declarative"
@@ -10,24 +15,32 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/koho/frpmgr/i18n"
+	"github.com/koho/frpmgr/pkg/config"
 	"github.com/koho/frpmgr/pkg/consts"
+	"github.com/koho/frpmgr/pkg/profile"
 	"github.com/koho/frpmgr/pkg/res"
 	"github.com/koho/frpmgr/pkg/sec"
 	"github.com/koho/frpmgr/pkg/validators"
+	"github.com/koho/frpmgr/services"
 )
 
 type PrefPage struct {
 	*walk.TabPage
 
 	usePassword *walk.CheckBox
+	useVault    *walk.CheckBox
+
+	// confView is reloaded after restoring a backup
+	confView *ConfView
 }
 
-func NewPrefPage() *PrefPage {
-	return new(PrefPage)
+func NewPrefPage(confView *ConfView) *PrefPage {
+	return &PrefPage{confView: confView}
 }
 
 func (pp *PrefPage) OnCreate() {
 	pp.usePassword.CheckedChanged().Attach(pp.switchPassword)
+	pp.useVault.CheckedChanged().Attach(pp.switchVault)
 }
 
 func (pp *PrefPage) Page() TabPage {
@@ -38,6 +51,7 @@ func (pp *PrefPage) Page() TabPage {
 		Children: []Widget{
 			pp.passwordSection(),
 			pp.languageSection(),
+			pp.backupSection(),
 			pp.advancedSection(),
 			VSpacer{},
 		},
@@ -73,6 +87,13 @@ func (pp *PrefPage) passwordSection() GroupBox {
 					HSpacer{},
 				},
 			},
+			CheckBox{
+				AssignTo: &pp.useVault,
+				Row:      3, Column: 1,
+				Text:    i18n.Sprintf("Encrypt secrets in configs with master password"),
+				Checked: appConf.Vault != nil,
+				Enabled: Bind("usePwd.Checked"),
+			},
 		},
 	}
 }
@@ -132,6 +153,205 @@ func (pp *PrefPage) languageSection() GroupBox {
 	}
 }
 
+func (pp *PrefPage) backupSection() GroupBox {
+	return GroupBox{
+		Title:  i18n.Sprintf("Backup"),
+		Layout: Grid{Alignment: AlignHNearVCenter, Columns: 2},
+		Children: []Widget{
+			ImageView{Image: loadIcon(res.IconExport, 32)},
+			Label{Text: i18n.Sprintf("You can back up all configs, logs and settings to a file,\nand restore them on this or another computer.")},
+			HSpacer{Size: 42},
+			Composite{
+				Layout: HBox{Margins: Margins{Top: 5, Bottom: 5}},
+				Children: []Widget{
+					PushButton{Text: i18n.SprintfEllipsis("Back Up"), MinSize: Size{Width: 100}, OnClicked: pp.onBackup},
+					PushButton{Text: i18n.SprintfEllipsis("Restore"), MinSize: Size{Width: 100}, OnClicked: pp.onRestore},
+					PushButton{Text: i18n.SprintfEllipsis("Location"), MinSize: Size{Width: 100}, OnClicked: pp.onMoveData},
+					PushButton{
+						Text:    i18n.SprintfEllipsis("Schedule"),
+						MinSize: Size{Width: 100},
+						OnClicked: func() {
+							if r, err := pp.setBackupSchedule(); err == nil && r == win.IDOK {
+								if err = saveAppConfig(); err != nil {
+									showError(err, pp.Form())
+									return
+								}
+								pp.confView.runSchedules(true)
+							}
+						},
+					},
+					HSpacer{},
+				},
+			},
+		},
+	}
+}
+
+func (pp *PrefPage) onBackup() {
+	dlg := walk.FileDialog{
+		Filter:   res.FilterZip,
+		Title:    i18n.Sprintf("Back Up"),
+		FilePath: "frpmgr-backup-" + time.Now().Format("20060102") + ".zip",
+	}
+	if ok, _ := dlg.ShowSave(pp.Form()); !ok {
+		return
+	}
+	if !strings.HasSuffix(dlg.FilePath, ".zip") {
+		dlg.FilePath += ".zip"
+	}
+	f, err := os.Create(dlg.FilePath)
+	if err != nil {
+		showError(err, pp.Form())
+		return
+	}
+	err = profiles.Backup(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		showError(err, pp.Form())
+		return
+	}
+	showInfoMessage(pp.Form(), i18n.Sprintf("Back Up"), i18n.Sprintf("The backup is saved."))
+}
+
+func (pp *PrefPage) onRestore() {
+	dlg := walk.FileDialog{
+		Filter: res.FilterZip,
+		Title:  i18n.Sprintf("Restore"),
+	}
+	if ok, _ := dlg.ShowOpen(pp.Form()); !ok {
+		return
+	}
+	if walk.MsgBox(pp.Form(), i18n.Sprintf("Restore"),
+		i18n.Sprintf("The configs and settings in the backup will replace the current ones.\nDo you want to continue?"),
+		walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+		return
+	}
+	zr, err := zip.OpenReader(dlg.FilePath)
+	if err != nil {
+		showError(err, pp.Form())
+		return
+	}
+	// The vault of the backup is opened with the master password of the backup
+	var password string
+	if app, err := profile.BackupApp(&zr.Reader); err == nil && app != nil && app.Vault != nil {
+		if password = pp.askPassword(app.Password); password == "" {
+			zr.Close()
+			return
+		}
+	}
+	list, err := profiles.RestoreBackup(&zr.Reader, password)
+	zr.Close()
+	if reloadErr := pp.confView.Reload(); err == nil {
+		err = reloadErr
+	}
+	if err != nil {
+		showError(err, pp.Form())
+		return
+	}
+	go func() {
+		err := services.InstallRestoredServices(profiles.Root(), list)
+		pp.Synchronize(func() {
+			if err != nil {
+				showError(err, pp.Form())
+			}
+			showInfoMessage(pp.Form(), i18n.Sprintf("Restore"),
+				i18n.Sprintf("Restored %d configs. Some settings take effect after restarting the program.", len(list)))
+		})
+	}()
+}
+
+// onMoveData moves all data to the data location selected by user.
+func (pp *PrefPage) onMoveData() {
+	path, err := os.Executable()
+	if err != nil {
+		showError(err, pp.Form())
+		return
+	}
+	exeDir := filepath.Dir(path)
+	current, err := config.LoadLocation(exeDir)
+	if err != nil {
+		showError(err, pp.Form())
+		return
+	}
+	vm := struct{ Location string }{string(current)}
+	texts := map[config.DataLocation]string{
+		config.LocationPortable: i18n.Sprintf("Next to the program (portable)"),
+		config.LocationMachine:  i18n.Sprintf("Shared by all users"),
+		config.LocationUser:     i18n.Sprintf("Current user only"),
+	}
+	buttons := lo.FilterMap(config.Locations, func(loc config.DataLocation, i int) (RadioButton, bool) {
+		root, err := loc.Root(exeDir)
+		return RadioButton{Text: texts[loc] + " (" + root + ")", Value: string(loc)}, err == nil
+	})
+	r, err := NewBasicDialog(nil, i18n.Sprintf("Data Location"), loadIcon(res.IconMove, 32),
+		DataBinder{DataSource: &vm}, nil,
+		Label{Text: i18n.Sprintf("Configs, logs and settings are stored in:")},
+		Composite{
+			Layout:   VBox{MarginsZero: true},
+			MinSize:  Size{Width: 350},
+			Children: []Widget{RadioButtonGroup{DataMember: "Location", Buttons: buttons}},
+		},
+		VSpacer{Size: 4},
+	).Run(pp.Form())
+	if err != nil || r != win.IDOK || vm.Location == string(current) {
+		return
+	}
+	if walk.MsgBox(pp.Form(), i18n.Sprintf("Data Location"),
+		i18n.Sprintf("All data will be moved, and running configs will be restarted.\nDo you want to continue?"),
+		walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+		return
+	}
+	repo, err := services.MoveDataRoot(profiles, config.DataLocation(vm.Location))
+	if repo != nil {
+		if openErr := openDataRoot(repo.Root()); err == nil {
+			err = openErr
+		}
+		profiles.SetVault(repo.Vault())
+		if reloadErr := pp.confView.Reload(); err == nil {
+			err = reloadErr
+		}
+	}
+	if err != nil {
+		showError(err, pp.Form())
+		return
+	}
+	showInfoMessage(pp.Form(), i18n.Sprintf("Data Location"), i18n.Sprintf("All data is moved to %s.", repo.Root()))
+}
+
+func (pp *PrefPage) setBackupSchedule() (int, error) {
+	vm := appConf.Backup
+	if vm.Interval <= 0 {
+		vm.Interval = int(profile.DefaultBackupInterval / time.Hour)
+	}
+	if vm.Keep <= 0 {
+		vm.Keep = profile.DefaultBackupRetention
+	}
+	var w *walk.Dialog
+	r, err := NewBasicDialog(&w, i18n.Sprintf("Scheduled Backup"), loadIcon(res.IconExport, 32),
+		DataBinder{DataSource: &vm}, nil,
+		Composite{
+			Layout:  Grid{Columns: 2, MarginsZero: true},
+			MinSize: Size{Width: 350},
+			Children: []Widget{
+				Label{Text: i18n.SprintfColon("Directory")},
+				NewBrowseLineEdit(nil, nil, nil, Bind("Dir"), i18n.Sprintf("Select a directory to save backups."), "", false),
+				Label{Text: i18n.SprintfColon("Interval")},
+				NewNumberInput(NIOption{Value: Bind("Interval"), Suffix: i18n.Sprintf("Hours"), Min: 1, Max: math.MaxFloat64}),
+				Label{Text: i18n.SprintfColon("Keep")},
+				NewNumberInput(NIOption{Value: Bind("Keep"), Suffix: i18n.Sprintf("Backups"), Min: 1, Max: math.MaxFloat64}),
+			},
+		},
+		Label{Text: i18n.Sprintf("* Leave the directory empty to disable scheduled backups.")},
+		VSpacer{Size: 4},
+	).Run(pp.Form())
+	if err == nil && r == win.IDOK {
+		appConf.Backup = lo.Ternary(vm.Dir == "", config.BackupConfig{}, vm)
+	}
+	return r, err
+}
+
 func (pp *PrefPage) advancedSection() GroupBox {
 	return GroupBox{
 		Title:  i18n.Sprintf("Advanced"),
@@ -167,6 +387,11 @@ func (pp *PrefPage) switchPassword() {
 			pp.usePassword.SetChecked(false)
 		}
 	} else {
+		if appConf.Vault != nil {
+			showWarningMessage(pp.Form(), "", i18n.Sprintf("Disable the encryption of secrets before removing the master password."))
+			pp.usePassword.SetChecked(true)
+			return
+		}
 		if appConf.Password != "" {
 			appConf.Password = ""
 			if err := saveAppConfig(); err != nil {
@@ -200,10 +425,25 @@ func (pp *PrefPage) changePassword() string {
 			},
 		}, VSpacer{}).Run(pp.Form())
 	if vm.Password != "" {
-		oldPassword := appConf.Password
-		appConf.Password = sec.EncryptPassword(vm.Password)
-		if err := saveAppConfig(); err != nil {
-			appConf.Password = oldPassword
+		hashed, err := sec.HashPassword(vm.Password)
+		if err != nil {
+			showError(err, pp.Form())
+			return ""
+		}
+		oldPassword, oldVault := appConf.Password, appConf.Vault
+		appConf.Password = hashed
+		// The vault key is encrypted with the new password
+		if vault := profiles.Vault(); vault != nil && appConf.Vault != nil {
+			key, err := vault.Wrap(vm.Password)
+			if err != nil {
+				appConf.Password = oldPassword
+				showError(err, pp.Form())
+				return ""
+			}
+			appConf.Vault = &config.VaultConfig{Key: key, MachineKey: appConf.Vault.MachineKey}
+		}
+		if err = saveAppConfig(); err != nil {
+			appConf.Password, appConf.Vault = oldPassword, oldVault
 			showError(err, pp.Form())
 		} else {
 			showInfoMessage(pp.Form(), "", i18n.Sprintf("Password is set."))
@@ -212,6 +452,56 @@ func (pp *PrefPage) changePassword() string {
 	return vm.Password
 }
 
+func (pp *PrefPage) switchVault() {
+	if pp.useVault.Checked() == (appConf.Vault != nil) {
+		return
+	}
+	var err error
+	if pp.useVault.Checked() {
+		password := pp.askPassword(appConf.Password)
+		if password == "" {
+			pp.useVault.SetChecked(false)
+			return
+		}
+		err = enableVault(password)
+	} else {
+		err = disableVault()
+	}
+	if err != nil {
+		showError(err, pp.Form())
+	}
+	pp.useVault.SetChecked(appConf.Vault != nil)
+}
+
+// askPassword asks for the master password of the given hash. It returns an empty string if canceled.
+func (pp *PrefPage) askPassword(hash string) string {
+	var vm struct {
+		Password string
+	}
+	for {
+		NewBasicDialog(nil, i18n.Sprintf("Master password"), loadIcon(res.IconKey, 32),
+			DataBinder{
+				DataSource:     &vm,
+				ErrorPresenter: validators.SilentToolTipErrorPresenter{},
+			}, nil, Composite{
+				Layout:  VBox{MarginsZero: true},
+				MinSize: Size{Width: 280},
+				Children: []Widget{
+					Label{Text: i18n.SprintfColon("Password")},
+					LineEdit{Text: Bind("Password", res.ValidateNonEmpty), PasswordMode: true},
+				},
+			}, VSpacer{}).Run(pp.Form())
+		if vm.Password == "" {
+			return ""
+		}
+		if ok, _ := sec.VerifyPassword(vm.Password, hash); ok {
+			return vm.Password
+		}
+		showErrorMessage(pp.Form(), "", i18n.Sprintf("The password is incorrect. Re-enter password."))
+		vm.Password = ""
+	}
+}
+
 func (pp *PrefPage) switchLanguage(lc string) {
 	appConf.Lang = lc
 	if err := saveAppConfig(); err != nil {
@@ -222,6 +512,9 @@ func (pp *PrefPage) switchLanguage(lc string) {
 func (pp *PrefPage) setAdvancedSettings() (int, error) {
 	var w *walk.Dialog
 	var dbs [2]*walk.DataBinder
+	if appConf.Defaults.Format == "" {
+		appConf.Defaults.Format = consts.FormatTOML
+	}
 	dlg := NewBasicDialog(&w, i18n.Sprintf("Advanced"),
 		loadIcon(res.IconSettings, 32),
 		DataBinder{}, func() {
@@ -271,6 +564,13 @@ func (pp *PrefPage) setAdvancedSettings() (int, error) {
 						LineEdit{Text: Bind("NatHoleSTUNServer")},
 						Label{Text: i18n.SprintfColon("Source Address")},
 						LineEdit{Text: Bind("ConnectServerLocalIP")},
+						Label{Text: i18n.SprintfColon("File Format")},
+						ComboBox{
+							Value:         Bind("Format"),
+							Model:         NewListModel(consts.Formats, "TOML", "YAML", "JSON"),
+							BindingMember: "Value",
+							DisplayMember: "Title",
+						},
 						Composite{
 							Layout: VBox{MarginsZero: true, SpacingZero: true},
 							Children: []Widget{
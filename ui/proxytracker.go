@@ -32,7 +32,7 @@ type ProxyTracker struct {
 func NewProxyTracker(owner walk.Form, model *ProxyModel, refresh bool) (tracker *ProxyTracker) {
 	cache := make(map[string]*config.Proxy)
 	ctx, cancel := context.WithCancel(context.Background())
-	client := ipc.NewPipeClient(services.ServiceNameOfClient(model.conf.Path), func() []string {
+	client := ipc.NewClient(ipc.NewTransport(services.ServiceNameOfClient(model.conf.Path)), func() []string {
 		tracker.RLock()
 		defer tracker.RUnlock()
 		names := make([]string, 0, len(cache))
@@ -15,6 +15,7 @@ import (
 	"github.com/koho/frpmgr/pkg/config"
 	"github.com/koho/frpmgr/pkg/consts"
 	"github.com/koho/frpmgr/pkg/res"
+	"github.com/koho/frpmgr/pkg/sharelink"
 	"github.com/koho/frpmgr/pkg/util"
 )
 
@@ -371,6 +372,20 @@ func (pv *ProxyView) createProxyTable() TableView {
 				Image:       loadIcon(res.IconNat, 16),
 				OnTriggered: pv.onCopyAccessAddr,
 			},
+			Menu{
+				Enabled: Bind("proxy.SelectedCount > 0"),
+				Text:    i18n.Sprintf("Share Selected Proxies"),
+				Items: []MenuItem{
+					Action{
+						Text:        i18n.Sprintf("Copy Share Link"),
+						OnTriggered: func() { pv.onCopyShareLink(false) },
+					},
+					Action{
+						Text:        i18n.Sprintf("Copy Visitor Share Link"),
+						OnTriggered: func() { pv.onCopyShareLink(true) },
+					},
+				},
+			},
 			Action{
 				Enabled: Bind("proxy.SelectedCount < proxy.ItemCount"),
 				Text:    i18n.Sprintf("Select all"),
@@ -529,6 +544,24 @@ func (pv *ProxyView) onClipboardImport() {
 	pv.onEdit(proxy, true)
 }
 
+// onCopyShareLink copies a share link of the selected proxies. Secrets are replaced with
+// placeholders of environment variables, except the secret keys required by visitors.
+func (pv *ProxyView) onCopyShareLink(visitor bool) {
+	if pv.model == nil {
+		return
+	}
+	opts := config.ShareOptions{VisitorSide: visitor, Redaction: config.RedactPlaceholder, KeepSecretKeys: true}
+	for _, idx := range pv.table.SelectedIndexes() {
+		opts.Proxies = append(opts.Proxies, pv.model.items[idx].Name)
+	}
+	link, err := config.ShareLink(pv.model.data, opts, sharelink.Options{})
+	if err != nil {
+		showError(err, pv.Form())
+		return
+	}
+	walk.Clipboard().SetText(link)
+}
+
 func (pv *ProxyView) onEditCopy() {
 	idx := pv.table.CurrentIndex()
 	if idx < 0 || pv.model == nil {
@@ -578,7 +611,7 @@ func (pv *ProxyView) onEdit(proxy *config.Proxy, create bool) {
 		oldName = proxy.Name
 		oldAliasLen = len(proxy.GetAlias())
 	}
-	dlg := NewEditProxyDialog(proxy, pv.visitors(except), create, pv.model.data.LegacyFormat, pv.model.HasName)
+	dlg := NewEditProxyDialog(proxy, pv.model.data, pv.visitors(except), create, pv.model.data.LegacyFormat, pv.model.HasName)
 	if result, _ := dlg.Run(pv.Form()); result == walk.DlgCmdOK {
 		if create {
 			pv.model.Add(dlg.Proxy)
@@ -1,7 +1,6 @@
 package ui
 
 import (
-	"os"
 	"path/filepath"
 	"strings"
 	"unsafe"
@@ -13,6 +12,7 @@ import (
 	"golang.org/x/sys/windows"
 
 	"github.com/koho/frpmgr/i18n"
+	"github.com/koho/frpmgr/pkg/config"
 	"github.com/koho/frpmgr/pkg/res"
 	"github.com/koho/frpmgr/pkg/util"
 )
@@ -63,30 +63,32 @@ func (fm *FRPManager) idealSize(empty bool) walk.Size {
 }
 
 func RunUI() error {
-	var err error
-	// Make sure the config directory exists.
-	if err = os.MkdirAll(PathOfConf(""), os.ModePerm); err != nil {
-		return err
-	}
-	if err = os.MkdirAll("stores", os.ModePerm); err != nil {
+	root, err := config.DataRoot()
+	if err != nil {
 		return err
 	}
-	cfgList, err := loadAllConfs()
-	if err != nil {
+	if err = openDataRoot(root); err != nil {
 		return err
 	}
+	loadAppConf()
+	vd := NewValidateDialog()
 	if appConf.Password != "" {
-		if r, err := NewValidateDialog().Run(); err != nil || r != win.IDOK {
+		if r, err := vd.Run(); err != nil || r != win.IDOK {
 			return err
 		}
 	}
+	// Secrets are only decrypted with the master password
+	cfgList, err := loadAllConfs(vd.password)
+	if err != nil {
+		return err
+	}
 	fm := new(FRPManager)
 	fm.confPage = NewConfPage(cfgList)
 	fm.logPage, err = NewLogPage()
 	if err != nil {
 		return err
 	}
-	fm.prefPage = NewPrefPage()
+	fm.prefPage = NewPrefPage(fm.confPage.confView)
 	fm.aboutPage = NewAboutPage()
 	mw := MainWindow{
 		Icon:       loadLogoIcon(32),
@@ -172,6 +174,21 @@ func showWarningMessage(owner walk.Form, title, message string) {
 	walk.MsgBox(owner, title, message, walk.MsgBoxIconWarning)
 }
 
+// confirmDiagnostics shows the problems found in a config, and asks whether to save it anyway.
+// It returns true if there is no problem.
+func confirmDiagnostics(owner walk.Form, diags []config.Diagnostic) bool {
+	if len(diags) == 0 {
+		return true
+	}
+	lines := lo.Map(diags, func(item config.Diagnostic, index int) string {
+		return item.String()
+	})
+	return walk.MsgBox(owner, i18n.Sprintf("Config Check"),
+		i18n.Sprintf("The following problems are found in the config:\n\n%s\n\n"+
+			"Are you sure you would like to save it?", strings.Join(lines, "\n")),
+		walk.MsgBoxYesNo|walk.MsgBoxIconWarning) == walk.DlgCmdYes
+}
+
 func showInfoMessage(owner walk.Form, title, message string) {
 	if title == "" {
 		title = AppLocalName
@@ -31,12 +31,16 @@ type URLImportDialog struct {
 }
 
 type urlImportViewModel struct {
-	URLs    string
-	Working bool
+	URLs string
+	// Subscribe defines whether to refresh the imported configs from the URLs
+	Subscribe bool
+	Working   bool
 }
 
 // URLConf provides config data downloaded from URL
 type URLConf struct {
+	// URL is the source of the file
+	URL string
 	// Filename is the name of the downloaded file
 	Filename string
 	// Zip defines whether the Data is a zip file
@@ -53,12 +57,18 @@ func (ud *URLImportDialog) Run(owner walk.Form) (int, error) {
 	return NewBasicDialog(&ud.Dialog, i18n.Sprintf("Import from URL"), loadIcon(res.IconURLImport, 32),
 		DataBinder{AssignTo: &ud.db, DataSource: &ud.viewModel, Name: "vm"}, ud.onImport,
 		Label{Text: i18n.Sprintf("* Support batch import, one link per line.")},
+		Label{Text: i18n.Sprintf("* Append \"#sha256=<checksum>\" to a link to verify the file.")},
 		TextEdit{
 			Enabled: Bind("!vm.Working"),
 			Text:    Bind("URLs", res.ValidateNonEmpty),
 			VScroll: true,
 			MinSize: Size{Width: 430, Height: 130},
 		},
+		CheckBox{
+			Enabled: Bind("!vm.Working"),
+			Text:    i18n.Sprintf("Keep configs updated from the URLs"),
+			Checked: Bind("Subscribe"),
+		},
 		Label{
 			AssignTo:     &ud.statusText,
 			Text:         fmt.Sprintf("%s: %s", i18n.Sprintf("Status"), i18n.Sprintf("Ready")),
@@ -105,7 +115,12 @@ func (ud *URLImportDialog) urlImport(ctx context.Context, wg *sync.WaitGroup, ur
 		ud.statusText.SetText(fmt.Sprintf("%s: [%d/%d] %s %s",
 			i18n.Sprintf("Status"), i+1, len(urls), i18n.Sprintf("Download"), url,
 		))
-		filename, mediaType, data, err := util.DownloadFile(ctx, url)
+		opts, err := appConf.Download.Options(url, profiles.Vault())
+		if err != nil {
+			showError(err, ud.Form())
+			continue
+		}
+		file, err := util.Download(ctx, url, opts)
 		if errors.Is(err, context.Canceled) {
 			result = walk.DlgCmdCancel
 			return
@@ -114,9 +129,10 @@ func (ud *URLImportDialog) urlImport(ctx context.Context, wg *sync.WaitGroup, ur
 			continue
 		}
 		ud.Items = append(ud.Items, URLConf{
-			Filename: filename,
-			Zip:      mediaType == "application/zip" || strings.ToLower(filepath.Ext(filename)) == ".zip",
-			Data:     data,
+			URL:      url,
+			Filename: file.Filename,
+			Zip:      file.MediaType == "application/zip" || strings.ToLower(filepath.Ext(file.Filename)) == ".zip",
+			Data:     file.Data,
 		})
 	}
 }
@@ -20,6 +20,8 @@ const stmSetIcon = 0x0170
 // ValidateDialog validates the administration password.
 type ValidateDialog struct {
 	hIcon win.HICON
+	// password is the validated password, which opens the vault.
+	password string
 }
 
 func NewValidateDialog() *ValidateDialog {
@@ -55,11 +57,16 @@ func (vd *ValidateDialog) proc(h win.HWND, msg uint32, wp, lp uintptr) uintptr {
 		switch win.LOWORD(uint32(wp)) {
 		case win.IDOK:
 			passwd := GetWindowText(win.GetDlgItem(h, res.DialogEdit))
-			if sec.EncryptPassword(passwd) != appConf.Password {
+			ok, upgrade := sec.VerifyPassword(passwd, appConf.Password)
+			if !ok {
 				win.MessageBox(h, windows.StringToUTF16Ptr(i18n.Sprintf("The password is incorrect. Re-enter password.")),
 					windows.StringToUTF16Ptr(AppLocalName), windows.MB_ICONERROR)
 				win.SetFocus(win.GetDlgItem(h, res.DialogEdit))
 			} else {
+				if upgrade {
+					upgradePassword(passwd)
+				}
+				vd.password = passwd
 				win.EndDialog(h, win.IDOK)
 			}
 		case win.IDCANCEL:
@@ -114,3 +121,17 @@ func GetWindowText(hWnd win.HWND) string {
 	win.SendMessage(hWnd, win.WM_GETTEXT, textLength+1, uintptr(unsafe.Pointer(&buf[0])))
 	return syscall.UTF16ToString(buf)
 }
+
+// upgradePassword replaces a legacy or weak password hash with a new one.
+// The old hash is kept if the new one can't be saved, as it still works.
+func upgradePassword(password string) {
+	hashed, err := sec.HashPassword(password)
+	if err != nil {
+		return
+	}
+	oldPassword := appConf.Password
+	appConf.Password = hashed
+	if err = saveAppConfig(); err != nil {
+		appConf.Password = oldPassword
+	}
+}